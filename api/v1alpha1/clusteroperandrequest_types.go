@@ -0,0 +1,160 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ClusterOperandRequestStatus defines the observed state of ClusterOperandRequest.
+type ClusterOperandRequestStatus struct {
+	// Phase is the cluster running phase, rolled up from the generated OperandRequests.
+	// +optional
+	Phase ClusterPhase `json:"phase,omitempty"`
+	// Conditions represents the current state of the ClusterOperandRequest.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+	// OperandRequests are the OperandRequests generated in the namespaces of the OperandRegistries.
+	// +optional
+	OperandRequests []GeneratedRequest `json:"operandRequests,omitempty"`
+	// Members represents the current operand status of the generated OperandRequests.
+	// +optional
+	Members []MemberStatus `json:"members,omitempty"`
+}
+
+// GeneratedRequest is an OperandRequest generated from a ClusterOperandRequest.
+type GeneratedRequest struct {
+	// Namespace of the OperandRequest, which is the namespace of its OperandRegistries.
+	Namespace string `json:"namespace"`
+	// Name of the OperandRequest.
+	Name string `json:"name"`
+	// Phase of the OperandRequest.
+	// +optional
+	Phase ClusterPhase `json:"phase,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ClusterOperandRequest requests operators for the whole cluster, without an owner namespace.
+// ODLM generates an OperandRequest in the namespace of each requested OperandRegistry, which must allow cluster requests.
+// +kubebuilder:resource:path=clusteroperandrequests,shortName=copreq,scope=Cluster
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=.status.phase
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=.metadata.creationTimestamp
+// +operator-sdk:csv:customresourcedefinitions:displayName="ClusterOperandRequest"
+type ClusterOperandRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The registryNamespace of each request is required, and the operands can set their instanceNamespace.
+	Spec   OperandRequestSpec          `json:"spec,omitempty"`
+	Status ClusterOperandRequestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterOperandRequestList contains a list of ClusterOperandRequest.
+type ClusterOperandRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterOperandRequest `json:"items"`
+}
+
+// GetRegistryKeys returns the keys of the OperandRegistries requested from the ClusterOperandRequest,
+// including the fallback OperandRegistries.
+func (r *ClusterOperandRequest) GetRegistryKeys() []types.NamespacedName {
+	var keys []types.NamespacedName
+	for _, req := range r.Spec.Requests {
+		for _, key := range GetRequestRegistryKeys(req) {
+			if !containsRegistryKey(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// GetRequestRegistryKeys returns the keys of the OperandRegistry and the fallback OperandRegistries of a request
+// of a ClusterOperandRequest, in order. Their namespaces are not defaulted.
+func GetRequestRegistryKeys(req Request) []types.NamespacedName {
+	keys := []types.NamespacedName{{Namespace: req.RegistryNamespace, Name: req.Registry}}
+	for _, fallback := range req.FallbackRegistries {
+		keys = append(keys, types.NamespacedName{Namespace: fallback.Namespace, Name: fallback.Name})
+	}
+	return keys
+}
+
+// SetNotAllowedCondition creates a Condition to claim the OperandRegistry doesn't allow cluster requests.
+// A condition that is no longer true is only updated when it exists.
+func (r *ClusterOperandRequest) SetNotAllowedCondition(registryKey types.NamespacedName, reason string, cs corev1.ConditionStatus) {
+	c := newCondition(ConditionNotAllowed, cs, reason, "OperandRegistry "+registryKey.String()+" doesn't allow the request")
+	pos, cp := getCondition(&r.Status.Conditions, c.Type, c.Message)
+	if cp == nil && cs != corev1.ConditionTrue {
+		return
+	} else if cp != nil && cp.Status == cs && cp.Reason == c.Reason {
+		c.LastTransitionTime = cp.LastTransitionTime
+		c.LastUpdateTime = cp.LastUpdateTime
+		r.Status.Conditions[pos] = *c
+		return
+	}
+	if cp != nil {
+		r.Status.Conditions[pos] = *c
+	} else {
+		r.Status.Conditions = append(r.Status.Conditions, *c)
+	}
+}
+
+// SetGeneratedRequests records the generated OperandRequests, sorted by namespace, and rolls their phases and members up.
+func (r *ClusterOperandRequest) SetGeneratedRequests(requests []OperandRequest) {
+	r.Status.OperandRequests, r.Status.Phase = rollUpGeneratedRequests(requests)
+	r.Status.Members = nil
+	for _, req := range requests {
+		r.Status.Members = append(r.Status.Members, req.Status.Members...)
+	}
+}
+
+// rollUpGeneratedRequests returns the generated OperandRequests and their rolled up phase.
+// A failed OperandRequest takes precedence over one not running yet, whose phase is the rolled up phase.
+func rollUpGeneratedRequests(requests []OperandRequest) ([]GeneratedRequest, ClusterPhase) {
+	var generated []GeneratedRequest
+	phase := ClusterPhaseNone
+	if len(requests) > 0 {
+		phase = ClusterPhaseRunning
+	}
+	for _, req := range requests {
+		generated = append(generated, GeneratedRequest{Namespace: req.Namespace, Name: req.Name, Phase: req.Status.Phase})
+		switch req.Status.Phase {
+		case ClusterPhaseRunning:
+		case ClusterPhaseFailed:
+			phase = ClusterPhaseFailed
+		default:
+			if phase == ClusterPhaseRunning {
+				phase = req.Status.Phase
+				if phase == "" {
+					phase = ClusterPhaseNone
+				}
+			}
+		}
+	}
+	return generated, phase
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterOperandRequest{}, &ClusterOperandRequestList{})
+}
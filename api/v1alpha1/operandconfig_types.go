@@ -17,6 +17,8 @@
 package v1alpha1
 
 import (
+	"encoding/json"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -29,6 +31,11 @@ type OperandConfigSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Operand Services Config List"
 	// +optional
 	Services []ConfigService `json:"services,omitempty"`
+	// RevisionHistoryLimit is the number of the previously applied specs kept as ControllerRevisions for rollback.
+	// The default value is 10.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
 }
 
 // ConfigService defines the configuration of the service.
@@ -42,6 +49,14 @@ type ConfigService struct {
 	// Resources is used to specify the kubernetes resources that are needed for the service.
 	// +optional
 	Resources []ConfigResource `json:"resources,omitempty"`
+	// Examples are the custom resource templates used in place of the alm-examples annotation
+	// when the operator is installed by an OLM v1 ClusterExtension, which has no ClusterServiceVersion.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Examples []runtime.RawExtension `json:"examples,omitempty"`
+	// Timeouts overrides the timeouts and requeue intervals of the OperandRegistry operator for the service.
+	// +optional
+	Timeouts *Timeouts `json:"timeouts,omitempty"`
 }
 
 // ConfigResource defines the resource needed for the service
@@ -81,6 +96,9 @@ type OperandConfigStatus struct {
 	// ServiceStatus defines all the status of a operator.
 	// +optional
 	ServiceStatus map[string]CrStatus `json:"serviceStatus,omitempty"`
+	// CurrentRevision is the name of the ControllerRevision recording the applied spec.
+	// +optional
+	CurrentRevision string `json:"currentRevision,omitempty"`
 }
 
 // CrStatus defines the status of the custom resource.
@@ -127,6 +145,7 @@ const (
 	ServiceFailed   ServicePhase = "Failed"
 	ServiceInit     ServicePhase = "Initialized"
 	ServiceCreating ServicePhase = "Creating"
+	ServiceDeleting ServicePhase = "Deleting"
 	ServiceNone     ServicePhase = ""
 )
 
@@ -140,7 +159,27 @@ func (r *OperandConfig) GetService(operandName string) *ConfigService {
 	return nil
 }
 
-//InitConfigServiceStatus initializes service status in the OperandConfig instance.
+// GetRevisionHistoryLimit returns the number of the previously applied specs kept for rollback.
+func (r *OperandConfig) GetRevisionHistoryLimit() int32 {
+	if r.Spec.RevisionHistoryLimit == nil {
+		return DefaultRevisionHistoryLimit
+	}
+	return *r.Spec.RevisionHistoryLimit
+}
+
+// GetALMExamples returns the custom resource templates of the service in the alm-examples format.
+func (s *ConfigService) GetALMExamples() (string, error) {
+	if len(s.Examples) == 0 {
+		return "", nil
+	}
+	examples, err := json.Marshal(s.Examples)
+	if err != nil {
+		return "", err
+	}
+	return string(examples), nil
+}
+
+// InitConfigServiceStatus initializes service status in the OperandConfig instance.
 func (r *OperandConfig) InitConfigServiceStatus() {
 	r.Status.ServiceStatus = make(map[string]CrStatus)
 
@@ -160,11 +199,13 @@ func (r *OperandConfig) UpdateOperandPhase() {
 		runningNum  int
 		failedNum   int
 		creatingNum int
+		deletingNum int
 	}{
 		notReadyNum: 0,
 		runningNum:  0,
 		failedNum:   0,
 		creatingNum: 0,
+		deletingNum: 0,
 	}
 	for _, operator := range r.Status.ServiceStatus {
 		for _, service := range operator.CrStatus {
@@ -175,6 +216,8 @@ func (r *OperandConfig) UpdateOperandPhase() {
 				operandStatusStat.failedNum++
 			case ServiceCreating:
 				operandStatusStat.creatingNum++
+			case ServiceDeleting:
+				operandStatusStat.deletingNum++
 			}
 		}
 	}
@@ -182,6 +225,8 @@ func (r *OperandConfig) UpdateOperandPhase() {
 		r.Status.Phase = ServiceFailed
 	} else if operandStatusStat.creatingNum > 0 {
 		r.Status.Phase = ServiceCreating
+	} else if operandStatusStat.deletingNum > 0 {
+		r.Status.Phase = ServiceDeleting
 	} else if operandStatusStat.runningNum > 0 {
 		r.Status.Phase = ServiceRunning
 	} else {
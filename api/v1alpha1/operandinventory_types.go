@@ -0,0 +1,160 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Kinds of the resources recorded in the OperandInventory
+const (
+	InventoryKindSubscription     string = "Subscription"
+	InventoryKindClusterExtension string = "ClusterExtension"
+)
+
+// OperandInventorySpec defines the OperandRequests holding a reference on a Subscription or ClusterExtension managed by ODLM.
+type OperandInventorySpec struct {
+	// Kind of the managed resource, either Subscription or ClusterExtension.
+	// +kubebuilder:validation:Enum=Subscription;ClusterExtension
+	Kind string `json:"kind"`
+	// Name of the managed resource.
+	Name string `json:"name"`
+	// Namespace of the managed Subscription. It is empty for the cluster scoped ClusterExtension.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Owners are the OperandRequests holding a reference on the managed resource, in the order they were added.
+	// The OperandRegistry and OperandConfig of the first owner manage the operator and its operands.
+	// +optional
+	Owners []InventoryOwner `json:"owners,omitempty"`
+}
+
+// InventoryOwner is an OperandRequest holding a reference on the managed resource through an OperandRegistry.
+type InventoryOwner struct {
+	// Request is the namespace/name of the OperandRequest.
+	Request ReconcileRequest `json:"request"`
+	// Registry is the namespace/name of the OperandRegistry the operator is requested from.
+	Registry ReconcileRequest `json:"registry"`
+	// Operand is the name of the requested operand.
+	Operand string `json:"operand"`
+}
+
+// +kubebuilder:object:root=true
+
+// OperandInventory is the ownership record of a Subscription or ClusterExtension managed by ODLM.
+// +kubebuilder:resource:path=operandinventories,shortName=opinv,scope=Namespaced
+// +kubebuilder:printcolumn:name="Kind",type=string,JSONPath=.spec.kind
+// +kubebuilder:printcolumn:name="Resource",type=string,JSONPath=.spec.name
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=.metadata.creationTimestamp
+// +operator-sdk:csv:customresourcedefinitions:displayName="OperandInventory"
+type OperandInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec OperandInventorySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OperandInventoryList contains a list of OperandInventory.
+type OperandInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OperandInventory `json:"items"`
+}
+
+// NewInventoryOwner returns the owner of an operand requested by the OperandRequest from the OperandRegistry.
+func NewInventoryOwner(requestKey, registryKey types.NamespacedName, operand string) InventoryOwner {
+	return InventoryOwner{
+		Request:  ReconcileRequest{Name: requestKey.Name, Namespace: requestKey.Namespace},
+		Registry: ReconcileRequest{Name: registryKey.Name, Namespace: registryKey.Namespace},
+		Operand:  operand,
+	}
+}
+
+// GetRequestKey returns the namespace/name of the OperandRequest.
+func (o InventoryOwner) GetRequestKey() types.NamespacedName {
+	return types.NamespacedName{Namespace: o.Request.Namespace, Name: o.Request.Name}
+}
+
+// GetRegistryKey returns the namespace/name of the OperandRegistry.
+func (o InventoryOwner) GetRegistryKey() types.NamespacedName {
+	return types.NamespacedName{Namespace: o.Registry.Namespace, Name: o.Registry.Name}
+}
+
+// AddOwner appends the owner if it isn't recorded yet.
+// It will return true if the owners changed, otherwise return false.
+func (r *OperandInventory) AddOwner(owner InventoryOwner) bool {
+	for _, o := range r.Spec.Owners {
+		if o == owner {
+			return false
+		}
+	}
+	r.Spec.Owners = append(r.Spec.Owners, owner)
+	return true
+}
+
+// RemoveOwner removes the owner from the inventory.
+// It will return true if the owners changed, otherwise return false.
+func (r *OperandInventory) RemoveOwner(owner InventoryOwner) bool {
+	for i, o := range r.Spec.Owners {
+		if o == owner {
+			r.Spec.Owners = append(r.Spec.Owners[:i], r.Spec.Owners[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// GetManagingRegistry returns the OperandRegistry of the first owner, which manages the operator and its operands.
+func (r *OperandInventory) GetManagingRegistry() *types.NamespacedName {
+	if len(r.Spec.Owners) == 0 {
+		return nil
+	}
+	key := r.Spec.Owners[0].GetRegistryKey()
+	return &key
+}
+
+// GetRegistries returns the distinct OperandRegistries of the owners, in the order of the owners.
+func (r *OperandInventory) GetRegistries() []types.NamespacedName {
+	var registries []types.NamespacedName
+	seen := make(map[types.NamespacedName]bool)
+	for _, o := range r.Spec.Owners {
+		key := o.GetRegistryKey()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		registries = append(registries, key)
+	}
+	return registries
+}
+
+// GenerateAnnotations generates the registry, config and request annotations of the managed resource from the owners.
+func (r *OperandInventory) GenerateAnnotations() map[string]string {
+	annotations := make(map[string]string)
+	for _, o := range r.Spec.Owners {
+		annotations[o.Registry.Namespace+"."+o.Registry.Name+"/registry"] = "true"
+		annotations[o.Registry.Namespace+"."+o.Registry.Name+"/config"] = "true"
+		annotations[o.Request.Namespace+"."+o.Request.Name+"/request"] = "true"
+	}
+	return annotations
+}
+
+func init() {
+	SchemeBuilder.Register(&OperandInventory{}, &OperandInventoryList{})
+}
@@ -0,0 +1,125 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// OperandPolicySpec defines the desired state of OperandPolicy.
+type OperandPolicySpec struct {
+	// Namespaces are the names of the namespaces the policy applies to.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceSelector selects the namespaces the policy applies to, in addition to the namespaces.
+	// An empty selector selects all the namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Rules are the operators the OperandRequests of the namespaces may request.
+	// When the policies applying to a namespace have rules, it can only request the operators allowed by one of them.
+	// +optional
+	Rules []PolicyRule `json:"rules,omitempty"`
+	// MaxOperands is the maximum number of operands requested by the OperandRequests of each namespace.
+	// There is no limit when it is not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxOperands *int32 `json:"maxOperands,omitempty"`
+}
+
+// PolicyRule allows requesting operators from OperandRegistries.
+type PolicyRule struct {
+	// Registries are the OperandRegistries, in the namespace/name format, the operators can be requested from.
+	// All the OperandRegistries are allowed when it is empty.
+	// +optional
+	Registries []string `json:"registries,omitempty"`
+	// Operators are the names of the operators which can be requested. All the operators are allowed when it is empty.
+	// +optional
+	Operators []string `json:"operators,omitempty"`
+	// Kinds are the custom resource kinds, in the Kind.group format, the operands of an OperandRequest can create.
+	// The group is omitted for the core group, and * allows all the kinds. No kind is allowed when it is empty.
+	// +optional
+	Kinds []string `json:"kinds,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OperandPolicy controls which operators the OperandRequests of the namespaces can request, from which OperandRegistries,
+// which custom resources they can create, and how many operands they can request.
+// A namespace no OperandPolicy applies to is not restricted.
+// +kubebuilder:resource:path=operandpolicies,shortName=opp,scope=Cluster
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=.metadata.creationTimestamp
+// +operator-sdk:csv:customresourcedefinitions:displayName="OperandPolicy"
+type OperandPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec OperandPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OperandPolicyList contains a list of OperandPolicy.
+type OperandPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OperandPolicy `json:"items"`
+}
+
+// AppliesTo returns true when the policy applies to the namespace.
+func (p *OperandPolicy) AppliesTo(namespace string, namespaceLabels map[string]string) (bool, error) {
+	for _, ns := range p.Spec.Namespaces {
+		if ns == namespace {
+			return true, nil
+		}
+	}
+	if p.Spec.NamespaceSelector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(p.Spec.NamespaceSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(namespaceLabels)), nil
+}
+
+// Allows returns true when the rule allows requesting the operator from the OperandRegistry.
+func (r *PolicyRule) Allows(registry, operator string) bool {
+	return matchesPolicyItem(r.Registries, registry, true) && matchesPolicyItem(r.Operators, operator, true)
+}
+
+// AllowsKind returns true when the rule allows creating the custom resource kind, in the Kind.group format.
+func (r *PolicyRule) AllowsKind(kind string) bool {
+	return matchesPolicyItem(r.Kinds, kind, false)
+}
+
+// matchesPolicyItem returns true when the items contain the item or *, or when they are empty and allow all
+func matchesPolicyItem(items []string, item string, emptyAllowsAll bool) bool {
+	if len(items) == 0 {
+		return emptyAllowsAll
+	}
+	for _, i := range items {
+		if i == "*" || i == item {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	SchemeBuilder.Register(&OperandPolicy{}, &OperandPolicyList{})
+}
@@ -17,6 +17,8 @@
 package v1alpha1
 
 import (
+	"time"
+
 	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -53,9 +55,13 @@ type Operator struct {
 	// The target namespace of the OperatorGroups.
 	TargetNamespaces []string `json:"targetNamespaces,omitempty"`
 	// Name of the package that defines the applications.
-	PackageName string `json:"packageName"`
+	// It is required unless the operator overrides an operator of the base OperandRegistry.
+	// +optional
+	PackageName string `json:"packageName,omitempty"`
 	// Name of the channel to track.
-	Channel string `json:"channel"`
+	// The default channel of the package is tracked when it is not set.
+	// +optional
+	Channel string `json:"channel,omitempty"`
 	// Description of a common service.
 	// +optional
 	Description string `json:"description,omitempty"`
@@ -71,6 +77,39 @@ type Operator struct {
 	// SubscriptionConfig is used to override operator configuration.
 	// +optional
 	SubscriptionConfig *olmv1alpha1.SubscriptionConfig `json:"subscriptionConfig,omitempty"`
+	// The OLM API used to install the operator, either subscription or clusterextension.
+	// Valid values are:
+	// - "subscription" (default): operator is installed by an OLM v0 Subscription;
+	// - "clusterextension": operator is installed by an OLM v1 ClusterExtension;
+	// +kubebuilder:validation:Enum=subscription;clusterextension
+	// +optional
+	InstallBackend string `json:"installBackend,omitempty"`
+	// ServiceAccountName is the service account used by OLM v1 to install the ClusterExtension.
+	// It is only used when InstallBackend is "clusterextension".
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Version is the version range of the bundle installed by the ClusterExtension.
+	// It is only used when InstallBackend is "clusterextension".
+	// +optional
+	Version string `json:"version,omitempty"`
+	// DeletionPolicy defines what happens to the operator and its operands when it is no longer requested.
+	// Valid values are:
+	// - "Delete" (default): the custom resources, the k8s resources, the Subscription and the ClusterServiceVersion are deleted;
+	// - "Retain": the custom resources and the k8s resources are deleted, the Subscription and the ClusterServiceVersion are kept;
+	// - "Orphan": everything is kept and the ODLM labels are removed from the resources;
+	// The operator.ibm.com/opreq-do-not-uninstall label on a resource still prevents it from being deleted.
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+	// Timeouts overrides the global timeouts and requeue intervals for the operator and its operands.
+	// +optional
+	Timeouts *Timeouts `json:"timeouts,omitempty"`
+	// RollbackPolicy defines what happens when an upgrade of the operator fails.
+	// Valid values are:
+	// - "None" (default): the failed ClusterServiceVersion is kept and the operator is Failed;
+	// - "Automatic": the operator is reinstalled with the last succeeded ClusterServiceVersion;
+	// It is only used when InstallBackend is "subscription".
+	// +optional
+	RollbackPolicy RollbackPolicy `json:"rollbackPolicy,omitempty"`
 }
 
 // +kubebuilder:validation:Enum=public;private
@@ -92,12 +131,167 @@ const (
 	InstallModeNamespace string = "namespace"
 )
 
+const (
+	// InstallBackendSubscription means install the operator with an OLM v0 Subscription.
+	InstallBackendSubscription string = "subscription"
+	// InstallBackendClusterExtension means install the operator with an OLM v1 ClusterExtension.
+	InstallBackendClusterExtension string = "clusterextension"
+)
+
+// DeletionPolicy defines what happens to an operator when it is no longer requested.
+// +kubebuilder:validation:Enum=Delete;Retain;Orphan
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete means delete the operator and its operands.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain means keep the operator and delete its operands.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicyOrphan means keep the operator and its operands, and stop managing them.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// RollbackPolicy defines what happens when an upgrade of an operator fails.
+// +kubebuilder:validation:Enum=None;Automatic
+type RollbackPolicy string
+
+const (
+	// RollbackPolicyNone means keep the failed ClusterServiceVersion.
+	RollbackPolicyNone RollbackPolicy = "None"
+	// RollbackPolicyAutomatic means reinstall the last succeeded ClusterServiceVersion.
+	RollbackPolicyAutomatic RollbackPolicy = "Automatic"
+)
+
+// DefaultRevisionHistoryLimit is the default number of the previously applied specs kept for rollback.
+const DefaultRevisionHistoryLimit int32 = 10
+
+const (
+	// DefaultRolloutBatchSize is the default number of the OperandRequests in a batch of a rollout.
+	DefaultRolloutBatchSize int32 = 1
+	// DefaultRolloutBatchTimeoutSeconds is the default time a stage of a rollout has to reach Running.
+	DefaultRolloutBatchTimeoutSeconds int32 = 600
+)
+
+// Timeouts defines the timeouts and requeue intervals used to reconcile an operator and its operands.
+// An unset field falls back to the OperandRegistry operator and then to the global value set by the manager.
+type Timeouts struct {
+	// CRDeleteTimeout is how long to wait for a custom resource or a k8s resource to be deleted.
+	// +optional
+	CRDeleteTimeout *metav1.Duration `json:"crDeleteTimeout,omitempty"`
+	// SubDeleteTimeout is how long to wait for a Subscription, a ClusterServiceVersion or a ClusterExtension to be deleted.
+	// +optional
+	SubDeleteTimeout *metav1.Duration `json:"subDeleteTimeout,omitempty"`
+	// RequeueDuration is how long to wait before reconciling an OperandRequest that is not ready yet.
+	// +optional
+	RequeueDuration *metav1.Duration `json:"requeueDuration,omitempty"`
+	// SyncPeriod is how often a ready OperandRequest is reconciled.
+	// +optional
+	SyncPeriod *metav1.Duration `json:"syncPeriod,omitempty"`
+}
+
+// Merge returns a copy of the timeouts with the fields set in the override replaced.
+func (t Timeouts) Merge(override *Timeouts) Timeouts {
+	if override == nil {
+		return t
+	}
+	if override.CRDeleteTimeout != nil {
+		t.CRDeleteTimeout = override.CRDeleteTimeout.DeepCopy()
+	}
+	if override.SubDeleteTimeout != nil {
+		t.SubDeleteTimeout = override.SubDeleteTimeout.DeepCopy()
+	}
+	if override.RequeueDuration != nil {
+		t.RequeueDuration = override.RequeueDuration.DeepCopy()
+	}
+	if override.SyncPeriod != nil {
+		t.SyncPeriod = override.SyncPeriod.DeepCopy()
+	}
+	return t
+}
+
 // OperandRegistrySpec defines the desired state of OperandRegistry.
 type OperandRegistrySpec struct {
 	// Operators is a list of operator OLM definition.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Operators Registry List"
 	// +optional
 	Operators []Operator `json:"operators,omitempty"`
+	// CatalogSourcePolicy defines how to choose the CatalogSource when several CatalogSources provide the package
+	// of an operator without sourceName and sourceNamespace.
+	// +optional
+	CatalogSourcePolicy *CatalogSourcePolicy `json:"catalogSourcePolicy,omitempty"`
+	// ProgressDeadlineSeconds is the default progress deadline of the operators requested from the OperandRegistry.
+	// The progressDeadlineSeconds of an OperandRequest takes precedence over it.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+	// RevisionHistoryLimit is the number of the previously applied specs kept as ControllerRevisions for rollback.
+	// The default value is 10.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+	// RolloutStrategy rolls out a change of the OperandRegistry to the OperandRequests in stages.
+	// The change is applied by all the OperandRequests at once when it is not set.
+	// +optional
+	RolloutStrategy *RolloutStrategy `json:"rolloutStrategy,omitempty"`
+	// Priority of the OperandRegistry when several OperandRegistries request the same operator,
+	// the one with the highest priority manages it under the Priority conflict policy. The default value is 0.
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+	// Extends is the base OperandRegistry the operators of this OperandRegistry are merged onto.
+	// An operator with the name of a base operator overrides the top-level fields it sets, the other operators are added.
+	// A nested field set by the overlay replaces the base value as a whole, and a field can't be unset.
+	// +optional
+	Extends *RegistryReference `json:"extends,omitempty"`
+	// AllowClusterRequests allows the ClusterOperandRequests to request the operators of the OperandRegistry.
+	// +optional
+	AllowClusterRequests bool `json:"allowClusterRequests,omitempty"`
+	// RemoveOperators are the names of the operators of the base OperandRegistry which are not inherited.
+	// +optional
+	RemoveOperators []string `json:"removeOperators,omitempty"`
+}
+
+// RegistryReference refers to an OperandRegistry.
+type RegistryReference struct {
+	// Name of the OperandRegistry.
+	Name string `json:"name"`
+	// Namespace of the OperandRegistry. The default value is the namespace of the referring OperandRegistry or OperandRequest.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// RolloutStrategy defines the stages of rolling out a change of the OperandRegistry.
+// The OperandRequests in the canary namespaces receive the change first, then the other OperandRequests in batches.
+// The next stage starts when all the OperandRequests of the current stage are Running.
+type RolloutStrategy struct {
+	// CanaryNamespaces are the namespaces of the OperandRequests receiving the change first.
+	// +optional
+	CanaryNamespaces []string `json:"canaryNamespaces,omitempty"`
+	// BatchSize is the number of the OperandRequests receiving the change in each batch after the canaries.
+	// The default value is 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BatchSize *int32 `json:"batchSize,omitempty"`
+	// BatchTimeoutSeconds is the time the OperandRequests of a stage have to reach Running before the rollout is paused.
+	// The default value is 600.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BatchTimeoutSeconds *int32 `json:"batchTimeoutSeconds,omitempty"`
+}
+
+// CatalogSourcePolicy defines the preference order of the CatalogSources providing the same package.
+// The criteria are evaluated in order: patterns, selector, priority,
+// then CatalogSources in the OperandRegistry namespace, in the operator namespace and in alphabetical order.
+type CatalogSourcePolicy struct {
+	// Patterns is an ordered list of "namespace/name" glob patterns, e.g. "private-catalogs/*".
+	// A CatalogSource matching an earlier pattern is preferred.
+	// +optional
+	Patterns []string `json:"patterns,omitempty"`
+	// Selector prefers the CatalogSources matching the label selector, e.g. catalog-tier=certified.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// HonorPriority prefers the CatalogSource with the higher spec.priority.
+	// +optional
+	HonorPriority bool `json:"honorPriority,omitempty"`
 }
 
 // OperandRegistryStatus defines the observed state of OperandRegistry.
@@ -113,6 +307,96 @@ type OperandRegistryStatus struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Conditions",xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
 	Conditions []Condition `json:"conditions,omitempty"`
+	// CatalogSources records the CatalogSource chosen for each operator and the reason for the choice.
+	// +optional
+	CatalogSources map[string]CatalogSourceStatus `json:"catalogSources,omitempty"`
+	// DefaultChannels records the default channel of the package resolved for each operator without channel.
+	// +optional
+	DefaultChannels map[string]string `json:"defaultChannels,omitempty"`
+	// Rollbacks records the operators rolled back to their last succeeded ClusterServiceVersion after a failed upgrade.
+	// +optional
+	Rollbacks map[string]RollbackStatus `json:"rollbacks,omitempty"`
+	// CurrentRevision is the name of the ControllerRevision recording the applied spec.
+	// +optional
+	CurrentRevision string `json:"currentRevision,omitempty"`
+	// Rollout records the progress of rolling out the current revision to the OperandRequests.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+	// EffectiveOperators are the operators merged from the base OperandRegistry and this one when it extends a base.
+	// +optional
+	EffectiveOperators []Operator `json:"effectiveOperators,omitempty"`
+}
+
+// RolloutPhase defines the phase of a rollout.
+type RolloutPhase string
+
+// Rollout phases.
+const (
+	// RolloutProgressing means the revision is being rolled out to the OperandRequests.
+	RolloutProgressing RolloutPhase = "Progressing"
+	// RolloutPaused means a stage didn't reach Running before the timeout, the rollout waits to be resumed.
+	RolloutPaused RolloutPhase = "Paused"
+	// RolloutCompleted means all the OperandRequests receive the revision.
+	RolloutCompleted RolloutPhase = "Completed"
+)
+
+// RolloutStatus defines the progress of rolling out a revision of the OperandRegistry.
+type RolloutStatus struct {
+	// Revision is the name of the ControllerRevision being rolled out.
+	Revision string `json:"revision"`
+	// PreviousRevision is the name of the ControllerRevision applied by the OperandRequests not receiving the revision yet.
+	// +optional
+	PreviousRevision string `json:"previousRevision,omitempty"`
+	// Phase is the phase of the rollout.
+	Phase RolloutPhase `json:"phase"`
+	// Batch is the number of the stages started, the canary namespaces are the first stage.
+	// +optional
+	Batch int32 `json:"batch,omitempty"`
+	// BatchStartTime is when the current stage started.
+	// +optional
+	BatchStartTime *metav1.Time `json:"batchStartTime,omitempty"`
+	// CurrentBatch lists the OperandRequests of the current stage, in namespace/name format.
+	// +optional
+	CurrentBatch []string `json:"currentBatch,omitempty"`
+	// Promoted lists the OperandRequests receiving the revision, in namespace/name format.
+	// +optional
+	Promoted []string `json:"promoted,omitempty"`
+	// Message explains why the rollout is paused.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RollbackStatus defines the rollback of an operator after a failed upgrade.
+// The operator is pinned to the ClusterServiceVersion until the channel or the startingCSV of its registry entry changes.
+type RollbackStatus struct {
+	// FailedCSV is the ClusterServiceVersion whose installation failed.
+	FailedCSV string `json:"failedCSV"`
+	// CSV is the last succeeded ClusterServiceVersion the operator is rolled back to.
+	CSV string `json:"csv"`
+	// Channel is the channel of the last succeeded ClusterServiceVersion.
+	// +optional
+	Channel string `json:"channel,omitempty"`
+	// FailedChannel is the channel of the registry entry when the upgrade failed.
+	// +optional
+	FailedChannel string `json:"failedChannel,omitempty"`
+	// FailedStartingCSV is the startingCSV of the registry entry when the upgrade failed.
+	// +optional
+	FailedStartingCSV string `json:"failedStartingCSV,omitempty"`
+	// Time is when the operator was rolled back.
+	Time metav1.Time `json:"time"`
+}
+
+// CatalogSourceStatus defines the CatalogSource chosen for an operator.
+type CatalogSourceStatus struct {
+	// Name of the chosen CatalogSource.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Namespace of the chosen CatalogSource.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Reason describes why the CatalogSource is chosen.
+	// +optional
+	Reason string `json:"reason,omitempty"`
 }
 
 // OperatorStatus defines operators status and the number of reconcile request.
@@ -189,6 +473,22 @@ func (r *OperandRegistry) GetReconcileRequest(name string, reconcileRequest reco
 	return -1
 }
 
+// SetCatalogSourceStatus records the CatalogSource chosen for the operator in the OperandRegistry.
+func (r *OperandRegistry) SetCatalogSourceStatus(name string, status CatalogSourceStatus) {
+	if r.Status.CatalogSources == nil {
+		r.Status.CatalogSources = make(map[string]CatalogSourceStatus)
+	}
+	r.Status.CatalogSources[name] = status
+}
+
+// SetDefaultChannel records the default channel resolved for the operator.
+func (r *OperandRegistry) SetDefaultChannel(name, channel string) {
+	if r.Status.DefaultChannels == nil {
+		r.Status.DefaultChannels = make(map[string]string)
+	}
+	r.Status.DefaultChannels[name] = channel
+}
+
 // SetOperatorStatus sets the operator status in the OperandRegistry.
 func (r *OperandRegistry) SetOperatorStatus(name string, phase OperatorPhase, request reconcile.Request) {
 	s := r.Status.OperatorsStatus[name]
@@ -212,6 +512,111 @@ func (r *OperandRegistry) GetOperator(operandName string) *Operator {
 	return nil
 }
 
+// IsClusterExtension returns true if the operator is installed by an OLM v1 ClusterExtension.
+func (o *Operator) IsClusterExtension() bool {
+	return o.InstallBackend == InstallBackendClusterExtension
+}
+
+// GetDeletionPolicy returns the deletion policy of the operator, "Delete" by default.
+func (o *Operator) GetDeletionPolicy() DeletionPolicy {
+	if o.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+	return o.DeletionPolicy
+}
+
+// GetRollbackPolicy returns the rollback policy of the operator, "None" by default.
+func (o *Operator) GetRollbackPolicy() RollbackPolicy {
+	if o.RollbackPolicy == "" {
+		return RollbackPolicyNone
+	}
+	return o.RollbackPolicy
+}
+
+// GetRevisionHistoryLimit returns the number of the previously applied specs kept for rollback.
+func (r *OperandRegistry) GetRevisionHistoryLimit() int32 {
+	if r.Spec.RevisionHistoryLimit == nil {
+		return DefaultRevisionHistoryLimit
+	}
+	return *r.Spec.RevisionHistoryLimit
+}
+
+// RevisionSpec returns the spec recorded in the revisions of the OperandRegistry.
+// The revision history limit and the rollout strategy are not part of the revisions.
+func (r *OperandRegistry) RevisionSpec() *OperandRegistrySpec {
+	spec := r.Spec.DeepCopy()
+	spec.RevisionHistoryLimit = nil
+	spec.RolloutStrategy = nil
+	return spec
+}
+
+// GetRolloutRevision returns the name of the revision rolled out to the OperandRequest.
+// It is empty when the OperandRequest applies the current spec.
+func (r *OperandRegistry) GetRolloutRevision(requestKey types.NamespacedName) string {
+	rollout := r.Status.Rollout
+	if r.Spec.RolloutStrategy == nil || rollout == nil {
+		return ""
+	}
+	if !r.IsRolloutInProgress() {
+		return rollout.Revision
+	}
+	for _, promoted := range rollout.Promoted {
+		if promoted == requestKey.String() {
+			return rollout.Revision
+		}
+	}
+	return rollout.PreviousRevision
+}
+
+// IsRolloutInProgress returns true while a staged rollout hasn't promoted all the OperandRequests to the current revision.
+func (r *OperandRegistry) IsRolloutInProgress() bool {
+	rollout := r.Status.Rollout
+	return r.Spec.RolloutStrategy != nil && rollout != nil && rollout.Phase != RolloutCompleted && rollout.PreviousRevision != ""
+}
+
+// IsRolloutPending returns true when the OperandRequest applies the previous revision of a rollout in progress.
+func (r *OperandRegistry) IsRolloutPending(requestKey types.NamespacedName) bool {
+	revision := r.GetRolloutRevision(requestKey)
+	return revision != "" && revision != r.Status.Rollout.Revision
+}
+
+// GetBatchSize returns the number of the OperandRequests in a batch of the rollout.
+func (s *RolloutStrategy) GetBatchSize() int {
+	if s.BatchSize == nil {
+		return int(DefaultRolloutBatchSize)
+	}
+	return int(*s.BatchSize)
+}
+
+// GetBatchTimeout returns the time a stage of the rollout has to reach Running.
+func (s *RolloutStrategy) GetBatchTimeout() time.Duration {
+	if s.BatchTimeoutSeconds == nil {
+		return time.Duration(DefaultRolloutBatchTimeoutSeconds) * time.Second
+	}
+	return time.Duration(*s.BatchTimeoutSeconds) * time.Second
+}
+
+// GetRollback returns the rollback of the operator, it is nil when the operator isn't rolled back.
+func (r *OperandRegistry) GetRollback(name string) *RollbackStatus {
+	rb, ok := r.Status.Rollbacks[name]
+	if !ok {
+		return nil
+	}
+	return &rb
+}
+
+// SetRollback records the rollback of the operator, a nil rollback removes it.
+func (r *OperandRegistry) SetRollback(name string, rb *RollbackStatus) {
+	if rb == nil {
+		delete(r.Status.Rollbacks, name)
+		return
+	}
+	if r.Status.Rollbacks == nil {
+		r.Status.Rollbacks = make(map[string]RollbackStatus)
+	}
+	r.Status.Rollbacks[name] = *rb
+}
+
 // GetAllReconcileRequest gets all the ReconcileRequest from OperandRegistry status.
 func (r *OperandRegistry) GetAllReconcileRequest() []reconcile.Request {
 	maprrs := make(map[string]reconcile.Request)
@@ -244,6 +649,85 @@ func (r *OperandRegistry) SetNotFoundCondition(name string, rt ResourceType, cs
 	r.setCondition(*c)
 }
 
+// SetWaitingCondition creates a Condition to claim the registry is waiting for a resource being ready.
+// A waiting condition that is no longer true is only updated when it exists.
+func (r *OperandRegistry) SetWaitingCondition(name, reason string, rt ResourceType, cs corev1.ConditionStatus) {
+	c := newWaitingCondition(name, reason, rt, cs)
+	if pos, cp := getCondition(&r.Status.Conditions, c.Type, c.Message); cp == nil && cs != corev1.ConditionTrue {
+		return
+	} else if cp != nil && cp.Status == cs && cp.Reason == c.Reason {
+		c.LastTransitionTime = cp.LastTransitionTime
+		r.Status.Conditions[pos] = *c
+		return
+	}
+	r.setCondition(*c)
+}
+
+// SetConflictCondition creates a Condition to claim the operator is requested from several OperandRegistries.
+// A conflict condition that is no longer true is only updated when it exists.
+func (r *OperandRegistry) SetConflictCondition(name, reason string, cs corev1.ConditionStatus) {
+	r.setOperatorCondition(newConflictCondition(name, reason, cs))
+}
+
+// SetPackageNotFoundCondition creates a Condition to claim no CatalogSource provides the package of the operator.
+// A condition that is no longer true is only updated when it exists.
+func (r *OperandRegistry) SetPackageNotFoundCondition(name, reason string, cs corev1.ConditionStatus) {
+	r.setOperatorCondition(newCondition(ConditionPackageNotFound, cs, reason, "Package of operator "+name+" is not found"))
+}
+
+// SetChannelNotFoundCondition creates a Condition to claim the package of the operator doesn't have its channel.
+// A condition that is no longer true is only updated when it exists.
+func (r *OperandRegistry) SetChannelNotFoundCondition(name, reason string, cs corev1.ConditionStatus) {
+	r.setOperatorCondition(newCondition(ConditionChannelNotFound, cs, reason, "Channel of operator "+name+" is not found"))
+}
+
+// setOperatorCondition sets a condition of an operator, keeping its timestamps when it doesn't change.
+// A condition that is no longer true is only updated when it exists.
+func (r *OperandRegistry) setOperatorCondition(c *Condition) {
+	if pos, cp := getCondition(&r.Status.Conditions, c.Type, c.Message); cp == nil && c.Status != corev1.ConditionTrue {
+		return
+	} else if cp != nil && cp.Status == c.Status && cp.Reason == c.Reason {
+		c.LastTransitionTime = cp.LastTransitionTime
+		c.LastUpdateTime = cp.LastUpdateTime
+		r.Status.Conditions[pos] = *c
+		return
+	}
+	r.setCondition(*c)
+}
+
+// SetBaseNotFoundCondition creates a Condition to claim the base OperandRegistry is not found.
+// A condition that is no longer true is only updated when it exists.
+func (r *OperandRegistry) SetBaseNotFoundCondition(base types.NamespacedName, reason string, cs corev1.ConditionStatus) {
+	r.setOperatorCondition(newCondition(ConditionBaseNotFound, cs, reason, "Base OperandRegistry "+base.String()+" is not found"))
+}
+
+// SetInvalidBaseCondition creates a Condition to claim the base OperandRegistry can't be merged,
+// because the bases form a cycle or the operators of the OperandRegistry are invalid overlays.
+// A condition that is no longer true is only updated when it exists.
+func (r *OperandRegistry) SetInvalidBaseCondition(base types.NamespacedName, reason string, cs corev1.ConditionStatus) {
+	r.setOperatorCondition(newCondition(ConditionInvalidBase, cs, reason, "Base OperandRegistry "+base.String()+" can't be merged"))
+}
+
+// GetBaseKey returns the key of the base OperandRegistry, or nil when the OperandRegistry doesn't extend a base.
+func (r *OperandRegistry) GetBaseKey() *types.NamespacedName {
+	if r.Spec.Extends == nil {
+		return nil
+	}
+	key := types.NamespacedName{Name: r.Spec.Extends.Name, Namespace: r.Spec.Extends.Namespace}
+	if key.Namespace == "" {
+		key.Namespace = r.Namespace
+	}
+	return &key
+}
+
+// GetPriority returns the priority of the OperandRegistry.
+func (r *OperandRegistry) GetPriority() int32 {
+	if r.Spec.Priority == nil {
+		return 0
+	}
+	return *r.Spec.Priority
+}
+
 func (r *OperandRegistry) setCondition(c Condition) {
 	pos, cp := getCondition(&r.Status.Conditions, c.Type, c.Message)
 	if cp != nil {
@@ -253,6 +737,29 @@ func (r *OperandRegistry) setCondition(c Condition) {
 	}
 }
 
+// SummarizePhase rolls the phases of the requested operators up into the registry phase.
+// A failed operator takes precedence over an updating one, which takes precedence over an operator not running yet.
+func (r *OperandRegistry) SummarizePhase() RegistryPhase {
+	if len(r.Status.OperatorsStatus) == 0 {
+		return RegistryReady
+	}
+	phase := RegistryRunning
+	for _, s := range r.Status.OperatorsStatus {
+		switch s.Phase {
+		case OperatorFailed:
+			return RegistryFailed
+		case OperatorUpdating:
+			phase = RegistryUpdating
+		case OperatorRunning:
+		default:
+			if phase == RegistryRunning {
+				phase = RegistryPending
+			}
+		}
+	}
+	return phase
+}
+
 // UpdateRegistryPhase sets the current Phase status.
 func (r *OperandRegistry) UpdateRegistryPhase(phase RegistryPhase) {
 	r.Status.Phase = phase
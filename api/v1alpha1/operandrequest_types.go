@@ -35,6 +35,11 @@ type OperandRequestSpec struct {
 	// Requests defines a list of operands installation.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Operators Request List"
 	Requests []Request `json:"requests"`
+	// ProgressDeadlineSeconds is the number of seconds a member has to reach Running before it is considered stuck.
+	// It overrides the progressDeadlineSeconds of the OperandRegistry. There is no deadline when neither of them is set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
 }
 
 // Request identifies a operand detail.
@@ -47,6 +52,10 @@ type Request struct {
 	// The default is the current namespace in which the request is defined.
 	// +optional
 	RegistryNamespace string `json:"registryNamespace,omitempty"`
+	// FallbackRegistries are the OperandRegistries tried in order when the registry doesn't offer an operand,
+	// or doesn't allow the request to use it. Each operand is resolved from the first OperandRegistry offering it.
+	// +optional
+	FallbackRegistries []RegistryReference `json:"fallbackRegistries,omitempty"`
 	// Description is an optional description for the request.
 	// +optional
 	Description string `json:"description,omitempty"`
@@ -70,6 +79,10 @@ type Operand struct {
 	// It is the name of the custom resource.
 	// +optional
 	InstanceName string `json:"instanceName,omitempty"`
+	// InstanceNamespace is the namespace of the custom resource, the default is the namespace of the OperandRequest.
+	// It is only honored in a ClusterOperandRequest, and ignored for a cluster-scoped custom resource.
+	// +optional
+	InstanceNamespace string `json:"instanceNamespace,omitempty"`
 	// Spec is used when users want to deploy multiple custom resources.
 	// It is the configuration map of custom resource.
 	// +kubebuilder:pruning:PreserveUnknownFields
@@ -102,6 +115,15 @@ const (
 	ConditionNotFound   ConditionType = "NotFound"
 	ConditionOutofScope ConditionType = "OutofScope"
 	ConditionReady      ConditionType = "Ready"
+	ConditionWaiting    ConditionType = "Waiting"
+
+	ConditionProgressDeadlineExceeded ConditionType = "ProgressDeadlineExceeded"
+	ConditionConflict                 ConditionType = "Conflict"
+	ConditionPackageNotFound          ConditionType = "PackageNotFound"
+	ConditionChannelNotFound          ConditionType = "ChannelNotFound"
+	ConditionBaseNotFound             ConditionType = "BaseNotFound"
+	ConditionInvalidBase              ConditionType = "InvalidBase"
+	ConditionNotAllowed               ConditionType = "NotAllowed"
 
 	OperatorReady      OperatorPhase = "Ready for Deployment"
 	OperatorRunning    OperatorPhase = "Running"
@@ -118,12 +140,13 @@ const (
 	ClusterPhaseRunning    ClusterPhase = "Running"
 	ClusterPhaseFailed     ClusterPhase = "Failed"
 
-	ResourceTypeOperandRegistry ResourceType = "operandregistry"
-	ResourceTypeCatalogSource   ResourceType = "catalogsource"
-	ResourceTypeSub             ResourceType = "subscription"
-	ResourceTypeCsv             ResourceType = "csv"
-	ResourceTypeOperator        ResourceType = "operator"
-	ResourceTypeOperand         ResourceType = "operands"
+	ResourceTypeOperandRegistry  ResourceType = "operandregistry"
+	ResourceTypeCatalogSource    ResourceType = "catalogsource"
+	ResourceTypeSub              ResourceType = "subscription"
+	ResourceTypeCsv              ResourceType = "csv"
+	ResourceTypeClusterExtension ResourceType = "clusterextension"
+	ResourceTypeOperator         ResourceType = "operator"
+	ResourceTypeOperand          ResourceType = "operands"
 )
 
 // Condition represents the current state of the Request Service.
@@ -160,6 +183,49 @@ type OperandRequestStatus struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Phase",xDescriptors="urn:alm:descriptor:io.kubernetes.phase"
 	// +optional
 	Phase ClusterPhase `json:"phase,omitempty"`
+	// Deletions records the progress of the resources being deleted by the OperandRequest.
+	// +optional
+	Deletions []DeletionStatus `json:"deletions,omitempty"`
+	// RegistryRevisions records the revision of each OperandRegistry applied by the OperandRequest,
+	// the keys are the OperandRegistries in namespace/name format.
+	// +optional
+	RegistryRevisions map[string]string `json:"registryRevisions,omitempty"`
+}
+
+// DeletionPhase defines the phase of the deletion of a resource.
+type DeletionPhase string
+
+// Deletion phases.
+const (
+	// DeletionPending means the resource waits for its operands being deleted.
+	DeletionPending DeletionPhase = "Pending"
+	// DeletionDeleting means the resource is being deleted.
+	DeletionDeleting DeletionPhase = "Deleting"
+	// DeletionFailed means the resource isn't deleted before the timeout.
+	DeletionFailed DeletionPhase = "Failed"
+)
+
+// DeletionStatus defines the deletion progress of a resource.
+type DeletionStatus struct {
+	// Operand is the name of the operand the resource belongs to.
+	Operand string `json:"operand"`
+	// APIVersion is the API version of the resource.
+	APIVersion string `json:"apiVersion"`
+	// Kind is the kind of the resource.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the resource.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the resource.
+	Name string `json:"name"`
+	// Phase is the phase of the deletion, one of Pending, Deleting and Failed.
+	Phase DeletionPhase `json:"phase"`
+	// StartTime is the time the deletion of the resource was requested.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// A human readable message indicating details about the deletion.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // MemberPhase shows the phase of the operator and operator instance.
@@ -183,6 +249,9 @@ type OperandCRMember struct {
 	// APIVersion is the APIVersion of the custom resource.
 	// +optional
 	APIVersion string `json:"apiVersion,omitempty"`
+	// Namespace is the namespace of the custom resource when it isn't created in the namespace of the OperandRequest.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // MemberStatus shows if the Operator is ready.
@@ -195,6 +264,35 @@ type MemberStatus struct {
 	// OperandCRList shows the list of custom resource created by OperandRequest.
 	// +optional
 	OperandCRList []OperandCRMember `json:"operandCRList,omitempty"`
+	// InstalledBundle is the name of the bundle installed by an OLM v1 ClusterExtension.
+	// +optional
+	InstalledBundle string `json:"installedBundle,omitempty"`
+	// InstalledVersion is the version of the bundle installed by an OLM v1 ClusterExtension.
+	// +optional
+	InstalledVersion string `json:"installedVersion,omitempty"`
+	// Conditions are the conditions reported by the OLM v1 ClusterExtension.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+	// Timeouts are the effective timeouts and requeue intervals used for the operator and its operands.
+	// +optional
+	Timeouts *Timeouts `json:"timeouts,omitempty"`
+	// ProgressStartTime is the time the member started progressing towards Running.
+	// +optional
+	ProgressStartTime *metav1.Time `json:"progressStartTime,omitempty"`
+	// BlockingReason is the last known reason the member isn't Running.
+	// +optional
+	BlockingReason string `json:"blockingReason,omitempty"`
+	// Rollback is the rollback of the operator after a failed upgrade.
+	// +optional
+	Rollback *RollbackStatus `json:"rollback,omitempty"`
+	// Registry is the OperandRegistry the operator is resolved from, in the namespace/name format.
+	// +optional
+	Registry string `json:"registry,omitempty"`
+}
+
+// IsRunning returns true when the operator of the member is Running, and its operands are Running or not created by ODLM.
+func (m *MemberStatus) IsRunning() bool {
+	return m.Phase.OperatorPhase == OperatorRunning && (m.Phase.OperandPhase == ServiceRunning || m.Phase.OperandPhase == ServiceNone)
 }
 
 // +kubebuilder:object:root=true
@@ -279,6 +377,77 @@ func (r *OperandRequest) SetNotFoundOperandRegistryCondition(name string, rt Res
 	r.setCondition(*c)
 }
 
+// SetWaitingCondition creates a Condition to claim the resource is waiting for a dependency being ready.
+// A waiting condition that is no longer true is only updated when it exists.
+func (r *OperandRequest) SetWaitingCondition(name, reason string, rt ResourceType, cs corev1.ConditionStatus, mu sync.Locker) {
+	mu.Lock()
+	defer mu.Unlock()
+	c := newWaitingCondition(name, reason, rt, cs)
+	if pos, cp := getCondition(&r.Status.Conditions, c.Type, c.Message); cp == nil && cs != corev1.ConditionTrue {
+		return
+	} else if cp != nil && cp.Status == cs && cp.Reason == c.Reason {
+		c.LastTransitionTime = cp.LastTransitionTime
+		r.Status.Conditions[pos] = *c
+		return
+	}
+	r.setCondition(*c)
+}
+
+// SetProgressDeadlineExceededCondition creates a Condition to claim the member hasn't reached Running within its progress deadline.
+// It returns true when the condition becomes true. A condition that is no longer true is only updated when it exists.
+func (r *OperandRequest) SetProgressDeadlineExceededCondition(name, reason string, cs corev1.ConditionStatus, mu sync.Locker) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	c := newCondition(ConditionProgressDeadlineExceeded, cs, reason, "Operator "+name+" has not reached Running within the progress deadline")
+	pos, cp := getCondition(&r.Status.Conditions, c.Type, c.Message)
+	if cp == nil && cs != corev1.ConditionTrue {
+		return false
+	} else if cp != nil && cp.Status == cs {
+		c.LastTransitionTime = cp.LastTransitionTime
+		if cp.Reason == c.Reason {
+			c.LastUpdateTime = cp.LastUpdateTime
+		}
+		r.Status.Conditions[pos] = *c
+		return false
+	}
+	r.setCondition(*c)
+	return cs == corev1.ConditionTrue
+}
+
+// SetConflictCondition creates a Condition to claim the operator is requested from several OperandRegistries.
+// A conflict condition that is no longer true is only updated when it exists.
+func (r *OperandRequest) SetConflictCondition(name, reason string, cs corev1.ConditionStatus, mu sync.Locker) {
+	mu.Lock()
+	defer mu.Unlock()
+	c := newConflictCondition(name, reason, cs)
+	if pos, cp := getCondition(&r.Status.Conditions, c.Type, c.Message); cp == nil && cs != corev1.ConditionTrue {
+		return
+	} else if cp != nil && cp.Status == cs && cp.Reason == c.Reason {
+		c.LastTransitionTime = cp.LastTransitionTime
+		c.LastUpdateTime = cp.LastUpdateTime
+		r.Status.Conditions[pos] = *c
+		return
+	}
+	r.setCondition(*c)
+}
+
+// SetNotAllowedCondition creates a Condition to claim the resource is not allowed by the OperandPolicies of the namespace.
+// A condition that is no longer true is only updated when it exists.
+func (r *OperandRequest) SetNotAllowedCondition(name, reason string, rt ResourceType, cs corev1.ConditionStatus, mu sync.Locker) {
+	mu.Lock()
+	defer mu.Unlock()
+	c := newCondition(ConditionNotAllowed, cs, reason, string(rt)+" "+name+" is not allowed by the OperandPolicies")
+	if pos, cp := getCondition(&r.Status.Conditions, c.Type, c.Message); cp == nil && cs != corev1.ConditionTrue {
+		return
+	} else if cp != nil && cp.Status == cs && cp.Reason == c.Reason {
+		c.LastTransitionTime = cp.LastTransitionTime
+		c.LastUpdateTime = cp.LastUpdateTime
+		r.Status.Conditions[pos] = *c
+		return
+	}
+	r.setCondition(*c)
+}
+
 // setReadyCondition creates a Condition to claim Ready.
 func (r *OperandRequest) setReadyCondition(name string, rt ResourceType, cs corev1.ConditionStatus) {
 	c := &Condition{}
@@ -308,6 +477,14 @@ func getCondition(conds *[]Condition, t ConditionType, msg string) (int, *Condit
 	return -1, nil
 }
 
+func newWaitingCondition(name, reason string, rt ResourceType, cs corev1.ConditionStatus) *Condition {
+	return newCondition(ConditionWaiting, cs, reason, "Waiting for "+string(rt)+" "+name+" being ready")
+}
+
+func newConflictCondition(name, reason string, cs corev1.ConditionStatus) *Condition {
+	return newCondition(ConditionConflict, cs, reason, "Operator "+name+" is requested from several OperandRegistries")
+}
+
 func newCondition(condType ConditionType, status corev1.ConditionStatus, reason, message string) *Condition {
 	now := time.Now().Format(time.RFC3339)
 	return &Condition{
@@ -342,7 +519,8 @@ func (r *OperandRequest) SetMemberStatus(name string, operatorPhase OperatorPhas
 }
 
 // SetMemberCRStatus appends a Member CR in the Member status list.
-func (r *OperandRequest) SetMemberCRStatus(name, CRName, CRKind, CRAPIVersion string, mu sync.Locker) {
+// CRNamespace is empty when the CR is in the namespace of the OperandRequest.
+func (r *OperandRequest) SetMemberCRStatus(name, CRName, CRKind, CRAPIVersion, CRNamespace string, mu sync.Locker) {
 	mu.Lock()
 	defer mu.Unlock()
 	pos, m := getMemberStatus(&r.Status, name)
@@ -352,7 +530,92 @@ func (r *OperandRequest) SetMemberCRStatus(name, CRName, CRKind, CRAPIVersion st
 				return
 			}
 		}
-		r.Status.Members[pos].OperandCRList = append(r.Status.Members[pos].OperandCRList, OperandCRMember{APIVersion: CRAPIVersion, Kind: CRKind, Name: CRName})
+		r.Status.Members[pos].OperandCRList = append(r.Status.Members[pos].OperandCRList, OperandCRMember{APIVersion: CRAPIVersion, Kind: CRKind, Name: CRName, Namespace: CRNamespace})
+	}
+}
+
+// SetMemberInstallStatus sets the installed bundle, version and conditions reported by an OLM v1 ClusterExtension.
+func (r *OperandRequest) SetMemberInstallStatus(name, bundle, version string, conditions []Condition, mu sync.Locker) {
+	mu.Lock()
+	defer mu.Unlock()
+	pos, m := getMemberStatus(&r.Status, name)
+	if m != nil {
+		r.Status.Members[pos].InstalledBundle = bundle
+		r.Status.Members[pos].InstalledVersion = version
+		r.Status.Members[pos].Conditions = conditions
+	}
+}
+
+// SetMemberTimeouts sets the effective timeouts of a member.
+func (r *OperandRequest) SetMemberTimeouts(name string, timeouts Timeouts, mu sync.Locker) {
+	mu.Lock()
+	defer mu.Unlock()
+	pos, m := getMemberStatus(&r.Status, name)
+	if m != nil {
+		r.Status.Members[pos].Timeouts = &timeouts
+	}
+}
+
+// GetMemberTimeouts returns the effective timeouts of a member, it is nil when they are not recorded yet.
+func (r *OperandRequest) GetMemberTimeouts(name string) *Timeouts {
+	_, m := getMemberStatus(&r.Status, name)
+	if m == nil {
+		return nil
+	}
+	return m.Timeouts
+}
+
+// SetMemberBlockingReason records the last known reason the member isn't Running.
+func (r *OperandRequest) SetMemberBlockingReason(name, reason string, mu sync.Locker) {
+	mu.Lock()
+	defer mu.Unlock()
+	pos, m := getMemberStatus(&r.Status, name)
+	if m != nil {
+		r.Status.Members[pos].BlockingReason = reason
+	}
+}
+
+// SetMemberRegistry records the OperandRegistry the operator of a member is resolved from.
+func (r *OperandRequest) SetMemberRegistry(name string, registryKey types.NamespacedName, mu sync.Locker) {
+	mu.Lock()
+	defer mu.Unlock()
+	pos, m := getMemberStatus(&r.Status, name)
+	if m != nil {
+		r.Status.Members[pos].Registry = registryKey.String()
+	}
+}
+
+// SetMemberRollback records the rollback of the operator of a member, a nil rollback removes it.
+func (r *OperandRequest) SetMemberRollback(name string, rb *RollbackStatus, mu sync.Locker) {
+	mu.Lock()
+	defer mu.Unlock()
+	pos, m := getMemberStatus(&r.Status, name)
+	if m != nil {
+		r.Status.Members[pos].Rollback = rb
+	}
+}
+
+// SetRegistryRevision records the revision of the OperandRegistry applied by the OperandRequest.
+func (r *OperandRequest) SetRegistryRevision(registryKey types.NamespacedName, revision string, mu sync.Locker) {
+	mu.Lock()
+	defer mu.Unlock()
+	if r.Status.RegistryRevisions == nil {
+		r.Status.RegistryRevisions = make(map[string]string)
+	}
+	r.Status.RegistryRevisions[registryKey.String()] = revision
+}
+
+// SetMemberProgress sets the time the member started progressing towards Running.
+// A nil start time means the member is Running, and also clears its blocking reason.
+func (r *OperandRequest) SetMemberProgress(name string, startTime *metav1.Time, mu sync.Locker) {
+	mu.Lock()
+	defer mu.Unlock()
+	pos, m := getMemberStatus(&r.Status, name)
+	if m != nil {
+		r.Status.Members[pos].ProgressStartTime = startTime
+		if startTime == nil {
+			r.Status.Members[pos].BlockingReason = ""
+		}
 	}
 }
 
@@ -370,6 +633,66 @@ func (r *OperandRequest) RemoveMemberCRStatus(name, CRName, CRKind string, mu sy
 	}
 }
 
+// SetDeletionStatus adds or updates the deletion progress of a resource in the Deletions status list.
+func (r *OperandRequest) SetDeletionStatus(ds DeletionStatus, mu sync.Locker) {
+	mu.Lock()
+	defer mu.Unlock()
+	for i, d := range r.Status.Deletions {
+		if d.isSameResource(ds) {
+			r.Status.Deletions[i] = ds
+			return
+		}
+	}
+	r.Status.Deletions = append(r.Status.Deletions, ds)
+}
+
+// RemoveDeletionStatus removes the deletion progress of a deleted resource from the Deletions status list.
+func (r *OperandRequest) RemoveDeletionStatus(ds DeletionStatus, mu sync.Locker) {
+	mu.Lock()
+	defer mu.Unlock()
+	deletions := []DeletionStatus{}
+	for _, d := range r.Status.Deletions {
+		if !d.isSameResource(ds) {
+			deletions = append(deletions, d)
+		}
+	}
+	if len(deletions) == 0 {
+		deletions = nil
+	}
+	r.Status.Deletions = deletions
+}
+
+// RemoveOperandDeletionStatus removes the deletion progress of all the resources of the operand from the Deletions status list.
+func (r *OperandRequest) RemoveOperandDeletionStatus(operand string, mu sync.Locker) {
+	mu.Lock()
+	defer mu.Unlock()
+	deletions := []DeletionStatus{}
+	for _, d := range r.Status.Deletions {
+		if d.Operand != operand {
+			deletions = append(deletions, d)
+		}
+	}
+	if len(deletions) == 0 {
+		deletions = nil
+	}
+	r.Status.Deletions = deletions
+}
+
+// GetDeletingOperators returns the operands whose Subscription or ClusterExtension is being deleted.
+func (r *OperandRequest) GetDeletingOperators() []string {
+	var operands []string
+	for _, d := range r.Status.Deletions {
+		if d.Kind == "Subscription" || d.Kind == "ClusterExtension" {
+			operands = append(operands, d.Operand)
+		}
+	}
+	return operands
+}
+
+func (d DeletionStatus) isSameResource(ds DeletionStatus) bool {
+	return d.APIVersion == ds.APIVersion && d.Kind == ds.Kind && d.Namespace == ds.Namespace && d.Name == ds.Name
+}
+
 func (r *OperandRequest) setOperatorReadyCondition(operatorPhase OperatorPhase, name string) {
 	if operatorPhase == OperatorRunning {
 		r.setReadyCondition(name, ResourceTypeOperator, corev1.ConditionTrue)
@@ -494,7 +817,48 @@ func (r *OperandRequest) GetRegistryKey(req Request) types.NamespacedName {
 	return types.NamespacedName{Namespace: regNs, Name: regName}
 }
 
-//InitRequestStatus OperandConfig status.
+// GetRegistryKeys returns the keys of the OperandRegistry and the fallback OperandRegistries of a request, in order.
+func (r *OperandRequest) GetRegistryKeys(req Request) []types.NamespacedName {
+	keys := []types.NamespacedName{r.GetRegistryKey(req)}
+	for _, fallback := range req.FallbackRegistries {
+		key := r.GetRegistryKey(Request{Registry: fallback.Name, RegistryNamespace: fallback.Namespace})
+		if !containsRegistryKey(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// GetOperandRegistryKey returns the key of the OperandRegistry an operand of a request is resolved from.
+// It is the OperandRegistry recorded in the member status when it is still one of the OperandRegistries of the request,
+// the OperandRegistry of the request otherwise.
+func (r *OperandRequest) GetOperandRegistryKey(req Request, operandName string) types.NamespacedName {
+	keys := r.GetRegistryKeys(req)
+	if _, m := getMemberStatus(&r.Status, operandName); m != nil && m.Registry != "" {
+		for _, key := range keys {
+			if key.String() == m.Registry {
+				return key
+			}
+		}
+	}
+	return keys[0]
+}
+
+// UsesRegistry returns true when the request uses the OperandRegistry, as its OperandRegistry or a fallback one.
+func (r *OperandRequest) UsesRegistry(req Request, registryKey types.NamespacedName) bool {
+	return containsRegistryKey(r.GetRegistryKeys(req), registryKey)
+}
+
+func containsRegistryKey(keys []types.NamespacedName, key types.NamespacedName) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// InitRequestStatus OperandConfig status.
 func (r *OperandRequest) InitRequestStatus() bool {
 	isInitialized := true
 	if r.Status.Phase == "" {
@@ -508,9 +872,10 @@ func (r *OperandRequest) InitRequestStatus() bool {
 func (r *OperandRequest) GenerateLabels() map[string]string {
 	labels := make(map[string]string)
 	for _, req := range r.Spec.Requests {
-		registryKey := r.GetRegistryKey(req)
-		labels[registryKey.Namespace+"."+registryKey.Name+"/registry"] = "true"
-		labels[registryKey.Namespace+"."+registryKey.Name+"/config"] = "true"
+		for _, registryKey := range r.GetRegistryKeys(req) {
+			labels[registryKey.Namespace+"."+registryKey.Name+"/registry"] = "true"
+			labels[registryKey.Namespace+"."+registryKey.Name+"/config"] = "true"
+		}
 	}
 	return labels
 }
@@ -547,7 +912,9 @@ func (r *OperandRequest) UpdateLabels() bool {
 func (r *OperandRequest) GetAllRegistryReconcileRequest() []reconcile.Request {
 	rrs := []reconcile.Request{}
 	for _, req := range r.Spec.Requests {
-		rrs = append(rrs, reconcile.Request{NamespacedName: r.GetRegistryKey(req)})
+		for _, registryKey := range r.GetRegistryKeys(req) {
+			rrs = append(rrs, reconcile.Request{NamespacedName: registryKey})
+		}
 	}
 	return rrs
 }
@@ -0,0 +1,76 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OperandRequestTemplateSpec defines the desired state of OperandRequestTemplate.
+type OperandRequestTemplateSpec struct {
+	// NamespaceSelector selects the namespaces an OperandRequest is generated in.
+	// The selector can't be empty, and the system namespaces kube-* and openshift-* are never selected.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector"`
+	// Template is the spec of the generated OperandRequests.
+	// The registryNamespace of a request defaults to the namespace of the generated OperandRequest.
+	Template OperandRequestSpec `json:"template"`
+}
+
+// OperandRequestTemplateStatus defines the observed state of OperandRequestTemplate.
+type OperandRequestTemplateStatus struct {
+	// Phase is the cluster running phase, rolled up from the generated OperandRequests.
+	// +optional
+	Phase ClusterPhase `json:"phase,omitempty"`
+	// OperandRequests are the OperandRequests generated in the selected namespaces.
+	// +optional
+	OperandRequests []GeneratedRequest `json:"operandRequests,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// OperandRequestTemplate generates an OperandRequest from its template in every namespace matching its namespace selector.
+// The generated OperandRequest is deleted when the namespace stops matching.
+// +kubebuilder:resource:path=operandrequesttemplates,shortName=opreqt,scope=Cluster
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=.status.phase
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=.metadata.creationTimestamp
+// +operator-sdk:csv:customresourcedefinitions:displayName="OperandRequestTemplate"
+type OperandRequestTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OperandRequestTemplateSpec   `json:"spec,omitempty"`
+	Status OperandRequestTemplateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OperandRequestTemplateList contains a list of OperandRequestTemplate.
+type OperandRequestTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OperandRequestTemplate `json:"items"`
+}
+
+// SetGeneratedRequests records the generated OperandRequests, sorted by namespace, and rolls their phases up.
+func (r *OperandRequestTemplate) SetGeneratedRequests(requests []OperandRequest) {
+	r.Status.OperandRequests, r.Status.Phase = rollUpGeneratedRequests(requests)
+}
+
+func init() {
+	SchemeBuilder.Register(&OperandRequestTemplate{}, &OperandRequestTemplateList{})
+}
@@ -0,0 +1,122 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OperandStatusObserved is the observed state of an operator across the cluster, aggregated by ODLM.
+type OperandStatusObserved struct {
+	// PackageName is the package of the operator.
+	PackageName string `json:"packageName"`
+	// Kind of the resource installing the operator, either Subscription or ClusterExtension.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Subscription is the health of the Subscription installing the operator.
+	// +optional
+	Subscription *SubscriptionHealth `json:"subscription,omitempty"`
+	// InstalledCSV is the ClusterServiceVersion, or the bundle of a ClusterExtension, installed for the operator.
+	// +optional
+	InstalledCSV string `json:"installedCSV,omitempty"`
+	// Version is the version of the installed operator.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Requests are the OperandRequests requesting the operator, sorted by namespace/name.
+	// +optional
+	Requests []OperandStatusRequest `json:"requests,omitempty"`
+	// BindInfoCopies are the Secrets and ConfigMaps shared by the OperandBindInfos of the operator.
+	// +optional
+	BindInfoCopies []BindInfoCopy `json:"bindInfoCopies,omitempty"`
+	// LastUpdateTime is the last time the status was aggregated.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// SubscriptionHealth is the health of the Subscription installing an operator.
+type SubscriptionHealth struct {
+	// Namespace of the Subscription.
+	Namespace string `json:"namespace"`
+	// Name of the Subscription.
+	Name string `json:"name"`
+	// State of the Subscription reported by OLM.
+	// +optional
+	State string `json:"state,omitempty"`
+	// Healthy is false when the CatalogSources of the Subscription are unhealthy or its InstallPlan failed.
+	Healthy bool `json:"healthy"`
+	// Message explains why the Subscription isn't healthy.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// OperandStatusRequest is an OperandRequest requesting an operator.
+type OperandStatusRequest struct {
+	// Request is the namespace/name of the OperandRequest.
+	Request ReconcileRequest `json:"request"`
+	// Registry is the namespace/name of the OperandRegistry the operator is requested from.
+	Registry ReconcileRequest `json:"registry"`
+	// Operand is the name of the requested operand.
+	Operand string `json:"operand"`
+	// Phase is the phase of the operator and its operands in the OperandRequest.
+	// +optional
+	Phase MemberPhase `json:"phase,omitempty"`
+	// Resources are the custom resources created for the OperandRequest.
+	// +optional
+	Resources []OperandCRMember `json:"resources,omitempty"`
+}
+
+// BindInfoCopy is a Secret or ConfigMap copied by an OperandBindInfo to the namespace of an OperandRequest.
+type BindInfoCopy struct {
+	// BindInfo is the namespace/name of the OperandBindInfo.
+	BindInfo ReconcileRequest `json:"bindInfo"`
+	// Kind of the copy, either Secret or ConfigMap.
+	Kind string `json:"kind"`
+	// Namespace of the copy.
+	Namespace string `json:"namespace"`
+	// Name of the copy.
+	Name string `json:"name"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// OperandStatus is the read-only status of an operator aggregated across the cluster, it is named after the package of the operator.
+// +kubebuilder:resource:path=operandstatuses,shortName=opst,scope=Cluster
+// +kubebuilder:printcolumn:name="Package",type=string,JSONPath=.status.packageName
+// +kubebuilder:printcolumn:name="Installed CSV",type=string,JSONPath=.status.installedCSV
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=.status.version
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=.metadata.creationTimestamp
+// +operator-sdk:csv:customresourcedefinitions:displayName="OperandStatus"
+type OperandStatus struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status OperandStatusObserved `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OperandStatusList contains a list of OperandStatus.
+type OperandStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OperandStatus `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OperandStatus{}, &OperandStatusList{})
+}
@@ -1,3 +1,4 @@
+//go:build !ignore_autogenerated
 // +build !ignore_autogenerated
 
 //
@@ -22,9 +23,157 @@ package v1alpha1
 
 import (
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BindInfoCopy) DeepCopyInto(out *BindInfoCopy) {
+	*out = *in
+	out.BindInfo = in.BindInfo
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BindInfoCopy.
+func (in *BindInfoCopy) DeepCopy() *BindInfoCopy {
+	if in == nil {
+		return nil
+	}
+	out := new(BindInfoCopy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogSourcePolicy) DeepCopyInto(out *CatalogSourcePolicy) {
+	*out = *in
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogSourcePolicy.
+func (in *CatalogSourcePolicy) DeepCopy() *CatalogSourcePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogSourcePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogSourceStatus) DeepCopyInto(out *CatalogSourceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogSourceStatus.
+func (in *CatalogSourceStatus) DeepCopy() *CatalogSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOperandRequest) DeepCopyInto(out *ClusterOperandRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperandRequest.
+func (in *ClusterOperandRequest) DeepCopy() *ClusterOperandRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOperandRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterOperandRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOperandRequestList) DeepCopyInto(out *ClusterOperandRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterOperandRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperandRequestList.
+func (in *ClusterOperandRequestList) DeepCopy() *ClusterOperandRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOperandRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterOperandRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOperandRequestStatus) DeepCopyInto(out *ClusterOperandRequestStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		copy(*out, *in)
+	}
+	if in.OperandRequests != nil {
+		in, out := &in.OperandRequests, &out.OperandRequests
+		*out = make([]GeneratedRequest, len(*in))
+		copy(*out, *in)
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]MemberStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperandRequestStatus.
+func (in *ClusterOperandRequestStatus) DeepCopy() *ClusterOperandRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOperandRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -40,6 +189,40 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigResource) DeepCopyInto(out *ConfigResource) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigResource.
+func (in *ConfigResource) DeepCopy() *ConfigResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigService) DeepCopyInto(out *ConfigService) {
 	*out = *in
@@ -50,6 +233,25 @@ func (in *ConfigService) DeepCopyInto(out *ConfigService) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ConfigResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Examples != nil {
+		in, out := &in.Examples, &out.Examples
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(Timeouts)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigService.
@@ -84,6 +286,57 @@ func (in *CrStatus) DeepCopy() *CrStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletionStatus) DeepCopyInto(out *DeletionStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionStatus.
+func (in *DeletionStatus) DeepCopy() *DeletionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeletionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratedRequest) DeepCopyInto(out *GeneratedRequest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratedRequest.
+func (in *GeneratedRequest) DeepCopy() *GeneratedRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratedRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryOwner) DeepCopyInto(out *InventoryOwner) {
+	*out = *in
+	out.Request = in.Request
+	out.Registry = in.Registry
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryOwner.
+func (in *InventoryOwner) DeepCopy() *InventoryOwner {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryOwner)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MemberPhase) DeepCopyInto(out *MemberPhase) {
 	*out = *in
@@ -108,6 +361,25 @@ func (in *MemberStatus) DeepCopyInto(out *MemberStatus) {
 		*out = make([]OperandCRMember, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(Timeouts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProgressStartTime != nil {
+		in, out := &in.ProgressStartTime, &out.ProgressStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(RollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberStatus.
@@ -332,6 +604,11 @@ func (in *OperandConfigSpec) DeepCopyInto(out *OperandConfigSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandConfigSpec.
@@ -367,26 +644,25 @@ func (in *OperandConfigStatus) DeepCopy() *OperandConfigStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OperandRegistry) DeepCopyInto(out *OperandRegistry) {
+func (in *OperandInventory) DeepCopyInto(out *OperandInventory) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandRegistry.
-func (in *OperandRegistry) DeepCopy() *OperandRegistry {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandInventory.
+func (in *OperandInventory) DeepCopy() *OperandInventory {
 	if in == nil {
 		return nil
 	}
-	out := new(OperandRegistry)
+	out := new(OperandInventory)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OperandRegistry) DeepCopyObject() runtime.Object {
+func (in *OperandInventory) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -394,31 +670,31 @@ func (in *OperandRegistry) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OperandRegistryList) DeepCopyInto(out *OperandRegistryList) {
+func (in *OperandInventoryList) DeepCopyInto(out *OperandInventoryList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]OperandRegistry, len(*in))
+		*out = make([]OperandInventory, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandRegistryList.
-func (in *OperandRegistryList) DeepCopy() *OperandRegistryList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandInventoryList.
+func (in *OperandInventoryList) DeepCopy() *OperandInventoryList {
 	if in == nil {
 		return nil
 	}
-	out := new(OperandRegistryList)
+	out := new(OperandInventoryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OperandRegistryList) DeepCopyObject() runtime.Object {
+func (in *OperandInventoryList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -426,75 +702,45 @@ func (in *OperandRegistryList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OperandRegistrySpec) DeepCopyInto(out *OperandRegistrySpec) {
-	*out = *in
-	if in.Operators != nil {
-		in, out := &in.Operators, &out.Operators
-		*out = make([]Operator, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandRegistrySpec.
-func (in *OperandRegistrySpec) DeepCopy() *OperandRegistrySpec {
-	if in == nil {
-		return nil
-	}
-	out := new(OperandRegistrySpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OperandRegistryStatus) DeepCopyInto(out *OperandRegistryStatus) {
+func (in *OperandInventorySpec) DeepCopyInto(out *OperandInventorySpec) {
 	*out = *in
-	if in.OperatorsStatus != nil {
-		in, out := &in.OperatorsStatus, &out.OperatorsStatus
-		*out = make(map[string]OperatorStatus, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]Condition, len(*in))
+	if in.Owners != nil {
+		in, out := &in.Owners, &out.Owners
+		*out = make([]InventoryOwner, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandRegistryStatus.
-func (in *OperandRegistryStatus) DeepCopy() *OperandRegistryStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandInventorySpec.
+func (in *OperandInventorySpec) DeepCopy() *OperandInventorySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OperandRegistryStatus)
+	out := new(OperandInventorySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OperandRequest) DeepCopyInto(out *OperandRequest) {
+func (in *OperandPolicy) DeepCopyInto(out *OperandPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandRequest.
-func (in *OperandRequest) DeepCopy() *OperandRequest {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandPolicy.
+func (in *OperandPolicy) DeepCopy() *OperandPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(OperandRequest)
+	out := new(OperandPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OperandRequest) DeepCopyObject() runtime.Object {
+func (in *OperandPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -502,31 +748,31 @@ func (in *OperandRequest) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OperandRequestList) DeepCopyInto(out *OperandRequestList) {
+func (in *OperandPolicyList) DeepCopyInto(out *OperandPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]OperandRequest, len(*in))
+		*out = make([]OperandPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandRequestList.
-func (in *OperandRequestList) DeepCopy() *OperandRequestList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandPolicyList.
+func (in *OperandPolicyList) DeepCopy() *OperandPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(OperandRequestList)
+	out := new(OperandPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OperandRequestList) DeepCopyObject() runtime.Object {
+func (in *OperandPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -534,15 +780,292 @@ func (in *OperandRequestList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OperandRequestSpec) DeepCopyInto(out *OperandRequestSpec) {
+func (in *OperandPolicySpec) DeepCopyInto(out *OperandPolicySpec) {
 	*out = *in
-	if in.Requests != nil {
-		in, out := &in.Requests, &out.Requests
-		*out = make([]Request, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxOperands != nil {
+		in, out := &in.MaxOperands, &out.MaxOperands
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandPolicySpec.
+func (in *OperandPolicySpec) DeepCopy() *OperandPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandRegistry) DeepCopyInto(out *OperandRegistry) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandRegistry.
+func (in *OperandRegistry) DeepCopy() *OperandRegistry {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandRegistry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OperandRegistry) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandRegistryList) DeepCopyInto(out *OperandRegistryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OperandRegistry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandRegistryList.
+func (in *OperandRegistryList) DeepCopy() *OperandRegistryList {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandRegistryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OperandRegistryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandRegistrySpec) DeepCopyInto(out *OperandRegistrySpec) {
+	*out = *in
+	if in.Operators != nil {
+		in, out := &in.Operators, &out.Operators
+		*out = make([]Operator, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CatalogSourcePolicy != nil {
+		in, out := &in.CatalogSourcePolicy, &out.CatalogSourcePolicy
+		*out = new(CatalogSourcePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(RolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Extends != nil {
+		in, out := &in.Extends, &out.Extends
+		*out = new(RegistryReference)
+		**out = **in
+	}
+	if in.RemoveOperators != nil {
+		in, out := &in.RemoveOperators, &out.RemoveOperators
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandRegistrySpec.
+func (in *OperandRegistrySpec) DeepCopy() *OperandRegistrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandRegistrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandRegistryStatus) DeepCopyInto(out *OperandRegistryStatus) {
+	*out = *in
+	if in.OperatorsStatus != nil {
+		in, out := &in.OperatorsStatus, &out.OperatorsStatus
+		*out = make(map[string]OperatorStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		copy(*out, *in)
+	}
+	if in.CatalogSources != nil {
+		in, out := &in.CatalogSources, &out.CatalogSources
+		*out = make(map[string]CatalogSourceStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DefaultChannels != nil {
+		in, out := &in.DefaultChannels, &out.DefaultChannels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Rollbacks != nil {
+		in, out := &in.Rollbacks, &out.Rollbacks
+		*out = make(map[string]RollbackStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EffectiveOperators != nil {
+		in, out := &in.EffectiveOperators, &out.EffectiveOperators
+		*out = make([]Operator, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandRegistryStatus.
+func (in *OperandRegistryStatus) DeepCopy() *OperandRegistryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandRegistryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandRequest) DeepCopyInto(out *OperandRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandRequest.
+func (in *OperandRequest) DeepCopy() *OperandRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OperandRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandRequestList) DeepCopyInto(out *OperandRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OperandRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandRequestList.
+func (in *OperandRequestList) DeepCopy() *OperandRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OperandRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandRequestSpec) DeepCopyInto(out *OperandRequestSpec) {
+	*out = *in
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make([]Request, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandRequestSpec.
@@ -570,6 +1093,20 @@ func (in *OperandRequestStatus) DeepCopyInto(out *OperandRequestStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Deletions != nil {
+		in, out := &in.Deletions, &out.Deletions
+		*out = make([]DeletionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RegistryRevisions != nil {
+		in, out := &in.RegistryRevisions, &out.RegistryRevisions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandRequestStatus.
@@ -582,6 +1119,223 @@ func (in *OperandRequestStatus) DeepCopy() *OperandRequestStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandRequestTemplate) DeepCopyInto(out *OperandRequestTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandRequestTemplate.
+func (in *OperandRequestTemplate) DeepCopy() *OperandRequestTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandRequestTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OperandRequestTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandRequestTemplateList) DeepCopyInto(out *OperandRequestTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OperandRequestTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandRequestTemplateList.
+func (in *OperandRequestTemplateList) DeepCopy() *OperandRequestTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandRequestTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OperandRequestTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandRequestTemplateSpec) DeepCopyInto(out *OperandRequestTemplateSpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandRequestTemplateSpec.
+func (in *OperandRequestTemplateSpec) DeepCopy() *OperandRequestTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandRequestTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandRequestTemplateStatus) DeepCopyInto(out *OperandRequestTemplateStatus) {
+	*out = *in
+	if in.OperandRequests != nil {
+		in, out := &in.OperandRequests, &out.OperandRequests
+		*out = make([]GeneratedRequest, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandRequestTemplateStatus.
+func (in *OperandRequestTemplateStatus) DeepCopy() *OperandRequestTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandRequestTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandStatus) DeepCopyInto(out *OperandStatus) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandStatus.
+func (in *OperandStatus) DeepCopy() *OperandStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OperandStatus) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandStatusList) DeepCopyInto(out *OperandStatusList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OperandStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandStatusList.
+func (in *OperandStatusList) DeepCopy() *OperandStatusList {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandStatusList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OperandStatusList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandStatusObserved) DeepCopyInto(out *OperandStatusObserved) {
+	*out = *in
+	if in.Subscription != nil {
+		in, out := &in.Subscription, &out.Subscription
+		*out = new(SubscriptionHealth)
+		**out = **in
+	}
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make([]OperandStatusRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BindInfoCopies != nil {
+		in, out := &in.BindInfoCopies, &out.BindInfoCopies
+		*out = make([]BindInfoCopy, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandStatusObserved.
+func (in *OperandStatusObserved) DeepCopy() *OperandStatusObserved {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandStatusObserved)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandStatusRequest) DeepCopyInto(out *OperandStatusRequest) {
+	*out = *in
+	out.Request = in.Request
+	out.Registry = in.Registry
+	out.Phase = in.Phase
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]OperandCRMember, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandStatusRequest.
+func (in *OperandStatusRequest) DeepCopy() *OperandStatusRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandStatusRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Operator) DeepCopyInto(out *Operator) {
 	*out = *in
@@ -595,6 +1349,11 @@ func (in *Operator) DeepCopyInto(out *Operator) {
 		*out = new(operatorsv1alpha1.SubscriptionConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(Timeouts)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Operator.
@@ -627,6 +1386,36 @@ func (in *OperatorStatus) DeepCopy() *OperatorStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyRule) DeepCopyInto(out *PolicyRule) {
+	*out = *in
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Operators != nil {
+		in, out := &in.Operators, &out.Operators
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRule.
+func (in *PolicyRule) DeepCopy() *PolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReconcileRequest) DeepCopyInto(out *ReconcileRequest) {
 	*out = *in
@@ -642,6 +1431,21 @@ func (in *ReconcileRequest) DeepCopy() *ReconcileRequest {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryReference) DeepCopyInto(out *RegistryReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryReference.
+func (in *RegistryReference) DeepCopy() *RegistryReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Request) DeepCopyInto(out *Request) {
 	*out = *in
@@ -652,6 +1456,11 @@ func (in *Request) DeepCopyInto(out *Request) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FallbackRegistries != nil {
+		in, out := &in.FallbackRegistries, &out.FallbackRegistries
+		*out = make([]RegistryReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Request.
@@ -664,6 +1473,81 @@ func (in *Request) DeepCopy() *Request {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollbackStatus) DeepCopyInto(out *RollbackStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackStatus.
+func (in *RollbackStatus) DeepCopy() *RollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	if in.BatchStartTime != nil {
+		in, out := &in.BatchStartTime, &out.BatchStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CurrentBatch != nil {
+		in, out := &in.CurrentBatch, &out.CurrentBatch
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Promoted != nil {
+		in, out := &in.Promoted, &out.Promoted
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
+	*out = *in
+	if in.CanaryNamespaces != nil {
+		in, out := &in.CanaryNamespaces, &out.CanaryNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BatchTimeoutSeconds != nil {
+		in, out := &in.BatchTimeoutSeconds, &out.BatchTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategy.
+func (in *RolloutStrategy) DeepCopy() *RolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretConfigmap) DeepCopyInto(out *SecretConfigmap) {
 	*out = *in
@@ -678,3 +1562,53 @@ func (in *SecretConfigmap) DeepCopy() *SecretConfigmap {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubscriptionHealth) DeepCopyInto(out *SubscriptionHealth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionHealth.
+func (in *SubscriptionHealth) DeepCopy() *SubscriptionHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscriptionHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Timeouts) DeepCopyInto(out *Timeouts) {
+	*out = *in
+	if in.CRDeleteTimeout != nil {
+		in, out := &in.CRDeleteTimeout, &out.CRDeleteTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SubDeleteTimeout != nil {
+		in, out := &in.SubDeleteTimeout, &out.SubDeleteTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RequeueDuration != nil {
+		in, out := &in.RequeueDuration, &out.RequeueDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SyncPeriod != nil {
+		in, out := &in.SyncPeriod, &out.SyncPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Timeouts.
+func (in *Timeouts) DeepCopy() *Timeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(Timeouts)
+	in.DeepCopyInto(out)
+	return out
+}
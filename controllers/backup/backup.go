@@ -0,0 +1,377 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/util"
+)
+
+// ArchiveVersion is the version of the archive format, an archive of another version can't be restored
+const ArchiveVersion = "v1"
+
+// Archive holds the state managed by ODLM
+type Archive struct {
+	// Version is the version of the archive format
+	Version string `json:"version"`
+	// CreationTimestamp is when the archive was created
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+
+	OperandRegistries  []operatorv1alpha1.OperandRegistry  `json:"operandRegistries,omitempty"`
+	OperandConfigs     []operatorv1alpha1.OperandConfig    `json:"operandConfigs,omitempty"`
+	OperandInventories []operatorv1alpha1.OperandInventory `json:"operandInventories,omitempty"`
+	OperandRequests    []operatorv1alpha1.OperandRequest   `json:"operandRequests,omitempty"`
+	OperandBindInfos   []operatorv1alpha1.OperandBindInfo  `json:"operandBindInfos,omitempty"`
+	// The cluster scoped resources are only exported by the backup of all the namespaces
+	OperandPolicies         []operatorv1alpha1.OperandPolicy          `json:"operandPolicies,omitempty"`
+	ClusterOperandRequests  []operatorv1alpha1.ClusterOperandRequest  `json:"clusterOperandRequests,omitempty"`
+	OperandRequestTemplates []operatorv1alpha1.OperandRequestTemplate `json:"operandRequestTemplates,omitempty"`
+	// Subscriptions holds the ODLM labels and the owner annotations of the Subscriptions managed by ODLM
+	Subscriptions []SubscriptionOwnership `json:"subscriptions,omitempty"`
+}
+
+// SubscriptionOwnership defines the ODLM labels and the owner annotations of a Subscription
+type SubscriptionOwnership struct {
+	Namespace   string            `json:"namespace"`
+	Name        string            `json:"name"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Backup exports the OperandRegistries, OperandConfigs, OperandInventories, OperandRequests and OperandBindInfos,
+// and the ownership of the Subscriptions managed by ODLM. All the namespaces are exported when the namespace is empty,
+// together with the OperandPolicies, ClusterOperandRequests and OperandRequestTemplates.
+// The OperandRequests generated from a ClusterOperandRequest or an OperandRequestTemplate are not exported, they are generated again.
+// The status and the metadata set by the API server are not exported.
+func Backup(ctx context.Context, reader client.Reader, namespace string) (*Archive, error) {
+	archive := &Archive{
+		Version:           ArchiveVersion,
+		CreationTimestamp: metav1.Now(),
+	}
+	opts := []client.ListOption{client.InNamespace(namespace)}
+
+	registryList := &operatorv1alpha1.OperandRegistryList{}
+	if err := reader.List(ctx, registryList, opts...); err != nil {
+		return nil, errors.Wrap(err, "failed to list OperandRegistries")
+	}
+	for _, item := range registryList.Items {
+		if item.DeletionTimestamp != nil {
+			continue
+		}
+		item.Status = operatorv1alpha1.OperandRegistryStatus{}
+		cleanObjectMeta(&item.ObjectMeta)
+		archive.OperandRegistries = append(archive.OperandRegistries, item)
+	}
+
+	configList := &operatorv1alpha1.OperandConfigList{}
+	if err := reader.List(ctx, configList, opts...); err != nil {
+		return nil, errors.Wrap(err, "failed to list OperandConfigs")
+	}
+	for _, item := range configList.Items {
+		if item.DeletionTimestamp != nil {
+			continue
+		}
+		item.Status = operatorv1alpha1.OperandConfigStatus{}
+		cleanObjectMeta(&item.ObjectMeta)
+		archive.OperandConfigs = append(archive.OperandConfigs, item)
+	}
+
+	inventoryList := &operatorv1alpha1.OperandInventoryList{}
+	if err := reader.List(ctx, inventoryList, opts...); err != nil {
+		return nil, errors.Wrap(err, "failed to list OperandInventories")
+	}
+	for _, item := range inventoryList.Items {
+		if item.DeletionTimestamp != nil {
+			continue
+		}
+		cleanObjectMeta(&item.ObjectMeta)
+		archive.OperandInventories = append(archive.OperandInventories, item)
+	}
+
+	requestList := &operatorv1alpha1.OperandRequestList{}
+	if err := reader.List(ctx, requestList, opts...); err != nil {
+		return nil, errors.Wrap(err, "failed to list OperandRequests")
+	}
+	for _, item := range requestList.Items {
+		if item.DeletionTimestamp != nil || isGeneratedRequest(&item) {
+			continue
+		}
+		item.Status = operatorv1alpha1.OperandRequestStatus{}
+		cleanObjectMeta(&item.ObjectMeta)
+		archive.OperandRequests = append(archive.OperandRequests, item)
+	}
+
+	bindInfoList := &operatorv1alpha1.OperandBindInfoList{}
+	if err := reader.List(ctx, bindInfoList, opts...); err != nil {
+		return nil, errors.Wrap(err, "failed to list OperandBindInfos")
+	}
+	for _, item := range bindInfoList.Items {
+		if item.DeletionTimestamp != nil {
+			continue
+		}
+		item.Status = operatorv1alpha1.OperandBindInfoStatus{}
+		cleanObjectMeta(&item.ObjectMeta)
+		archive.OperandBindInfos = append(archive.OperandBindInfos, item)
+	}
+
+	subList := &olmv1alpha1.SubscriptionList{}
+	if err := reader.List(ctx, subList, append(opts, client.HasLabels{constant.OpreqLabel})...); err != nil {
+		return nil, errors.Wrap(err, "failed to list Subscriptions")
+	}
+	for _, sub := range subList.Items {
+		ownership := SubscriptionOwnership{
+			Namespace:   sub.Namespace,
+			Name:        sub.Name,
+			Labels:      make(map[string]string),
+			Annotations: make(map[string]string),
+		}
+		for _, label := range []string{constant.OpreqLabel, constant.NotUninstallLabel} {
+			if value, ok := sub.Labels[label]; ok {
+				ownership.Labels[label] = value
+			}
+		}
+		for anno, value := range sub.Annotations {
+			if deploy.OwnerAnnotation.MatchString(anno) {
+				ownership.Annotations[anno] = value
+			}
+		}
+		archive.Subscriptions = append(archive.Subscriptions, ownership)
+	}
+
+	klog.Infof("Exported %d OperandRegistries, %d OperandConfigs, %d OperandInventories, %d OperandRequests, %d OperandBindInfos and %d Subscriptions",
+		len(archive.OperandRegistries), len(archive.OperandConfigs), len(archive.OperandInventories), len(archive.OperandRequests), len(archive.OperandBindInfos), len(archive.Subscriptions))
+
+	if namespace == "" {
+		if err := backupClusterResources(ctx, reader, archive); err != nil {
+			return nil, err
+		}
+	}
+	return archive, nil
+}
+
+// backupClusterResources exports the OperandPolicies, ClusterOperandRequests and OperandRequestTemplates
+func backupClusterResources(ctx context.Context, reader client.Reader, archive *Archive) error {
+	policyList := &operatorv1alpha1.OperandPolicyList{}
+	if err := reader.List(ctx, policyList); err != nil {
+		return errors.Wrap(err, "failed to list OperandPolicies")
+	}
+	for _, item := range policyList.Items {
+		if item.DeletionTimestamp != nil {
+			continue
+		}
+		cleanObjectMeta(&item.ObjectMeta)
+		archive.OperandPolicies = append(archive.OperandPolicies, item)
+	}
+
+	clusterRequestList := &operatorv1alpha1.ClusterOperandRequestList{}
+	if err := reader.List(ctx, clusterRequestList); err != nil {
+		return errors.Wrap(err, "failed to list ClusterOperandRequests")
+	}
+	for _, item := range clusterRequestList.Items {
+		if item.DeletionTimestamp != nil {
+			continue
+		}
+		item.Status = operatorv1alpha1.ClusterOperandRequestStatus{}
+		cleanObjectMeta(&item.ObjectMeta)
+		archive.ClusterOperandRequests = append(archive.ClusterOperandRequests, item)
+	}
+
+	templateList := &operatorv1alpha1.OperandRequestTemplateList{}
+	if err := reader.List(ctx, templateList); err != nil {
+		return errors.Wrap(err, "failed to list OperandRequestTemplates")
+	}
+	for _, item := range templateList.Items {
+		if item.DeletionTimestamp != nil {
+			continue
+		}
+		item.Status = operatorv1alpha1.OperandRequestTemplateStatus{}
+		cleanObjectMeta(&item.ObjectMeta)
+		archive.OperandRequestTemplates = append(archive.OperandRequestTemplates, item)
+	}
+
+	klog.Infof("Exported %d OperandPolicies, %d ClusterOperandRequests and %d OperandRequestTemplates",
+		len(archive.OperandPolicies), len(archive.ClusterOperandRequests), len(archive.OperandRequestTemplates))
+	return nil
+}
+
+// isGeneratedRequest returns true when the OperandRequest is controlled by a ClusterOperandRequest or an OperandRequestTemplate
+func isGeneratedRequest(request *operatorv1alpha1.OperandRequest) bool {
+	owner := metav1.GetControllerOf(request)
+	return owner != nil && (owner.Kind == "ClusterOperandRequest" || owner.Kind == "OperandRequestTemplate")
+}
+
+// restoreObject is an object of the archive to be created
+type restoreObject struct {
+	kind string
+	obj  client.Object
+}
+
+// Restore recreates the objects of the archive in dependency order: the OperandPolicies, the namespaces, the OperandRegistries
+// and OperandConfigs, the OperandInventories, the OperandRequests, the OperandBindInfos, and the ClusterOperandRequests and
+// OperandRequestTemplates generating their OperandRequests. The OperandPolicies come first, so they apply to the restored
+// OperandRequests. The ODLM controllers then recreate the Subscriptions and the custom resources, and copy the secrets and
+// configmaps of the OperandBindInfos.
+// The ownership is restored on the Subscriptions which already exist. The objects which already exist are not changed.
+func Restore(ctx context.Context, c client.Client, archive *Archive) error {
+	if archive.Version != ArchiveVersion {
+		return fmt.Errorf("unsupported archive version %q, expected %q", archive.Version, ArchiveVersion)
+	}
+	merr := &util.MultiErr{}
+
+	// the objects in dependency order
+	var objects []restoreObject
+	for i := range archive.OperandPolicies {
+		objects = append(objects, restoreObject{"OperandPolicy", &archive.OperandPolicies[i]})
+	}
+	for i := range archive.OperandRegistries {
+		objects = append(objects, restoreObject{"OperandRegistry", &archive.OperandRegistries[i]})
+	}
+	for i := range archive.OperandConfigs {
+		objects = append(objects, restoreObject{"OperandConfig", &archive.OperandConfigs[i]})
+	}
+	for i := range archive.OperandInventories {
+		objects = append(objects, restoreObject{"OperandInventory", &archive.OperandInventories[i]})
+	}
+	for i := range archive.OperandRequests {
+		objects = append(objects, restoreObject{"OperandRequest", &archive.OperandRequests[i]})
+	}
+	for i := range archive.OperandBindInfos {
+		objects = append(objects, restoreObject{"OperandBindInfo", &archive.OperandBindInfos[i]})
+	}
+	for i := range archive.ClusterOperandRequests {
+		objects = append(objects, restoreObject{"ClusterOperandRequest", &archive.ClusterOperandRequests[i]})
+	}
+	for i := range archive.OperandRequestTemplates {
+		objects = append(objects, restoreObject{"OperandRequestTemplate", &archive.OperandRequestTemplates[i]})
+	}
+
+	namespaces := make(map[string]bool)
+	for _, o := range objects {
+		// the cluster scoped resources don't have a namespace
+		if o.obj.GetNamespace() == "" || namespaces[o.obj.GetNamespace()] {
+			continue
+		}
+		namespaces[o.obj.GetNamespace()] = true
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: o.obj.GetNamespace()}}
+		if err := c.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+			merr.Add(errors.Wrapf(err, "failed to create namespace %s", ns.Name))
+		}
+	}
+
+	for _, o := range objects {
+		key := types.NamespacedName{Namespace: o.obj.GetNamespace(), Name: o.obj.GetName()}
+		// the archive is not changed by the creation
+		if err := c.Create(ctx, o.obj.DeepCopyObject().(client.Object)); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				klog.Infof("%s %s already exists, skip restoring it", o.kind, key.String())
+				continue
+			}
+			merr.Add(errors.Wrapf(err, "failed to restore %s %s", o.kind, key.String()))
+			continue
+		}
+		klog.Infof("Restored %s %s", o.kind, key.String())
+	}
+
+	for _, ownership := range archive.Subscriptions {
+		if err := restoreSubscriptionOwnership(ctx, c, ownership); err != nil {
+			merr.Add(err)
+		}
+	}
+
+	if len(merr.Errors) != 0 {
+		return merr
+	}
+	return nil
+}
+
+// restoreSubscriptionOwnership sets the ODLM labels and the owner annotations on the Subscription if it exists
+func restoreSubscriptionOwnership(ctx context.Context, c client.Client, ownership SubscriptionOwnership) error {
+	key := types.NamespacedName{Namespace: ownership.Namespace, Name: ownership.Name}
+	sub := &olmv1alpha1.Subscription{}
+	if err := c.Get(ctx, key, sub); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.Infof("Subscription %s doesn't exist, ODLM creates it for the restored OperandRequests", key.String())
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get Subscription %s", key.String())
+	}
+	originalSub := sub.DeepCopy()
+	if sub.Labels == nil {
+		sub.Labels = make(map[string]string)
+	}
+	for label, value := range ownership.Labels {
+		sub.Labels[label] = value
+	}
+	if sub.Annotations == nil {
+		sub.Annotations = make(map[string]string)
+	}
+	for anno, value := range ownership.Annotations {
+		sub.Annotations[anno] = value
+	}
+	if err := c.Patch(ctx, sub, client.MergeFrom(originalSub)); err != nil {
+		return errors.Wrapf(err, "failed to restore the ownership of Subscription %s", key.String())
+	}
+	klog.Infof("Restored the ownership of Subscription %s", key.String())
+	return nil
+}
+
+// WriteArchive writes the archive in JSON format
+func WriteArchive(w io.Writer, archive *Archive) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(archive)
+}
+
+// ReadArchive reads the archive in JSON format
+func ReadArchive(r io.Reader) (*Archive, error) {
+	archive := &Archive{}
+	if err := json.NewDecoder(r).Decode(archive); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the archive")
+	}
+	if archive.Version != ArchiveVersion {
+		return nil, fmt.Errorf("unsupported archive version %q, expected %q", archive.Version, ArchiveVersion)
+	}
+	return archive, nil
+}
+
+// cleanObjectMeta removes the metadata set by the API server and the controllers, so that the object can be created again
+func cleanObjectMeta(meta *metav1.ObjectMeta) {
+	meta.ResourceVersion = ""
+	meta.UID = ""
+	meta.Generation = 0
+	meta.CreationTimestamp = metav1.Time{}
+	meta.ManagedFields = nil
+	meta.OwnerReferences = nil
+	meta.Finalizers = nil
+	meta.SelfLink = ""
+}
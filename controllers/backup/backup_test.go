@@ -0,0 +1,200 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+)
+
+// recordingClient records the kinds of the created objects
+type recordingClient struct {
+	client.Client
+	created []string
+}
+
+func (c *recordingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	c.created = append(c.created, fmt.Sprintf("%T", obj))
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+var _ = Describe("Backup and restore", func() {
+	ctx := context.Background()
+
+	createNamespace := func(name string) {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if err := k8sClient.Create(ctx, ns); !apierrors.IsAlreadyExists(err) {
+			Expect(err).ShouldNot(HaveOccurred())
+		}
+	}
+	request := func(name, namespace, registryNamespace string) *operatorv1alpha1.OperandRequest {
+		return &operatorv1alpha1.OperandRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: operatorv1alpha1.OperandRequestSpec{Requests: []operatorv1alpha1.Request{{
+				Registry:          "common-service",
+				RegistryNamespace: registryNamespace,
+				Operands:          []operatorv1alpha1.Operand{{Name: "etcd"}},
+			}}},
+		}
+	}
+	deleteAll := func(objs ...client.Object) {
+		for _, obj := range objs {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, obj))).Should(Succeed())
+		}
+	}
+
+	It("Should restore the backed up state in dependency order", func() {
+		for _, ns := range []string{"backup-services", "backup-app", "backup-etcd"} {
+			createNamespace(ns)
+		}
+		registry := &operatorv1alpha1.OperandRegistry{
+			ObjectMeta: metav1.ObjectMeta{Name: "common-service", Namespace: "backup-services"},
+			Spec:       operatorv1alpha1.OperandRegistrySpec{Operators: []operatorv1alpha1.Operator{{Name: "etcd", PackageName: "etcd", Channel: "alpha"}}},
+		}
+		config := &operatorv1alpha1.OperandConfig{ObjectMeta: metav1.ObjectMeta{Name: "common-service", Namespace: "backup-services"}}
+		req := request("etcd", "backup-app", "backup-services")
+		req.Finalizers = []string{"finalizer.request.ibm.com"}
+		bindInfo := &operatorv1alpha1.OperandBindInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "backup-services"},
+			Spec:       operatorv1alpha1.OperandBindInfoSpec{Operand: "etcd", Registry: "common-service"},
+		}
+		sub := &olmv1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "etcd",
+				Namespace:   "backup-etcd",
+				Labels:      map[string]string{constant.OpreqLabel: "true"},
+				Annotations: map[string]string{"backup-app.etcd.etcd/request": "etcd", "description": "etcd operator"},
+			},
+			Spec: &olmv1alpha1.SubscriptionSpec{Package: "etcd", CatalogSource: "community-operators", CatalogSourceNamespace: "openshift-marketplace"},
+		}
+		policy := &operatorv1alpha1.OperandPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-tenants"},
+			Spec:       operatorv1alpha1.OperandPolicySpec{Namespaces: []string{"backup-app"}, Rules: []operatorv1alpha1.PolicyRule{{Operators: []string{"etcd"}}}},
+		}
+		clusterRequest := &operatorv1alpha1.ClusterOperandRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-cluster"},
+			Spec:       request("", "", "backup-services").Spec,
+		}
+		template := &operatorv1alpha1.OperandRequestTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-tenant"},
+			Spec: operatorv1alpha1.OperandRequestTemplateSpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}},
+				Template:          request("", "", "backup-services").Spec,
+			},
+		}
+		for _, obj := range []client.Object{policy, registry, config, req, bindInfo, sub, clusterRequest, template} {
+			Expect(k8sClient.Create(ctx, obj)).Should(Succeed())
+		}
+		generated := request("backup-cluster", "backup-services", "backup-services")
+		generated.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(clusterRequest, operatorv1alpha1.GroupVersion.WithKind("ClusterOperandRequest"))}
+		Expect(k8sClient.Create(ctx, generated)).Should(Succeed())
+
+		archive, err := Backup(ctx, k8sClient, "")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(archive.OperandRegistries).Should(HaveLen(1))
+		Expect(archive.OperandRegistries[0].ResourceVersion).Should(BeEmpty())
+		Expect(archive.OperandRegistries[0].UID).Should(BeEmpty())
+		Expect(archive.OperandRequests).Should(HaveLen(1))
+		Expect(archive.OperandRequests[0].Name).Should(Equal("etcd"))
+		Expect(archive.OperandRequests[0].Finalizers).Should(BeEmpty())
+		Expect(archive.OperandPolicies).Should(HaveLen(1))
+		Expect(archive.ClusterOperandRequests).Should(HaveLen(1))
+		Expect(archive.OperandRequestTemplates).Should(HaveLen(1))
+		Expect(archive.Subscriptions).Should(HaveLen(1))
+		Expect(archive.Subscriptions[0].Annotations).Should(Equal(map[string]string{"backup-app.etcd.etcd/request": "etcd"}))
+
+		buf := &bytes.Buffer{}
+		Expect(WriteArchive(buf, archive)).Should(Succeed())
+		archive, err = ReadArchive(buf)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		// Delete the backed up state, the Subscription is kept without its ownership
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(req), req)).Should(Succeed())
+		req.Finalizers = nil
+		Expect(k8sClient.Update(ctx, req)).Should(Succeed())
+		deleteAll(policy, registry, config, req, bindInfo, clusterRequest, template, generated)
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(sub), sub)).Should(Succeed())
+		sub.Annotations = nil
+		Expect(k8sClient.Update(ctx, sub)).Should(Succeed())
+
+		target := &recordingClient{Client: k8sClient}
+		Expect(Restore(ctx, target, archive)).Should(Succeed())
+		Expect(target.created).Should(Equal([]string{
+			"*v1.Namespace",
+			"*v1.Namespace",
+			"*v1alpha1.OperandPolicy",
+			"*v1alpha1.OperandRegistry",
+			"*v1alpha1.OperandConfig",
+			"*v1alpha1.OperandRequest",
+			"*v1alpha1.OperandBindInfo",
+			"*v1alpha1.ClusterOperandRequest",
+			"*v1alpha1.OperandRequestTemplate",
+		}))
+
+		restoredRegistry := &operatorv1alpha1.OperandRegistry{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(registry), restoredRegistry)).Should(Succeed())
+		Expect(restoredRegistry.Spec.Operators[0].Channel).Should(Equal("alpha"))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), &operatorv1alpha1.OperandPolicy{})).Should(Succeed())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(clusterRequest), &operatorv1alpha1.ClusterOperandRequest{})).Should(Succeed())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(template), &operatorv1alpha1.OperandRequestTemplate{})).Should(Succeed())
+		// the generated OperandRequest is generated again by the restored ClusterOperandRequest
+		err = k8sClient.Get(ctx, client.ObjectKeyFromObject(generated), &operatorv1alpha1.OperandRequest{})
+		Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+		restoredSub := &olmv1alpha1.Subscription{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "etcd", Namespace: "backup-etcd"}, restoredSub)).Should(Succeed())
+		Expect(restoredSub.Annotations).Should(HaveKeyWithValue("backup-app.etcd.etcd/request", "etcd"))
+
+		// restoring again leaves the existing objects unchanged
+		Expect(Restore(ctx, k8sClient, archive)).Should(Succeed())
+		deleteAll(policy, registry, config, req, bindInfo, clusterRequest, template, sub)
+	})
+
+	It("Should not back up the cluster scoped resources with the namespace", func() {
+		createNamespace("backup-tenant")
+		policy := &operatorv1alpha1.OperandPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-namespaced"},
+			Spec:       operatorv1alpha1.OperandPolicySpec{Namespaces: []string{"backup-tenant"}},
+		}
+		req := request("etcd", "backup-tenant", "backup-services")
+		Expect(k8sClient.Create(ctx, policy)).Should(Succeed())
+		Expect(k8sClient.Create(ctx, req)).Should(Succeed())
+		defer deleteAll(policy, req)
+
+		archive, err := Backup(ctx, k8sClient, "backup-tenant")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(archive.OperandRequests).Should(HaveLen(1))
+		Expect(archive.OperandPolicies).Should(BeEmpty())
+	})
+
+	It("Should reject an archive of another version", func() {
+		_, err := ReadArchive(bytes.NewBufferString(`{"version": "v0"}`))
+		Expect(err).Should(HaveOccurred())
+	})
+})
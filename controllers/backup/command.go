@@ -0,0 +1,99 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package backup
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The subcommands of the manager
+const (
+	CommandBackup  = "backup"
+	CommandRestore = "restore"
+)
+
+// RunCommand runs the backup or the restore subcommand of the manager with its arguments
+func RunCommand(command string, args []string, scheme *runtime.Scheme) error {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	kubeconfig := fs.String("kubeconfig", "", "Path to the kubeconfig file, the in-cluster config or $KUBECONFIG is used when it is not set")
+	file := fs.String("file", "-", "Path to the archive, - means stdout for backup and stdin for restore")
+	namespace := fs.String("namespace", "", "Namespace to back up, all the namespaces are backed up when it is not set")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var cfg *rest.Config
+	var err error
+	if *kubeconfig != "" {
+		cfg, err = clientcmd.BuildConfigFromFlags("", *kubeconfig)
+	} else {
+		cfg, err = ctrl.GetConfig()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get the kubeconfig: %v", err)
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create the client: %v", err)
+	}
+
+	ctx := context.Background()
+	switch command {
+	case CommandBackup:
+		archive, err := Backup(ctx, c, *namespace)
+		if err != nil {
+			return err
+		}
+		var w io.Writer = os.Stdout
+		if *file != "-" {
+			f, err := os.Create(*file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+		return WriteArchive(w, archive)
+	case CommandRestore:
+		var r io.Reader = os.Stdin
+		if *file != "-" {
+			f, err := os.Open(*file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		archive, err := ReadArchive(r)
+		if err != nil {
+			return err
+		}
+		return Restore(ctx, c, archive)
+	default:
+		return fmt.Errorf("unknown command %s", command)
+	}
+}
@@ -0,0 +1,313 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package catalog
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/klog"
+	"sigs.k8s.io/yaml"
+)
+
+// The annotations of a bundle recording its package and channels
+const (
+	bundlePackageAnnotation        = "operators.operatorframework.io.bundle.package.v1"
+	bundleDefaultChannelAnnotation = "operators.operatorframework.io.bundle.channel.default.v1"
+	bundleChannelsAnnotation       = "operators.operatorframework.io.bundle.channels.v1"
+	almExamplesAnnotation          = "alm-examples"
+)
+
+// Package is an operator package read from a catalog
+type Package struct {
+	Name string
+	// DefaultChannel is the default channel of the package, it is empty when the catalog doesn't define it
+	DefaultChannel string
+	// ALMExamples is the alm-examples annotation of the latest bundle of the default channel
+	ALMExamples string
+}
+
+// fbcEntry is an entry of an olm.channel
+type fbcEntry struct {
+	Name     string   `json:"name"`
+	Replaces string   `json:"replaces,omitempty"`
+	Skips    []string `json:"skips,omitempty"`
+}
+
+// fbcProperty is a property of an olm.bundle
+type fbcProperty struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// fbcObject is a blob of a file-based catalog, the fields are read according to its schema
+type fbcObject struct {
+	Schema         string        `json:"schema"`
+	Name           string        `json:"name"`
+	Package        string        `json:"package"`
+	DefaultChannel string        `json:"defaultChannel"`
+	Entries        []fbcEntry    `json:"entries"`
+	Properties     []fbcProperty `json:"properties"`
+}
+
+// objectMeta is the part of a manifest read from the catalog
+type objectMeta struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name        string            `json:"name"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Spec struct {
+		Version string `json:"version"`
+	} `json:"spec"`
+}
+
+// ReadFBC reads the packages of an OLM file-based catalog directory.
+// The alm-examples are read from the olm.csv.metadata or the olm.bundle.object properties of the channel head.
+func ReadFBC(dir string) ([]Package, error) {
+	var packages []string
+	defaultChannels := make(map[string]string)
+	channels := make(map[string]map[string][]fbcEntry)
+	bundles := make(map[string]map[string]string)
+
+	err := walkManifests(dir, func(path string, data []byte) error {
+		var obj fbcObject
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return errors.Wrapf(err, "failed to parse %s", path)
+		}
+		switch obj.Schema {
+		case "olm.package":
+			packages = append(packages, obj.Name)
+			defaultChannels[obj.Name] = obj.DefaultChannel
+		case "olm.channel":
+			if channels[obj.Package] == nil {
+				channels[obj.Package] = make(map[string][]fbcEntry)
+			}
+			channels[obj.Package][obj.Name] = obj.Entries
+		case "olm.bundle":
+			if bundles[obj.Package] == nil {
+				bundles[obj.Package] = make(map[string]string)
+			}
+			almExamples, err := bundleALMExamples(obj.Properties)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read bundle %s in %s", obj.Name, path)
+			}
+			bundles[obj.Package][obj.Name] = almExamples
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Package
+	for _, name := range packages {
+		pkg := Package{Name: name, DefaultChannel: defaultChannels[name]}
+		if pkg.DefaultChannel == "" && len(channels[name]) == 1 {
+			for channel := range channels[name] {
+				pkg.DefaultChannel = channel
+			}
+		}
+		if head := channelHead(channels[name][pkg.DefaultChannel]); head != "" {
+			pkg.ALMExamples = bundles[name][head]
+		} else {
+			klog.Warningf("Package %s has no bundle in its default channel %q", name, pkg.DefaultChannel)
+		}
+		result = append(result, pkg)
+	}
+	sortPackages(result)
+	return result, nil
+}
+
+// ReadBundles reads the packages of the bundle ClusterServiceVersions in a directory.
+// The package and the default channel are read from the metadata/annotations.yaml of the bundle,
+// otherwise the package is the prefix of the ClusterServiceVersion name and the channel is left to the catalog.
+// The latest version of the ClusterServiceVersions of a package provides the alm-examples.
+func ReadBundles(dir string) ([]Package, error) {
+	latest := make(map[string]semver.Version)
+	packages := make(map[string]*Package)
+
+	err := walkManifests(dir, func(path string, data []byte) error {
+		var obj objectMeta
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return errors.Wrapf(err, "failed to parse %s", path)
+		}
+		if obj.Kind != "ClusterServiceVersion" {
+			return nil
+		}
+		pkg, err := bundlePackage(path, obj.Metadata.Name)
+		if err != nil {
+			return err
+		}
+		version, err := semver.ParseTolerant(obj.Spec.Version)
+		if err != nil {
+			klog.Warningf("ClusterServiceVersion %s in %s has an invalid version %q", obj.Metadata.Name, path, obj.Spec.Version)
+		}
+		if found, ok := packages[pkg.Name]; ok {
+			if !version.GT(latest[pkg.Name]) {
+				return nil
+			}
+			if pkg.DefaultChannel == "" {
+				pkg.DefaultChannel = found.DefaultChannel
+			}
+		}
+		pkg.ALMExamples = obj.Metadata.Annotations[almExamplesAnnotation]
+		packages[pkg.Name] = pkg
+		latest[pkg.Name] = version
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Package
+	for _, pkg := range packages {
+		result = append(result, *pkg)
+	}
+	sortPackages(result)
+	return result, nil
+}
+
+// bundlePackage returns the package of the ClusterServiceVersion from the metadata of its bundle
+func bundlePackage(csvPath, csvName string) (*Package, error) {
+	pkg := &Package{Name: strings.SplitN(csvName, ".", 2)[0]}
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(filepath.Dir(csvPath)), "metadata", "annotations.yaml"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return pkg, nil
+		}
+		return nil, err
+	}
+	metadata := struct {
+		Annotations map[string]string `json:"annotations"`
+	}{}
+	if err := yaml.Unmarshal(data, &metadata); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the bundle metadata of %s", csvPath)
+	}
+	if name := metadata.Annotations[bundlePackageAnnotation]; name != "" {
+		pkg.Name = name
+	}
+	pkg.DefaultChannel = metadata.Annotations[bundleDefaultChannelAnnotation]
+	if channels := strings.Split(metadata.Annotations[bundleChannelsAnnotation], ","); pkg.DefaultChannel == "" && len(channels) == 1 {
+		pkg.DefaultChannel = strings.TrimSpace(channels[0])
+	}
+	return pkg, nil
+}
+
+// bundleALMExamples returns the alm-examples in the properties of an olm.bundle
+func bundleALMExamples(properties []fbcProperty) (string, error) {
+	for _, p := range properties {
+		switch p.Type {
+		case "olm.csv.metadata":
+			metadata := struct {
+				Annotations map[string]string `json:"annotations"`
+			}{}
+			if err := json.Unmarshal(p.Value, &metadata); err != nil {
+				return "", err
+			}
+			return metadata.Annotations[almExamplesAnnotation], nil
+		case "olm.bundle.object":
+			object := struct {
+				Data []byte `json:"data"`
+			}{}
+			if err := json.Unmarshal(p.Value, &object); err != nil {
+				return "", err
+			}
+			var obj objectMeta
+			if err := json.Unmarshal(object.Data, &obj); err != nil {
+				return "", err
+			}
+			if obj.Kind == "ClusterServiceVersion" {
+				return obj.Metadata.Annotations[almExamplesAnnotation], nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// channelHead returns the entry of the channel which isn't replaced or skipped by another entry
+func channelHead(entries []fbcEntry) string {
+	replaced := make(map[string]bool)
+	for _, e := range entries {
+		replaced[e.Replaces] = true
+		for _, skip := range e.Skips {
+			replaced[skip] = true
+		}
+	}
+	var heads []string
+	for _, e := range entries {
+		if !replaced[e.Name] {
+			heads = append(heads, e.Name)
+		}
+	}
+	if len(heads) == 0 {
+		return ""
+	}
+	sort.Strings(heads)
+	return heads[len(heads)-1]
+}
+
+// walkManifests calls fn with every JSON or YAML document of the files in the directory, converted to JSON
+func walkManifests(dir string, fn func(path string, data []byte) error) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".json", ".yaml", ".yml":
+		default:
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		decoder := utilyaml.NewYAMLOrJSONDecoder(f, 4096)
+		for {
+			doc := json.RawMessage{}
+			if err := decoder.Decode(&doc); err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				return errors.Wrapf(err, "failed to decode %s", path)
+			}
+			if len(doc) == 0 || string(doc) == "null" {
+				continue
+			}
+			if err := fn(path, doc); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+func sortPackages(packages []Package) {
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+}
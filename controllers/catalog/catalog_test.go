@@ -0,0 +1,157 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package catalog
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const etcdExamples = `[{"apiVersion":"etcd.database.coreos.com/v1beta2","kind":"EtcdCluster","metadata":{"name":"example"},"spec":{"size":3,"version":"3.2.13"}}]`
+
+var _ = Describe("Catalog generation", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "catalog")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).Should(Succeed())
+	})
+	writeFile := func(path, content string) {
+		path = filepath.Join(dir, path)
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).Should(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o600)).Should(Succeed())
+	}
+
+	It("Should read the default channel and the alm-examples of the channel head from a file-based catalog", func() {
+		csv := `{"apiVersion":"operators.coreos.com/v1alpha1","kind":"ClusterServiceVersion","metadata":{"name":"jenkins.v0.2.0","annotations":{"alm-examples":"[]"}}}`
+		writeFile("etcd/catalog.yaml", `---
+schema: olm.package
+name: etcd
+defaultChannel: singlenamespace-alpha
+---
+schema: olm.channel
+package: etcd
+name: singlenamespace-alpha
+entries:
+- name: etcdoperator.v0.9.2
+- name: etcdoperator.v0.9.4
+  replaces: etcdoperator.v0.9.2
+---
+schema: olm.bundle
+package: etcd
+name: etcdoperator.v0.9.2
+properties:
+- type: olm.csv.metadata
+  value:
+    annotations:
+      alm-examples: '[]'
+---
+schema: olm.bundle
+package: etcd
+name: etcdoperator.v0.9.4
+properties:
+- type: olm.csv.metadata
+  value:
+    annotations:
+      alm-examples: '`+etcdExamples+`'
+`)
+		writeFile("jenkins/catalog.json", `{"schema":"olm.package","name":"jenkins"}
+{"schema":"olm.channel","package":"jenkins","name":"alpha","entries":[{"name":"jenkins.v0.2.0"}]}
+{"schema":"olm.bundle","package":"jenkins","name":"jenkins.v0.2.0","properties":[{"type":"olm.bundle.object","value":{"data":"`+base64.StdEncoding.EncodeToString([]byte(csv))+`"}}]}
+`)
+
+		packages, err := ReadFBC(dir)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(packages).Should(Equal([]Package{
+			{Name: "etcd", DefaultChannel: "singlenamespace-alpha", ALMExamples: etcdExamples},
+			{Name: "jenkins", DefaultChannel: "alpha", ALMExamples: "[]"},
+		}))
+	})
+
+	It("Should read the latest bundle ClusterServiceVersion of each package", func() {
+		csv := func(version, examples string) string {
+			return `apiVersion: operators.coreos.com/v1alpha1
+kind: ClusterServiceVersion
+metadata:
+  name: etcdoperator.v` + version + `
+  annotations:
+    alm-examples: '` + examples + `'
+spec:
+  version: ` + version + `
+`
+		}
+		writeFile("etcd-0.9.4/manifests/etcd.clusterserviceversion.yaml", csv("0.9.4", etcdExamples))
+		writeFile("etcd-0.9.4/metadata/annotations.yaml", `annotations:
+  operators.operatorframework.io.bundle.package.v1: etcd
+  operators.operatorframework.io.bundle.channels.v1: singlenamespace-alpha,clusterwide-alpha
+  operators.operatorframework.io.bundle.channel.default.v1: singlenamespace-alpha
+`)
+		writeFile("etcd-0.9.2/manifests/etcd.clusterserviceversion.yaml", csv("0.9.2", "[]"))
+		writeFile("etcd-0.9.2/metadata/annotations.yaml", `annotations:
+  operators.operatorframework.io.bundle.package.v1: etcd
+`)
+
+		packages, err := ReadBundles(dir)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(packages).Should(Equal([]Package{{Name: "etcd", DefaultChannel: "singlenamespace-alpha", ALMExamples: etcdExamples}}))
+	})
+
+	It("Should generate the OperandRegistry and the OperandConfig skeleton", func() {
+		registry, config, err := Generate([]Package{{Name: "etcd", DefaultChannel: "singlenamespace-alpha", ALMExamples: etcdExamples}},
+			Options{Name: "common-service", Namespace: "ibm-common-services", SourceName: "community-operators", SourceNamespace: "openshift-marketplace"})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		var out bytes.Buffer
+		Expect(WriteManifests(&out, registry, config)).Should(Succeed())
+		Expect(out.String()).Should(Equal(`apiVersion: operator.ibm.com/v1alpha1
+kind: OperandRegistry
+metadata:
+  name: common-service
+  namespace: ibm-common-services
+spec:
+  operators:
+  - channel: singlenamespace-alpha
+    name: etcd
+    namespace: ibm-common-services
+    packageName: etcd
+    sourceName: community-operators
+    sourceNamespace: openshift-marketplace
+---
+apiVersion: operator.ibm.com/v1alpha1
+kind: OperandConfig
+metadata:
+  name: common-service
+  namespace: ibm-common-services
+spec:
+  services:
+  - name: etcd
+    spec:
+      etcdCluster:
+        size: 3
+        version: 3.2.13
+`))
+	})
+})
@@ -0,0 +1,102 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package catalog
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// CommandGenerate is the subcommand of the manager generating an OperandRegistry and an OperandConfig from a catalog
+const CommandGenerate = "generate"
+
+// RunCommand runs the generate subcommand of the manager with its arguments
+func RunCommand(args []string) error {
+	fs := flag.NewFlagSet(CommandGenerate, flag.ContinueOnError)
+	catalogDir := fs.String("catalog", "", "Path to the directory of an OLM file-based catalog")
+	bundlesDir := fs.String("bundles", "", "Path to the directory of the bundle ClusterServiceVersions, used when --catalog is not set")
+	packageNames := fs.String("packages", "", "Comma separated packages to generate, all the packages of the catalog are generated when it is not set")
+	file := fs.String("file", "-", "Path to the generated manifests, - means stdout")
+	opts := Options{}
+	fs.StringVar(&opts.Name, "name", "common-service", "Name of the OperandRegistry and the OperandConfig")
+	fs.StringVar(&opts.Namespace, "namespace", "ibm-common-services", "Namespace of the OperandRegistry and the OperandConfig")
+	fs.StringVar(&opts.OperatorNamespace, "operator-namespace", "", "Namespace of the operators, the default value is --namespace")
+	fs.StringVar(&opts.SourceName, "source-name", "", "Name of the CatalogSource of the operators, ODLM resolves it when it is not set")
+	fs.StringVar(&opts.SourceNamespace, "source-namespace", "", "Namespace of the CatalogSource of the operators")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var packages []Package
+	var err error
+	switch {
+	case *catalogDir != "":
+		packages, err = ReadFBC(*catalogDir)
+	case *bundlesDir != "":
+		packages, err = ReadBundles(*bundlesDir)
+	default:
+		return fmt.Errorf("either --catalog or --bundles is required")
+	}
+	if err != nil {
+		return err
+	}
+	if *packageNames != "" {
+		packages, err = filterPackages(packages, strings.Split(*packageNames, ","))
+		if err != nil {
+			return err
+		}
+	}
+	if len(packages) == 0 {
+		return fmt.Errorf("no package is found")
+	}
+
+	registry, config, err := Generate(packages, opts)
+	if err != nil {
+		return err
+	}
+	var w io.Writer = os.Stdout
+	if *file != "-" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return WriteManifests(w, registry, config)
+}
+
+// filterPackages keeps the packages in the order of the names, and fails when a package is not in the catalog
+func filterPackages(packages []Package, names []string) ([]Package, error) {
+	found := make(map[string]Package)
+	for _, pkg := range packages {
+		found[pkg.Name] = pkg
+	}
+	var filtered []Package
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		pkg, ok := found[name]
+		if !ok {
+			return nil, fmt.Errorf("package %s is not found in the catalog", name)
+		}
+		filtered = append(filtered, pkg)
+	}
+	return filtered, nil
+}
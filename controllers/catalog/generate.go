@@ -0,0 +1,135 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package catalog
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog"
+	"sigs.k8s.io/yaml"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+)
+
+// Options are the settings of the generated OperandRegistry and OperandConfig
+type Options struct {
+	Name      string
+	Namespace string
+	// OperatorNamespace is the namespace of the operators, the default value is Namespace
+	OperatorNamespace string
+	// SourceName and SourceNamespace are the CatalogSource of the operators, ODLM resolves it when they are not set
+	SourceName      string
+	SourceNamespace string
+}
+
+// Generate returns an OperandRegistry with an operator tracking the default channel of each package,
+// and an OperandConfig with a service whose spec is seeded from the alm-examples of the package
+func Generate(packages []Package, opts Options) (*operatorv1alpha1.OperandRegistry, *operatorv1alpha1.OperandConfig, error) {
+	meta := metav1.ObjectMeta{Name: opts.Name, Namespace: opts.Namespace}
+	registry := &operatorv1alpha1.OperandRegistry{
+		TypeMeta:   metav1.TypeMeta{APIVersion: operatorv1alpha1.GroupVersion.String(), Kind: "OperandRegistry"},
+		ObjectMeta: meta,
+	}
+	config := &operatorv1alpha1.OperandConfig{
+		TypeMeta:   metav1.TypeMeta{APIVersion: operatorv1alpha1.GroupVersion.String(), Kind: "OperandConfig"},
+		ObjectMeta: meta,
+	}
+
+	operatorNamespace := opts.OperatorNamespace
+	if operatorNamespace == "" {
+		operatorNamespace = opts.Namespace
+	}
+	for _, pkg := range packages {
+		registry.Spec.Operators = append(registry.Spec.Operators, operatorv1alpha1.Operator{
+			Name:            pkg.Name,
+			Namespace:       operatorNamespace,
+			PackageName:     pkg.Name,
+			Channel:         pkg.DefaultChannel,
+			SourceName:      opts.SourceName,
+			SourceNamespace: opts.SourceNamespace,
+		})
+		spec, err := configSpec(pkg.ALMExamples)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to parse the alm-examples of package %s", pkg.Name)
+		}
+		config.Spec.Services = append(config.Spec.Services, operatorv1alpha1.ConfigService{Name: pkg.Name, Spec: spec})
+	}
+	return registry, config, nil
+}
+
+// configSpec returns the spec of the custom resources in the alm-examples keyed by their kind in lower camel case
+func configSpec(almExamples string) (map[string]runtime.RawExtension, error) {
+	if almExamples == "" {
+		return nil, nil
+	}
+	var examples []struct {
+		Kind string          `json:"kind"`
+		Spec json.RawMessage `json:"spec"`
+	}
+	if err := json.Unmarshal([]byte(almExamples), &examples); err != nil {
+		return nil, err
+	}
+	spec := make(map[string]runtime.RawExtension)
+	for _, example := range examples {
+		if example.Kind == "" {
+			continue
+		}
+		key := strings.ToLower(example.Kind[:1]) + example.Kind[1:]
+		if _, ok := spec[key]; ok {
+			klog.Warningf("Skip the duplicated example of kind %s", example.Kind)
+			continue
+		}
+		raw := example.Spec
+		if len(raw) == 0 || string(raw) == "null" {
+			raw = json.RawMessage("{}")
+		}
+		spec[key] = runtime.RawExtension{Raw: raw}
+	}
+	return spec, nil
+}
+
+// WriteManifests writes the objects as a multi-document YAML stream, without their status and server-set metadata
+func WriteManifests(w io.Writer, objs ...runtime.Object) error {
+	for i, obj := range objs {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		delete(content, "status")
+		if metadata, ok := content["metadata"].(map[string]interface{}); ok {
+			delete(metadata, "creationTimestamp")
+		}
+		data, err := yaml.Marshal(content)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package clusteroperandrequest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
+)
+
+// Reconciler generates an OperandRequest in the namespace of each OperandRegistry requested by a ClusterOperandRequest.
+// The generated OperandRequests reconcile the operators and count the references like any OperandRequest,
+// and they are garbage collected with the ClusterOperandRequest owning them.
+type Reconciler struct {
+	*deploy.ODLMOperator
+}
+
+// Reconcile creates, updates or deletes the OperandRequests generated from the ClusterOperandRequest and rolls their status up
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reconcileErr error) {
+	instance := &operatorv1alpha1.ClusterOperandRequest{}
+	if err := r.Reader.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !instance.DeletionTimestamp.IsZero() {
+		// The generated OperandRequests are deleted by the garbage collector
+		return ctrl.Result{}, nil
+	}
+
+	originalInstance := instance.DeepCopy()
+
+	// Always attempt to patch the status after each reconciliation.
+	defer func() {
+		if reflect.DeepEqual(originalInstance.Status, instance.Status) {
+			return
+		}
+		if err := r.Client.Status().Patch(ctx, instance, client.MergeFrom(originalInstance)); err != nil {
+			reconcileErr = utilerrors.NewAggregate([]error{reconcileErr, fmt.Errorf("error while patching ClusterOperandRequest.Status: %v", err)})
+		}
+	}()
+
+	klog.V(2).Infof("Reconciling ClusterOperandRequest: %s", req.Name)
+
+	desired, allowed, err := r.desiredRequests(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	specs := make(map[string]operatorv1alpha1.OperandRequestSpec)
+	for ns, requests := range desired {
+		specs[ns] = operatorv1alpha1.OperandRequestSpec{
+			Requests:                requests,
+			ProgressDeadlineSeconds: instance.Spec.ProgressDeadlineSeconds,
+		}
+	}
+	if err := r.SyncGeneratedRequests(ctx, instance, constant.ClusterOpreqLabel, specs); err != nil {
+		return ctrl.Result{}, err
+	}
+	if !allowed {
+		instance.Status.Phase = operatorv1alpha1.ClusterPhaseFailed
+	}
+	klog.V(2).Infof("Finished reconciling ClusterOperandRequest: %s", req.Name)
+	return ctrl.Result{}, nil
+}
+
+// desiredRequests groups the requests allowed by their OperandRegistries by the namespace of the OperandRegistries,
+// and sets the NotAllowed conditions. It returns false when a request is not allowed.
+func (r *Reconciler) desiredRequests(ctx context.Context, instance *operatorv1alpha1.ClusterOperandRequest) (map[string][]operatorv1alpha1.Request, bool, error) {
+	allowedRegistries := make(map[types.NamespacedName]bool)
+	allowed := true
+	for _, registryKey := range instance.GetRegistryKeys() {
+		reason, err := r.checkRegistry(ctx, registryKey)
+		if err != nil {
+			return nil, false, err
+		}
+		if reason != "" {
+			klog.Warningf("ClusterOperandRequest %s is not allowed by OperandRegistry %s: %s", instance.Name, registryKey.String(), reason)
+			instance.SetNotAllowedCondition(registryKey, reason, corev1.ConditionTrue)
+			allowed = false
+			continue
+		}
+		instance.SetNotAllowedCondition(registryKey, "", corev1.ConditionFalse)
+		allowedRegistries[registryKey] = true
+	}
+
+	desired := make(map[string][]operatorv1alpha1.Request)
+	for _, req := range instance.Spec.Requests {
+		// A request is generated when its OperandRegistry and all its fallback OperandRegistries allow it
+		requestAllowed := true
+		for _, key := range operatorv1alpha1.GetRequestRegistryKeys(req) {
+			requestAllowed = requestAllowed && allowedRegistries[key]
+		}
+		if requestAllowed {
+			desired[req.RegistryNamespace] = append(desired[req.RegistryNamespace], *req.DeepCopy())
+		}
+	}
+	return desired, allowed, nil
+}
+
+// checkRegistry returns the reason why the OperandRegistry doesn't allow the ClusterOperandRequest, it is empty when it is allowed
+func (r *Reconciler) checkRegistry(ctx context.Context, registryKey types.NamespacedName) (string, error) {
+	if registryKey.Namespace == "" {
+		return "The registryNamespace of the request is required", nil
+	}
+	registry := &operatorv1alpha1.OperandRegistry{}
+	if err := r.Client.Get(ctx, registryKey, registry); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "OperandRegistry is not found", nil
+		}
+		return "", errors.Wrapf(err, "failed to get OperandRegistry %s", registryKey.String())
+	}
+	if !registry.Spec.AllowClusterRequests {
+		return "OperandRegistry doesn't allow cluster requests", nil
+	}
+	return "", nil
+}
+
+// getRegistryToClusterRequestMapper maps an OperandRegistry to the ClusterOperandRequests requesting it,
+// a change of allowClusterRequests allows or refuses them
+func (r *Reconciler) getRegistryToClusterRequestMapper() handler.MapFunc {
+	ctx := context.Background()
+	return func(object client.Object) []reconcile.Request {
+		clusterRequestList := &operatorv1alpha1.ClusterOperandRequestList{}
+		if err := r.Reader.List(ctx, clusterRequestList); err != nil {
+			klog.Warningf("failed to list ClusterOperandRequests: %v", err)
+			return nil
+		}
+		registryKey := types.NamespacedName{Namespace: object.GetNamespace(), Name: object.GetName()}
+		requests := []reconcile.Request{}
+		for _, clusterRequest := range clusterRequestList.Items {
+			for _, key := range clusterRequest.GetRegistryKeys() {
+				if key == registryKey {
+					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: clusterRequest.Name}})
+					break
+				}
+			}
+		}
+		return requests
+	}
+}
+
+// SetupWithManager adds ClusterOperandRequest controller to the manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&operatorv1alpha1.ClusterOperandRequest{}).
+		Watches(&source.Kind{Type: &operatorv1alpha1.OperandRequest{}}, handler.EnqueueRequestsFromMapFunc(deploy.GetGeneratedRequestMapper("ClusterOperandRequest"))).
+		Watches(&source.Kind{Type: &operatorv1alpha1.OperandRegistry{}}, handler.EnqueueRequestsFromMapFunc(r.getRegistryToClusterRequestMapper())).
+		Complete(r)
+}
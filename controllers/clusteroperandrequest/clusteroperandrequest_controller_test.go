@@ -0,0 +1,157 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package clusteroperandrequest
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/testutil"
+)
+
+var _ = Describe("ClusterOperandRequest", func() {
+	ctx := context.Background()
+
+	newReconciler := func(objs ...client.Object) *Reconciler {
+		c := testutil.NewFakeClient(objs...)
+		return &Reconciler{ODLMOperator: &deploy.ODLMOperator{Client: c, Reader: c, Scheme: c.Scheme()}}
+	}
+	registry := func(namespace string, allowed bool) *operatorv1alpha1.OperandRegistry {
+		return &operatorv1alpha1.OperandRegistry{
+			ObjectMeta: metav1.ObjectMeta{Name: "common-service", Namespace: namespace},
+			Spec: operatorv1alpha1.OperandRegistrySpec{
+				AllowClusterRequests: allowed,
+				Operators:            []operatorv1alpha1.Operator{{Name: "etcd", Namespace: namespace, PackageName: "etcd", Channel: "alpha"}},
+			},
+		}
+	}
+	clusterRequest := func(namespaces ...string) *operatorv1alpha1.ClusterOperandRequest {
+		cr := &operatorv1alpha1.ClusterOperandRequest{ObjectMeta: metav1.ObjectMeta{Name: "example", UID: "example-uid"}}
+		for _, ns := range namespaces {
+			cr.Spec.Requests = append(cr.Spec.Requests, operatorv1alpha1.Request{
+				Registry:          "common-service",
+				RegistryNamespace: ns,
+				Operands:          []operatorv1alpha1.Operand{{Name: "etcd", InstanceNamespace: "example-ns"}},
+			})
+		}
+		return cr
+	}
+	reconcileRequest := func(r *Reconciler) *operatorv1alpha1.ClusterOperandRequest {
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "example"}})
+		Expect(err).ShouldNot(HaveOccurred())
+		cr := &operatorv1alpha1.ClusterOperandRequest{}
+		Expect(r.Client.Get(ctx, types.NamespacedName{Name: "example"}, cr)).Should(Succeed())
+		return cr
+	}
+
+	It("Should generate an OperandRequest in the namespace of each allowed OperandRegistry", func() {
+		r := newReconciler(clusterRequest("ns-a", "ns-b"), registry("ns-a", true), registry("ns-b", false))
+		cr := reconcileRequest(r)
+
+		generated := &operatorv1alpha1.OperandRequest{}
+		Expect(r.Client.Get(ctx, types.NamespacedName{Namespace: "ns-a", Name: "example"}, generated)).Should(Succeed())
+		Expect(generated.Labels).Should(HaveKeyWithValue(constant.ClusterOpreqLabel, "example"))
+		Expect(metav1.IsControlledBy(generated, cr)).Should(BeTrue())
+		Expect(generated.Spec.Requests).Should(HaveLen(1))
+		Expect(generated.Spec.Requests[0].Operands[0].InstanceNamespace).Should(Equal("example-ns"))
+
+		err := r.Client.Get(ctx, types.NamespacedName{Namespace: "ns-b", Name: "example"}, &operatorv1alpha1.OperandRequest{})
+		Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+		Expect(cr.Status.Phase).Should(Equal(operatorv1alpha1.ClusterPhaseFailed))
+		Expect(cr.Status.OperandRequests).Should(Equal([]operatorv1alpha1.GeneratedRequest{{Namespace: "ns-a", Name: "example"}}))
+		Expect(cr.Status.Conditions).Should(HaveLen(1))
+		Expect(cr.Status.Conditions[0].Type).Should(Equal(operatorv1alpha1.ConditionNotAllowed))
+		Expect(cr.Status.Conditions[0].Reason).Should(Equal("OperandRegistry doesn't allow cluster requests"))
+	})
+
+	labeledRequest := func(namespace string, owner *operatorv1alpha1.ClusterOperandRequest) *operatorv1alpha1.OperandRequest {
+		request := &operatorv1alpha1.OperandRequest{ObjectMeta: metav1.ObjectMeta{
+			Name:      "example",
+			Namespace: namespace,
+			Labels:    map[string]string{constant.ClusterOpreqLabel: "example"},
+		}}
+		if owner != nil {
+			request.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(owner, operatorv1alpha1.GroupVersion.WithKind("ClusterOperandRequest"))}
+		}
+		return request
+	}
+
+	It("Should delete the generated OperandRequest when its OperandRegistry is no longer requested", func() {
+		r := newReconciler(clusterRequest("ns-a"), registry("ns-a", true), labeledRequest("ns-b", clusterRequest()))
+		cr := reconcileRequest(r)
+
+		err := r.Client.Get(ctx, types.NamespacedName{Namespace: "ns-b", Name: "example"}, &operatorv1alpha1.OperandRequest{})
+		Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+		Expect(cr.Status.OperandRequests).Should(HaveLen(1))
+		Expect(cr.Status.OperandRequests[0].Namespace).Should(Equal("ns-a"))
+	})
+
+	It("Should not delete an OperandRequest which only has the label of the ClusterOperandRequest", func() {
+		r := newReconciler(clusterRequest("ns-a"), registry("ns-a", true), labeledRequest("ns-b", nil))
+		reconcileRequest(r)
+
+		Expect(r.Client.Get(ctx, types.NamespacedName{Namespace: "ns-b", Name: "example"}, &operatorv1alpha1.OperandRequest{})).Should(Succeed())
+	})
+
+	It("Should only trust the OperandRequests controlled by the ClusterOperandRequest", func() {
+		other := clusterRequest("ns-a")
+		other.UID = "other-uid"
+		r := newReconciler(clusterRequest("ns-a"), registry("ns-a", true))
+
+		for _, request := range []*operatorv1alpha1.OperandRequest{labeledRequest("ns-a", nil), labeledRequest("ns-a", other), labeledRequest("ns-b", clusterRequest())} {
+			generated, err := r.IsGeneratedFromClusterRequest(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(generated).Should(BeFalse())
+		}
+		generated, err := r.IsGeneratedFromClusterRequest(ctx, labeledRequest("ns-a", clusterRequest()))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(generated).Should(BeTrue())
+	})
+
+	It("Should not take over an OperandRequest which isn't generated from the ClusterOperandRequest", func() {
+		existing := &operatorv1alpha1.OperandRequest{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "ns-a"}}
+		r := newReconciler(clusterRequest("ns-a"), registry("ns-a", true), existing)
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "example"}})
+		Expect(err).Should(MatchError(ContainSubstring("already exists and isn't generated from ClusterOperandRequest example")))
+	})
+
+	It("Should roll the phases of the generated OperandRequests up", func() {
+		cr := &operatorv1alpha1.ClusterOperandRequest{}
+		withPhase := func(ns string, phase operatorv1alpha1.ClusterPhase) operatorv1alpha1.OperandRequest {
+			return operatorv1alpha1.OperandRequest{
+				ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: ns},
+				Status:     operatorv1alpha1.OperandRequestStatus{Phase: phase},
+			}
+		}
+		cr.SetGeneratedRequests(nil)
+		Expect(cr.Status.Phase).Should(Equal(operatorv1alpha1.ClusterPhaseNone))
+		cr.SetGeneratedRequests([]operatorv1alpha1.OperandRequest{withPhase("ns-a", operatorv1alpha1.ClusterPhaseRunning), withPhase("ns-b", operatorv1alpha1.ClusterPhaseInstalling)})
+		Expect(cr.Status.Phase).Should(Equal(operatorv1alpha1.ClusterPhaseInstalling))
+		cr.SetGeneratedRequests([]operatorv1alpha1.OperandRequest{withPhase("ns-a", operatorv1alpha1.ClusterPhaseInstalling), withPhase("ns-b", operatorv1alpha1.ClusterPhaseFailed)})
+		Expect(cr.Status.Phase).Should(Equal(operatorv1alpha1.ClusterPhaseFailed))
+	})
+})
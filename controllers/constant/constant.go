@@ -52,12 +52,54 @@ const (
 	//HashedData is the key for checking the checksum of data section
 	HashedData string = "hashedData"
 
+	//LastSucceededCSVAnnotation is the annotation recording the last succeeded ClusterServiceVersion of a subscription
+	LastSucceededCSVAnnotation string = "operator.ibm.com/last-succeeded-csv"
+
+	//LastSucceededChannelAnnotation is the annotation recording the channel of the last succeeded ClusterServiceVersion of a subscription
+	LastSucceededChannelAnnotation string = "operator.ibm.com/last-succeeded-channel"
+
+	//RollbackToRevisionAnnotation is the annotation requesting to restore the spec of an OperandRegistry or OperandConfig from a revision
+	RollbackToRevisionAnnotation string = "operator.ibm.com/rollback-to-revision"
+
+	//RevisionOwnerKindLabel is the label recording the kind of the object a ControllerRevision belongs to
+	RevisionOwnerKindLabel string = "operator.ibm.com/revision-owner-kind"
+
+	//RevisionOwnerNameLabel is the label recording the name of the object a ControllerRevision belongs to
+	RevisionOwnerNameLabel string = "operator.ibm.com/revision-owner-name"
+
+	//ClusterOpreqLabel is the label recording the ClusterOperandRequest an OperandRequest is generated from
+	ClusterOpreqLabel string = "operator.ibm.com/cluster-opreq"
+
+	//OpreqTemplateLabel is the label recording the OperandRequestTemplate an OperandRequest is generated from
+	OpreqTemplateLabel string = "operator.ibm.com/opreq-template"
+
+	//ResumeRolloutAnnotation is the annotation resuming a paused rollout of an OperandRegistry
+	ResumeRolloutAnnotation string = "operator.ibm.com/resume-rollout"
+
+	//ODLMConfigMapName is the name of the ConfigMap holding the global settings of ODLM in the operator namespace
+	ODLMConfigMapName string = "operand-deployment-lifecycle-manager-config"
+
+	//GCKindsConfigMapName is the name of the ConfigMap recording the kinds swept by the garbage collector in the operator namespace
+	GCKindsConfigMapName string = "operand-deployment-lifecycle-manager-gc-kinds"
+
+	//DefaultGlobalCatalogNamespace is the default namespace of the CatalogSources OLM makes available to all the namespaces
+	DefaultGlobalCatalogNamespace string = "openshift-marketplace"
+
+	//CatalogSourceStateReady is the gRPC connection state of a CatalogSource serving the packages
+	CatalogSourceStateReady string = "READY"
+
+	//CatalogSourceStateIdle is the gRPC connection state of an idle CatalogSource connection which can still serve the packages
+	CatalogSourceStateIdle string = "IDLE"
+
 	//DefaultRequestTimeout is the default timeout for kube request
 	DefaultRequestTimeout = 5 * time.Second
 
 	//DefaultRequeueDuration is the default requeue time duration for request
 	DefaultRequeueDuration = 20 * time.Second
 
+	//DefaultDeletionRequeueDuration is the requeue time duration for checking the progress of the deletion
+	DefaultDeletionRequeueDuration = 5 * time.Second
+
 	//DefaultSyncPeriod is the frequency at which watched resources are reconciled
 	DefaultSyncPeriod = 3 * time.Hour
 
@@ -73,6 +115,18 @@ const (
 	//DefaultCRDeletePeriod is the default retry Period for deleting a custom resource
 	DefaultCRDeletePeriod = 20 * time.Second
 
+	//DefaultPackageManifestCacheTTL is the default time to live of the cached PackageManifest resolution
+	DefaultPackageManifestCacheTTL = 10 * time.Minute
+
+	//DefaultGCInterval is the default period of sweeping the orphaned resources
+	DefaultGCInterval = 30 * time.Minute
+
+	//DefaultGCGracePeriod is the default time a resource must stay orphaned before it is deleted
+	DefaultGCGracePeriod = 1 * time.Hour
+
 	//DefaultSubDeleteTimeout is the default timeout for deleting a subscription
 	DefaultSubDeleteTimeout = 10 * time.Minute
+
+	//DefaultMaxProgressBackoff is the longest interval between the retries of a member exceeding its progress deadline
+	DefaultMaxProgressBackoff = 30 * time.Minute
 )
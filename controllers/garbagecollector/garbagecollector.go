@@ -0,0 +1,283 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package garbagecollector
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/util"
+)
+
+var (
+	subscriptionGVK          = schema.GroupVersionKind{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "Subscription"}
+	clusterServiceVersionGVK = schema.GroupVersionKind{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "ClusterServiceVersion"}
+	operatorGroupGVK         = schema.GroupVersionKind{Group: "operators.coreos.com", Version: "v1", Kind: "OperatorGroup"}
+	namespaceGVK             = corev1.SchemeGroupVersion.WithKind("Namespace")
+)
+
+// Collector periodically finds the resources labeled with operator.ibm.com/opreq-control
+// which no live OperandRequest or OperandConfig accounts for, reports them with events and deletes them.
+// Namespaces are only reported, they are never deleted.
+type Collector struct {
+	*deploy.ODLMOperator
+	// Interval is the period of the sweep, the collector is disabled when it is not positive
+	Interval time.Duration
+	// GracePeriod is how long a resource must stay orphaned before it is deleted
+	GracePeriod time.Duration
+	// DryRun only reports the orphaned resources without deleting them
+	DryRun bool
+	// Namespaces are the namespaces to sweep, an empty namespace means all the namespaces
+	Namespaces []string
+
+	// orphans records when each orphaned resource was found for the first time
+	orphans map[string]time.Time
+}
+
+// SetupWithManager adds the garbage collector to the manager.
+func (c *Collector) SetupWithManager(mgr ctrl.Manager) error {
+	if c.Interval <= 0 {
+		klog.Info("Garbage collector for orphaned resources is disabled")
+		return nil
+	}
+	return mgr.Add(c)
+}
+
+// NeedLeaderElection makes the garbage collector only run on the leader.
+func (c *Collector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs the sweep periodically until the context is done.
+func (c *Collector) Start(ctx context.Context) error {
+	klog.Infof("Starting garbage collector for orphaned resources, interval: %s, grace period: %s, dry run: %v", c.Interval, c.GracePeriod, c.DryRun)
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := c.Sweep(ctx); err != nil {
+			klog.Errorf("failed to sweep orphaned resources: %v", err)
+		}
+	}, c.Interval)
+	return nil
+}
+
+// Sweep finds the orphaned resources and deletes the ones orphaned longer than the grace period.
+func (c *Collector) Sweep(ctx context.Context) error {
+	if c.orphans == nil {
+		c.orphans = make(map[string]time.Time)
+	}
+
+	inv, err := c.buildInventory(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to collect the resources requested by OperandRequests")
+	}
+	if err := c.loadKinds(ctx, inv); err != nil {
+		return err
+	}
+	candidates, subscriptionNamespaces, err := c.listLabeledResources(ctx, inv)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	merr := &util.MultiErr{}
+	found := make(map[string]bool)
+	for _, obj := range candidates {
+		if obj.GetDeletionTimestamp() != nil || c.CheckLabel(obj, map[string]string{constant.NotUninstallLabel: "true"}) {
+			continue
+		}
+		if inv.accounts(obj, subscriptionNamespaces) {
+			continue
+		}
+		key := orphanKey(obj)
+		found[key] = true
+		if err := c.collect(ctx, obj, key, now); err != nil {
+			merr.Add(err)
+		}
+	}
+
+	// Forget the resources which are deleted or accounted for again
+	for key := range c.orphans {
+		if !found[key] {
+			delete(c.orphans, key)
+		}
+	}
+
+	if err := c.saveKinds(ctx, inv, candidates); err != nil {
+		merr.Add(err)
+	}
+
+	klog.V(2).Infof("Garbage collector found %d orphaned resources", len(found))
+	if len(merr.Errors) != 0 {
+		return merr
+	}
+	return nil
+}
+
+// collect reports the orphaned resource and deletes it once the grace period is over
+func (c *Collector) collect(ctx context.Context, obj unstructured.Unstructured, key string, now time.Time) error {
+	firstSeen, ok := c.orphans[key]
+	if !ok {
+		firstSeen = now
+		c.orphans[key] = now
+		klog.Warningf("Found orphaned %s %s not accounted for by any OperandRequest", obj.GetKind(), key)
+		c.Recorder.Eventf(&obj, corev1.EventTypeWarning, "Orphaned", "%s %s is not accounted for by any OperandRequest or OperandConfig", obj.GetKind(), obj.GetName())
+	}
+
+	if obj.GroupVersionKind() == namespaceGVK {
+		return nil
+	}
+	if now.Sub(firstSeen) < c.GracePeriod || now.Sub(obj.GetCreationTimestamp().Time) < c.GracePeriod {
+		return nil
+	}
+	if c.DryRun {
+		klog.V(1).Infof("Dry run: orphaned %s %s would be deleted", obj.GetKind(), key)
+		return nil
+	}
+
+	// Uninstall the operator with its Subscription
+	if obj.GroupVersionKind() == subscriptionGVK {
+		if err := c.deleteInstalledCSV(ctx, obj); err != nil {
+			return err
+		}
+	}
+
+	klog.V(1).Infof("Deleting orphaned %s %s", obj.GetKind(), key)
+	if err := c.Delete(ctx, &obj); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete orphaned %s %s", obj.GetKind(), key)
+	}
+	c.Recorder.Eventf(&obj, corev1.EventTypeNormal, "OrphanDeleted", "Deleted orphaned %s %s", obj.GetKind(), obj.GetName())
+	delete(c.orphans, key)
+	return nil
+}
+
+func (c *Collector) deleteInstalledCSV(ctx context.Context, sub unstructured.Unstructured) error {
+	installedCSV, _, _ := unstructured.NestedString(sub.Object, "status", "installedCSV")
+	if installedCSV == "" {
+		return nil
+	}
+	csv := &unstructured.Unstructured{}
+	csv.SetGroupVersionKind(clusterServiceVersionGVK)
+	if err := c.Reader.Get(ctx, types.NamespacedName{Namespace: sub.GetNamespace(), Name: installedCSV}, csv); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	klog.V(1).Infof("Deleting the ClusterServiceVersion %s/%s of orphaned Subscription %s", csv.GetNamespace(), csv.GetName(), sub.GetName())
+	if err := c.Delete(ctx, csv); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete the ClusterServiceVersion %s/%s", csv.GetNamespace(), csv.GetName())
+	}
+	return nil
+}
+
+// listLabeledResources lists all the resources labeled with operator.ibm.com/opreq-control,
+// and the namespaces having Subscriptions
+func (c *Collector) listLabeledResources(ctx context.Context, inv *inventory) ([]unstructured.Unstructured, map[string]bool, error) {
+	var candidates []unstructured.Unstructured
+	subscriptionNamespaces := make(map[string]bool)
+	namespaces := c.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	for _, ns := range namespaces {
+		// All the Subscriptions, including the ones not created by ODLM, keep the OperatorGroups
+		subs, err := c.list(ctx, subscriptionGVK, ns, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, sub := range subs {
+			subscriptionNamespaces[sub.GetNamespace()] = true
+			if !c.CheckLabel(sub, map[string]string{constant.OpreqLabel: "true"}) {
+				continue
+			}
+			candidates = append(candidates, sub)
+			if err := c.addSubscriptionExamples(ctx, inv, sub); err != nil {
+				return nil, nil, err
+			}
+		}
+		ogs, err := c.list(ctx, operatorGroupGVK, ns, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		candidates = append(candidates, ogs...)
+	}
+
+	gvks := make(map[schema.GroupVersionKind]bool)
+	for gvk := range inv.gvks {
+		gvks[gvk] = true
+	}
+	for gvk := range inv.recordedGVKs {
+		gvks[gvk] = true
+	}
+	for gvk := range gvks {
+		for _, ns := range namespaces {
+			objs, err := c.list(ctx, gvk, ns, true)
+			if err != nil {
+				return nil, nil, err
+			}
+			candidates = append(candidates, objs...)
+		}
+	}
+
+	// Cluster scoped resources
+	for _, gvk := range []schema.GroupVersionKind{deploy.ClusterExtensionGVK, namespaceGVK} {
+		objs, err := c.list(ctx, gvk, "", true)
+		if err != nil {
+			return nil, nil, err
+		}
+		candidates = append(candidates, objs...)
+	}
+	return candidates, subscriptionNamespaces, nil
+}
+
+// list lists the resources of the kind in the namespace, the kinds not installed
+// or not allowed to be listed are skipped
+func (c *Collector) list(ctx context.Context, gvk schema.GroupVersionKind, namespace string, labeled bool) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind + "List"})
+	opts := []client.ListOption{client.InNamespace(namespace)}
+	if labeled {
+		opts = append(opts, client.MatchingLabels{constant.OpreqLabel: "true"})
+	}
+	if err := c.Reader.List(ctx, list, opts...); err != nil {
+		if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) || apierrors.IsForbidden(err) || apierrors.IsMethodNotSupported(err) {
+			klog.V(2).Infof("Skip sweeping %s in namespace %q: %v", gvk.Kind, namespace, err)
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to list %s in namespace %q", gvk.Kind, namespace)
+	}
+	for i := range list.Items {
+		list.Items[i].SetGroupVersionKind(gvk)
+	}
+	return list.Items, nil
+}
+
+func orphanKey(obj unstructured.Unstructured) string {
+	return strings.Join([]string{obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj.GetName()}, "/")
+}
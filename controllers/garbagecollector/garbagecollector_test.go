@@ -0,0 +1,211 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package garbagecollector
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/testutil"
+)
+
+const operatorNamespace = "ibm-common-services"
+
+func labeledConfigMap(namespace, name string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			Labels:            map[string]string{constant.OpreqLabel: "true"},
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+		},
+	}
+}
+
+func configMapConfig() *operatorv1alpha1.OperandConfig {
+	return &operatorv1alpha1.OperandConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: operatorNamespace, Name: "common-service"},
+		Spec: operatorv1alpha1.OperandConfigSpec{
+			Services: []operatorv1alpha1.ConfigService{{
+				Name:      "etcd",
+				Resources: []operatorv1alpha1.ConfigResource{{Name: "etcd-config", Kind: "ConfigMap", APIVersion: "v1"}},
+			}},
+		},
+	}
+}
+
+func newCollector(objs ...client.Object) (*Collector, client.Client) {
+	c := testutil.NewFakeClient(objs...)
+	return &Collector{
+		ODLMOperator: &deploy.ODLMOperator{Client: c, Reader: c, Recorder: record.NewFakeRecorder(100)},
+		GracePeriod:  time.Hour,
+	}, c
+}
+
+var _ = Describe("Sweep", func() {
+	ctx := context.Background()
+	leftoverKey := types.NamespacedName{Namespace: "app", Name: "leftover"}
+	leftoverOrphanKey := "v1/ConfigMap/app/leftover"
+
+	var previousNamespace string
+	BeforeEach(func() {
+		previousNamespace = os.Getenv("OPERATOR_NAMESPACE")
+		Expect(os.Setenv("OPERATOR_NAMESPACE", operatorNamespace)).Should(Succeed())
+	})
+	AfterEach(func() {
+		Expect(os.Setenv("OPERATOR_NAMESPACE", previousNamespace)).Should(Succeed())
+	})
+
+	It("Should keep the orphaned resource within the grace period and delete it after", func() {
+		collector, c := newCollector(configMapConfig(), labeledConfigMap(leftoverKey.Namespace, leftoverKey.Name))
+
+		Expect(collector.Sweep(ctx)).Should(Succeed())
+		Expect(c.Get(ctx, leftoverKey, &corev1.ConfigMap{})).Should(Succeed())
+		Expect(collector.orphans).Should(HaveKey(leftoverOrphanKey))
+
+		collector.orphans[leftoverOrphanKey] = time.Now().Add(-2 * time.Hour)
+		Expect(collector.Sweep(ctx)).Should(Succeed())
+		Expect(apierrors.IsNotFound(c.Get(ctx, leftoverKey, &corev1.ConfigMap{}))).Should(BeTrue())
+		Expect(collector.orphans).ShouldNot(HaveKey(leftoverOrphanKey))
+	})
+
+	It("Should never delete the orphaned resource in dry run mode", func() {
+		collector, c := newCollector(configMapConfig(), labeledConfigMap(leftoverKey.Namespace, leftoverKey.Name))
+		collector.DryRun = true
+
+		Expect(collector.Sweep(ctx)).Should(Succeed())
+		collector.orphans[leftoverOrphanKey] = time.Now().Add(-2 * time.Hour)
+		Expect(collector.Sweep(ctx)).Should(Succeed())
+		Expect(c.Get(ctx, leftoverKey, &corev1.ConfigMap{})).Should(Succeed())
+		Expect(collector.orphans).Should(HaveKeyWithValue(leftoverOrphanKey, BeTemporally("<", time.Now().Add(-time.Hour))))
+	})
+
+	It("Should track the orphaned resource across the sweeps until it is accounted for again", func() {
+		collector, c := newCollector(configMapConfig(), labeledConfigMap(leftoverKey.Namespace, leftoverKey.Name))
+
+		Expect(collector.Sweep(ctx)).Should(Succeed())
+		firstSeen := collector.orphans[leftoverOrphanKey]
+		Expect(collector.Sweep(ctx)).Should(Succeed())
+		Expect(collector.orphans).Should(HaveKeyWithValue(leftoverOrphanKey, firstSeen))
+
+		cm := &corev1.ConfigMap{}
+		Expect(c.Get(ctx, leftoverKey, cm)).Should(Succeed())
+		cm.Labels[constant.NotUninstallLabel] = "true"
+		Expect(c.Update(ctx, cm)).Should(Succeed())
+		Expect(collector.Sweep(ctx)).Should(Succeed())
+		Expect(collector.orphans).ShouldNot(HaveKey(leftoverOrphanKey))
+	})
+
+	It("Should sweep the kinds recorded before the OperandConfig was deleted", func() {
+		config := configMapConfig()
+		collector, c := newCollector(config, labeledConfigMap(leftoverKey.Namespace, leftoverKey.Name))
+
+		Expect(collector.Sweep(ctx)).Should(Succeed())
+		kinds := &corev1.ConfigMap{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: operatorNamespace, Name: constant.GCKindsConfigMapName}, kinds)).Should(Succeed())
+		Expect(kinds.Data[kindsDataKey]).Should(Equal(`[{"kind":"ConfigMap","apiVersion":"v1"}]`))
+
+		Expect(c.Delete(ctx, config)).Should(Succeed())
+		collector.orphans[leftoverOrphanKey] = time.Now().Add(-2 * time.Hour)
+		Expect(collector.Sweep(ctx)).Should(Succeed())
+		Expect(apierrors.IsNotFound(c.Get(ctx, leftoverKey, &corev1.ConfigMap{}))).Should(BeTrue())
+
+		// The kind is forgotten once all its resources are deleted
+		Expect(collector.Sweep(ctx)).Should(Succeed())
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: operatorNamespace, Name: constant.GCKindsConfigMapName}, kinds)).Should(Succeed())
+		Expect(kinds.Data[kindsDataKey]).Should(Equal("null"))
+	})
+
+	It("Should keep the operators of the revision pinned to the OperandRequest", func() {
+		etcd := func(namespace string) operatorv1alpha1.Operator {
+			return operatorv1alpha1.Operator{
+				Name:            "etcd",
+				Namespace:       namespace,
+				PackageName:     "etcd",
+				Channel:         "alpha",
+				SourceName:      "community-operators",
+				SourceNamespace: "openshift-marketplace",
+			}
+		}
+		previousData, err := json.Marshal(&deploy.RegistryRevision{
+			OperandRegistrySpec: operatorv1alpha1.OperandRegistrySpec{Operators: []operatorv1alpha1.Operator{etcd("etcd-ns")}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		previous := &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{Namespace: operatorNamespace, Name: "common-service-previous"},
+			Data:       runtime.RawExtension{Raw: previousData},
+		}
+		registry := &operatorv1alpha1.OperandRegistry{
+			ObjectMeta: metav1.ObjectMeta{Namespace: operatorNamespace, Name: "common-service"},
+			Spec: operatorv1alpha1.OperandRegistrySpec{
+				Operators:       []operatorv1alpha1.Operator{etcd("etcd-new-ns")},
+				RolloutStrategy: &operatorv1alpha1.RolloutStrategy{CanaryNamespaces: []string{"canary"}},
+			},
+			Status: operatorv1alpha1.OperandRegistryStatus{
+				Rollout: &operatorv1alpha1.RolloutStatus{
+					Revision:         "common-service-current",
+					PreviousRevision: previous.Name,
+					Phase:            operatorv1alpha1.RolloutProgressing,
+				},
+			},
+		}
+		request := &operatorv1alpha1.OperandRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "req"},
+			Spec: operatorv1alpha1.OperandRequestSpec{
+				Requests: []operatorv1alpha1.Request{{
+					Registry:          registry.Name,
+					RegistryNamespace: registry.Namespace,
+					Operands:          []operatorv1alpha1.Operand{{Name: "etcd"}},
+				}},
+			},
+		}
+		sub := &olmv1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "etcd-ns",
+				Name:              "etcd",
+				Labels:            map[string]string{constant.OpreqLabel: "true"},
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+			},
+		}
+		collector, c := newCollector(previous, registry, request, sub)
+
+		Expect(collector.Sweep(ctx)).Should(Succeed())
+		Expect(collector.orphans).Should(BeEmpty())
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(sub), &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": subscriptionGVK.GroupVersion().String(),
+			"kind":       subscriptionGVK.Kind,
+		}})).Should(Succeed())
+	})
+})
@@ -0,0 +1,261 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package garbagecollector
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
+)
+
+// inventory records the resources the live OperandRequests and OperandConfigs account for
+type inventory struct {
+	// operators are the namespace/name of the requested Subscriptions, and /name of the requested ClusterExtensions
+	operators map[string]bool
+	// namespaces are the namespaces of the requested operators and their operands
+	namespaces map[string]bool
+	// resources are the apiVersion/kind/namespace/name of the requested custom resources and k8s resources
+	resources map[string]bool
+	// configKinds are the lowercase kind/namespace of the custom resources configured in the OperandConfigs
+	configKinds map[string]bool
+	// gvks are the kinds of the custom resources and k8s resources created by ODLM
+	gvks map[schema.GroupVersionKind]bool
+	// recordedGVKs are the other kinds recorded by the previous sweeps
+	recordedGVKs map[schema.GroupVersionKind]bool
+}
+
+func newInventory() *inventory {
+	return &inventory{
+		operators:    make(map[string]bool),
+		namespaces:   make(map[string]bool),
+		resources:    make(map[string]bool),
+		configKinds:  make(map[string]bool),
+		gvks:         make(map[schema.GroupVersionKind]bool),
+		recordedGVKs: make(map[schema.GroupVersionKind]bool),
+	}
+}
+
+func resourceKey(apiVersion, kind, namespace, name string) string {
+	return apiVersion + "/" + kind + "/" + namespace + "/" + name
+}
+
+func (inv *inventory) addResource(apiVersion, kind, namespace, name string) {
+	inv.resources[resourceKey(apiVersion, kind, namespace, name)] = true
+	// The namespace is ignored when the resource is cluster scoped
+	inv.resources[resourceKey(apiVersion, kind, "", name)] = true
+	inv.addGVK(apiVersion, kind)
+}
+
+func (inv *inventory) addGVK(apiVersion, kind string) {
+	if apiVersion == "" || kind == "" {
+		return
+	}
+	inv.gvks[schema.FromAPIVersionAndKind(apiVersion, kind)] = true
+}
+
+// addALMExamples records the kinds of the custom resources in the alm-examples
+func (inv *inventory) addALMExamples(almExamples string) {
+	if almExamples == "" {
+		return
+	}
+	var almExampleList []unstructured.Unstructured
+	if err := json.Unmarshal([]byte(almExamples), &almExampleList); err != nil {
+		klog.V(2).Infof("Skip the invalid alm-examples: %v", err)
+		return
+	}
+	for _, cr := range almExampleList {
+		inv.addGVK(cr.GetAPIVersion(), cr.GetKind())
+	}
+}
+
+// accounts returns true when a live OperandRequest or OperandConfig accounts for the resource
+func (inv *inventory) accounts(obj unstructured.Unstructured, subscriptionNamespaces map[string]bool) bool {
+	switch obj.GroupVersionKind() {
+	case subscriptionGVK:
+		return inv.operators[obj.GetNamespace()+"/"+obj.GetName()]
+	case deploy.ClusterExtensionGVK:
+		return inv.operators["/"+obj.GetName()]
+	case operatorGroupGVK:
+		// The OperatorGroup is required as long as any Subscription exists in its namespace
+		return subscriptionNamespaces[obj.GetNamespace()]
+	case namespaceGVK:
+		return inv.namespaces[obj.GetName()]
+	default:
+		return inv.resources[resourceKey(obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj.GetName())] ||
+			inv.configKinds[strings.ToLower(obj.GetKind())+"/"+obj.GetNamespace()]
+	}
+}
+
+// buildInventory collects the resources the live OperandRequests and OperandConfigs account for
+func (c *Collector) buildInventory(ctx context.Context) (*inventory, error) {
+	inv := newInventory()
+	requestList, err := c.ListOperandRequests(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, request := range requestList.Items {
+		// Custom resources created from the OperandRequest
+		for _, member := range request.Status.Members {
+			for _, cr := range member.OperandCRList {
+				namespace := request.Namespace
+				if cr.Namespace != "" {
+					namespace = cr.Namespace
+				}
+				inv.addResource(cr.APIVersion, cr.Kind, namespace, cr.Name)
+			}
+		}
+
+		// The operators of the fallback OperandRegistries are accounted for as well, as any of them may be resolved.
+		// They are resolved from the revision rolled out to the OperandRequest, so the operators of the previous revision
+		// aren't orphaned during a staged rollout.
+		requestKey := types.NamespacedName{Namespace: request.Namespace, Name: request.Name}
+		for _, req := range request.Spec.Requests {
+			for _, registryKey := range request.GetRegistryKeys(req) {
+				registry, _, err := c.GetOperandRegistryForRequest(ctx, registryKey, requestKey)
+				if err != nil {
+					if apierrors.IsNotFound(err) {
+						// The resources of a deleted OperandRegistry are not accounted for
+						continue
+					}
+					return nil, err
+				}
+				config, err := c.GetOperandConfig(ctx, registryKey)
+				if err != nil && !apierrors.IsNotFound(err) {
+					return nil, err
+				}
+
+				for _, operand := range req.Operands {
+					o := registry.GetOperator(operand.Name)
+					if o == nil {
+						continue
+					}
+					if err := c.addOperator(ctx, inv, o, config); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+
+	// The k8s resources configured in any OperandConfig are swept, even when their OperandRegistry is deleted
+	configList := &operatorv1alpha1.OperandConfigList{}
+	if err := c.Client.List(ctx, configList); err != nil {
+		return nil, err
+	}
+	for _, config := range configList.Items {
+		for _, service := range config.Spec.Services {
+			for _, res := range service.Resources {
+				inv.addGVK(res.APIVersion, res.Kind)
+			}
+		}
+	}
+
+	// The operators kept by the Retain deletion policy are accounted for by their OperandRegistry
+	registryList, err := c.ListOperandRegistry(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, registry := range registryList.Items {
+		for i := range registry.Spec.Operators {
+			o := &registry.Spec.Operators[i]
+			if o.GetDeletionPolicy() == operatorv1alpha1.DeletionPolicyRetain {
+				c.addRetainedOperator(inv, o)
+			}
+		}
+	}
+	return inv, nil
+}
+
+// addRetainedOperator records the Subscription or ClusterExtension of an operator kept by the Retain deletion policy
+func (c *Collector) addRetainedOperator(inv *inventory, o *operatorv1alpha1.Operator) {
+	if o.IsClusterExtension() {
+		inv.operators["/"+o.Name] = true
+		return
+	}
+	namespace := c.GetOperatorNamespace(o.InstallMode, o.Namespace)
+	inv.namespaces[namespace] = true
+	inv.operators[namespace+"/"+o.Name] = true
+	inv.operators[namespace+"/"+o.PackageName] = true
+}
+
+// addOperator records the operator and the operands configured for it
+func (c *Collector) addOperator(ctx context.Context, inv *inventory, o *operatorv1alpha1.Operator, config *operatorv1alpha1.OperandConfig) error {
+	var service *operatorv1alpha1.ConfigService
+	if config != nil {
+		service = config.GetService(o.Name)
+	}
+
+	inv.namespaces[o.Namespace] = true
+	if o.IsClusterExtension() {
+		inv.operators["/"+o.Name] = true
+		if service != nil {
+			almExamples, err := service.GetALMExamples()
+			if err != nil {
+				return err
+			}
+			inv.addALMExamples(almExamples)
+		}
+	} else {
+		namespace := c.GetOperatorNamespace(o.InstallMode, o.Namespace)
+		inv.namespaces[namespace] = true
+		inv.operators[namespace+"/"+o.Name] = true
+		inv.operators[namespace+"/"+o.PackageName] = true
+	}
+
+	if service == nil {
+		return nil
+	}
+	for crName := range service.Spec {
+		inv.configKinds[strings.ToLower(crName)+"/"+o.Namespace] = true
+	}
+	for _, res := range service.Resources {
+		namespace := res.Namespace
+		if namespace == "" {
+			namespace = o.Namespace
+		}
+		inv.addResource(res.APIVersion, res.Kind, namespace, res.Name)
+	}
+	return nil
+}
+
+// addSubscriptionExamples records the kinds of the custom resources in the alm-examples of the installed CSV
+func (c *Collector) addSubscriptionExamples(ctx context.Context, inv *inventory, sub unstructured.Unstructured) error {
+	installedCSV, _, _ := unstructured.NestedString(sub.Object, "status", "installedCSV")
+	if installedCSV == "" {
+		return nil
+	}
+	csv := &olmv1alpha1.ClusterServiceVersion{}
+	if err := c.Reader.Get(ctx, types.NamespacedName{Namespace: sub.GetNamespace(), Name: installedCSV}, csv); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	inv.addALMExamples(csv.GetAnnotations()["alm-examples"])
+	return nil
+}
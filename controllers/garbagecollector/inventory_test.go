@@ -0,0 +1,81 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package garbagecollector
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
+)
+
+func labeledResource(gvk schema.GroupVersionKind, namespace, name string) unstructured.Unstructured {
+	obj := unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+var _ = Describe("Inventory", func() {
+	etcdClusterGVK := schema.GroupVersionKind{Group: "etcd.database.coreos.com", Version: "v1beta2", Kind: "EtcdCluster"}
+	configMapGVK := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+
+	var inv *inventory
+	BeforeEach(func() {
+		inv = newInventory()
+		inv.operators["etcd-ns/etcd"] = true
+		inv.namespaces["etcd-ns"] = true
+		inv.configKinds["etcdcluster/etcd-ns"] = true
+		inv.addResource("v1", "ConfigMap", "etcd-ns", "etcd-config")
+	})
+
+	It("Should account for the requested Subscriptions only", func() {
+		Expect(inv.accounts(labeledResource(subscriptionGVK, "etcd-ns", "etcd"), nil)).Should(BeTrue())
+		Expect(inv.accounts(labeledResource(subscriptionGVK, "etcd-ns", "jenkins"), nil)).Should(BeFalse())
+	})
+
+	It("Should account for the OperatorGroups in namespaces having Subscriptions", func() {
+		og := labeledResource(operatorGroupGVK, "jenkins-ns", "operand-deployment-lifecycle-manager-operatorgroup")
+		Expect(inv.accounts(og, map[string]bool{"jenkins-ns": true})).Should(BeTrue())
+		Expect(inv.accounts(og, map[string]bool{"etcd-ns": true})).Should(BeFalse())
+	})
+
+	It("Should account for the configured custom resources and k8s resources", func() {
+		Expect(inv.accounts(labeledResource(etcdClusterGVK, "etcd-ns", "example"), nil)).Should(BeTrue())
+		Expect(inv.accounts(labeledResource(etcdClusterGVK, "jenkins-ns", "example"), nil)).Should(BeFalse())
+		Expect(inv.accounts(labeledResource(configMapGVK, "etcd-ns", "etcd-config"), nil)).Should(BeTrue())
+		Expect(inv.accounts(labeledResource(configMapGVK, "etcd-ns", "other-config"), nil)).Should(BeFalse())
+	})
+
+	It("Should account for the operators kept by the Retain deletion policy", func() {
+		c := &Collector{ODLMOperator: &deploy.ODLMOperator{}}
+		c.addRetainedOperator(inv, &operatorv1alpha1.Operator{Name: "jenkins", Namespace: "jenkins-ns", PackageName: "jenkins-operator", DeletionPolicy: operatorv1alpha1.DeletionPolicyRetain})
+		Expect(inv.accounts(labeledResource(subscriptionGVK, "jenkins-ns", "jenkins"), nil)).Should(BeTrue())
+		Expect(inv.accounts(labeledResource(subscriptionGVK, "jenkins-ns", "jenkins-operator"), nil)).Should(BeTrue())
+		Expect(inv.accounts(labeledResource(etcdClusterGVK, "jenkins-ns", "example"), nil)).Should(BeFalse())
+	})
+
+	It("Should collect the kinds in the alm-examples", func() {
+		inv.addALMExamples(`[{"apiVersion":"etcd.database.coreos.com/v1beta2","kind":"EtcdCluster","metadata":{"name":"example"}}]`)
+		Expect(inv.gvks).Should(HaveKey(etcdClusterGVK))
+		Expect(inv.gvks).Should(HaveKey(configMapGVK))
+	})
+})
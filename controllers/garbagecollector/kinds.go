@@ -0,0 +1,128 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package garbagecollector
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/util"
+)
+
+// kindsDataKey is the key of the recorded kinds in the ConfigMap of the garbage collector
+const kindsDataKey = "kinds"
+
+// loadKinds adds the kinds recorded by the previous sweeps to the inventory.
+// The kinds of the resources created from a deleted OperandRegistry or OperandConfig are only known from this record.
+func (c *Collector) loadKinds(ctx context.Context, inv *inventory) error {
+	namespace := util.GetOperatorNamespace()
+	if namespace == "" {
+		return nil
+	}
+	cm := &corev1.ConfigMap{}
+	if err := c.Reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: constant.GCKindsConfigMapName}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get ConfigMap %s/%s", namespace, constant.GCKindsConfigMapName)
+	}
+	var kinds []metav1.TypeMeta
+	if err := json.Unmarshal([]byte(cm.Data[kindsDataKey]), &kinds); err != nil {
+		klog.Warningf("Skip the invalid kinds recorded in ConfigMap %s/%s: %v", namespace, constant.GCKindsConfigMapName, err)
+		return nil
+	}
+	for _, kind := range kinds {
+		gvk := schema.FromAPIVersionAndKind(kind.APIVersion, kind.Kind)
+		if kind.APIVersion != "" && kind.Kind != "" && !inv.gvks[gvk] {
+			inv.recordedGVKs[gvk] = true
+		}
+	}
+	return nil
+}
+
+// saveKinds records the kinds accounted for by the live OperandRequests and OperandConfigs,
+// and the recorded kinds which still have labeled resources.
+// A recorded kind is forgotten once the garbage collector deleted all its resources.
+func (c *Collector) saveKinds(ctx context.Context, inv *inventory, candidates []unstructured.Unstructured) error {
+	namespace := util.GetOperatorNamespace()
+	if namespace == "" {
+		klog.V(2).Info("Skip recording the swept kinds, the operator namespace is unknown")
+		return nil
+	}
+	kinds := make(map[schema.GroupVersionKind]bool)
+	for gvk := range inv.gvks {
+		kinds[gvk] = true
+	}
+	for _, obj := range candidates {
+		if gvk := obj.GroupVersionKind(); inv.recordedGVKs[gvk] {
+			kinds[gvk] = true
+		}
+	}
+	var kindList []metav1.TypeMeta
+	for gvk := range kinds {
+		apiVersion, kind := gvk.ToAPIVersionAndKind()
+		kindList = append(kindList, metav1.TypeMeta{APIVersion: apiVersion, Kind: kind})
+	}
+	sort.Slice(kindList, func(i, j int) bool {
+		if kindList[i].APIVersion != kindList[j].APIVersion {
+			return kindList[i].APIVersion < kindList[j].APIVersion
+		}
+		return kindList[i].Kind < kindList[j].Kind
+	})
+	data, err := json.Marshal(kindList)
+	if err != nil {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: namespace, Name: constant.GCKindsConfigMapName}
+	if err := c.Reader.Get(ctx, key, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get ConfigMap %s", key.String())
+		}
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Data:       map[string]string{kindsDataKey: string(data)},
+		}
+		if err := c.Create(ctx, cm); err != nil {
+			return errors.Wrapf(err, "failed to create ConfigMap %s", key.String())
+		}
+		return nil
+	}
+	if cm.Data[kindsDataKey] == string(data) {
+		return nil
+	}
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[kindsDataKey] = string(data)
+	if err := c.Update(ctx, cm); err != nil {
+		return errors.Wrapf(err, "failed to update ConfigMap %s", key.String())
+	}
+	return nil
+}
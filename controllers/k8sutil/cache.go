@@ -59,6 +59,8 @@ func NewODLMCache(isolatedModeEnable bool, namespaces []string, gvkLabelMap map[
 				{Group: "operator.ibm.com", Kind: "OperandRegistry", Version: "v1alpha1"},
 				{Group: "operator.ibm.com", Kind: "OperandConfig", Version: "v1alpha1"},
 				{Group: "operator.ibm.com", Kind: "OperandBindInfo", Version: "v1alpha1"},
+				{Group: "operators.coreos.com", Kind: "CatalogSource", Version: "v1alpha1"},
+				{Group: "packages.operators.coreos.com", Kind: "PackageManifest", Version: "v1"},
 			}
 			clusterGVKList = append(clusterGVKList, GVKList...)
 		}
@@ -435,6 +437,8 @@ func kindToResource(kind string) string {
 		"OperandRegistry": "operandregistries",
 		"OperandConfig":   "operandconfigs",
 		"OperandBindInfo": "operandbindinfos",
+		"CatalogSource":   "catalogsources",
+		"PackageManifest": "packagemanifests",
 	}
 	return kindToResourceMap[kind]
 }
@@ -0,0 +1,71 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package namespacescope
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/testutil"
+)
+
+func TestGeneratedRequestNamespaces(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv("OPERATOR_NAMESPACE", "odlm")
+
+	spec := func(registryNamespace, instanceNamespace string) operatorv1alpha1.OperandRequestSpec {
+		return operatorv1alpha1.OperandRequestSpec{
+			Requests: []operatorv1alpha1.Request{{
+				Registry:          "common-service",
+				RegistryNamespace: registryNamespace,
+				Operands:          []operatorv1alpha1.Operand{{Name: "etcd", InstanceNamespace: instanceNamespace}},
+			}},
+		}
+	}
+	clusterRequest := &operatorv1alpha1.ClusterOperandRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", UID: types.UID("example-uid")},
+		Spec:       spec("ibm-common-services", "example-ns"),
+	}
+	generated := &operatorv1alpha1.OperandRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "ibm-common-services",
+			Name:            clusterRequest.Name,
+			Labels:          map[string]string{constant.ClusterOpreqLabel: clusterRequest.Name},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(clusterRequest, operatorv1alpha1.GroupVersion.WithKind("ClusterOperandRequest"))},
+		},
+		Spec: spec("ibm-common-services", "example-ns"),
+	}
+	// The instanceNamespace of an OperandRequest created in a tenant namespace isn't a member
+	tenant := &operatorv1alpha1.OperandRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tenant", Name: "example"},
+		Spec:       spec("ibm-common-services", "other-ns"),
+	}
+
+	c := testutil.NewFakeClient(clusterRequest, generated, tenant)
+	r := &Reconciler{ODLMOperator: &deploy.ODLMOperator{Client: c, Reader: c, Scheme: c.Scheme()}}
+
+	namespaces, err := r.getOpreqNs(context.Background())
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(namespaces).Should(ConsistOf("odlm", "ibm-common-services", "example-ns", "tenant"))
+}
@@ -127,8 +127,26 @@ func (r *Reconciler) getOpreqNs(ctx context.Context) (nsMems []string, getOpreqN
 		nsSet.Add(operatorNs)
 	}
 
-	for _, opreq := range opreqList.Items {
+	for i := range opreqList.Items {
+		opreq := &opreqList.Items[i]
 		nsSet.Add(opreq.Namespace)
+		// The OperandRequests generated from a ClusterOperandRequest live in the namespace of their OperandRegistry,
+		// and the namespaces of the custom resources they create in other namespaces are members as well
+		generated, err := r.IsGeneratedFromClusterRequest(ctx, opreq)
+		if err != nil {
+			getOpreqNsErr = err
+			return
+		}
+		if !generated {
+			continue
+		}
+		for _, req := range opreq.Spec.Requests {
+			for _, operand := range req.Operands {
+				if operand.InstanceNamespace != "" {
+					nsSet.Add(operand.InstanceNamespace)
+				}
+			}
+		}
 	}
 
 	for ns := range nsSet.Iter() {
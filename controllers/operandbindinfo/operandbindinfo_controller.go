@@ -200,7 +200,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 
 	if requeue {
 		r.updateBindInfoPhase(bindInfoInstance, operatorv1alpha1.BindInfoWaiting, requestNamespaces)
-		return reconcile.Result{RequeueAfter: constant.DefaultRequeueDuration}, nil
+		return reconcile.Result{RequeueAfter: r.GlobalTimeouts().RequeueDuration.Duration}, nil
 	}
 
 	r.updateBindInfoPhase(bindInfoInstance, operatorv1alpha1.BindInfoCompleted, requestNamespaces)
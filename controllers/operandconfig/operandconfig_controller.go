@@ -23,14 +23,15 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/mohae/deepcopy"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -47,6 +48,9 @@ import (
 	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/util"
 )
 
+// revisionKind is the kind recorded in the labels of the ControllerRevisions of the OperandConfig
+const revisionKind = "OperandConfig"
+
 // Reconciler reconciles a OperandConfig object
 type Reconciler struct {
 	*deploy.ODLMOperator
@@ -64,6 +68,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Restore the spec from the revision requested by the rollback annotation
+	if revision, ok := instance.Annotations[constant.RollbackToRevisionAnnotation]; ok {
+		return ctrl.Result{}, r.rollbackToRevision(ctx, instance, revision)
+	}
+
 	klog.V(2).Infof("Reconciling OperandConfig: %s", req.NamespacedName)
 
 	originalInstance := instance.DeepCopy()
@@ -78,6 +87,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		}
 	}()
 
+	// Record the applied spec as a ControllerRevision
+	if err := r.recordRevision(ctx, instance); err != nil {
+		klog.Errorf("failed to record the revision of OperandConfig %s : %v", req.NamespacedName.String(), err)
+		return ctrl.Result{}, err
+	}
+
 	// Update status of OperandConfig by checking CRs
 	if err := r.updateStatus(ctx, instance); err != nil {
 		klog.Errorf("failed to update the status for OperandConfig %s : %v", req.NamespacedName.String(), err)
@@ -88,7 +103,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 	if instance.Status.Phase != operatorv1alpha1.ServiceInit &&
 		instance.Status.Phase != operatorv1alpha1.ServiceRunning {
 		klog.V(2).Info("Waiting for all the services being deployed ...")
-		return ctrl.Result{RequeueAfter: constant.DefaultRequeueDuration}, nil
+		return ctrl.Result{RequeueAfter: r.GlobalTimeouts().RequeueDuration.Duration}, nil
 	}
 
 	klog.V(2).Infof("Finished reconciling OperandConfig: %s", req.NamespacedName)
@@ -124,32 +139,12 @@ func (r *Reconciler) updateStatus(ctx context.Context, instance *operatorv1alpha
 			continue
 		}
 
-		// Looking for the CSV
-		namespace := r.GetOperatorNamespace(op.InstallMode, op.Namespace)
-		sub, err := r.GetSubscription(ctx, op.Name, namespace, op.PackageName)
-
-		if apierrors.IsNotFound(err) {
-			klog.V(3).Infof("There is no Subscription %s or %s in the namespace %s", op.Name, op.PackageName, namespace)
-			continue
-		}
-
+		// Looking for the custom resource templates
+		almExamples, sourceName, installed, err := r.getALMExamples(ctx, &op, service)
 		if err != nil {
-			return errors.Wrapf(err, "failed to get Subscription %s or %s in the namespace %s", op.Name, op.PackageName, namespace)
+			return err
 		}
-
-		if _, ok := sub.Labels[constant.OpreqLabel]; !ok {
-			// Subscription existing and not managed by OperandRequest controller
-			klog.V(1).Infof("Subscription %s in the namespace %s isn't created by ODLM", sub.Name, sub.Namespace)
-		}
-
-		csv, err := r.GetClusterServiceVersion(ctx, sub)
-
-		if err != nil {
-			return errors.Wrapf(err, "failed to get ClusterServiceVersion for the Subscription %s/%s", namespace, sub.Name)
-		}
-
-		if csv == nil {
-			klog.Warningf("ClusterServiceVersion for the Subscription %s/%s doesn't exist, retry...", namespace, sub.Name)
+		if !installed {
 			continue
 		}
 
@@ -168,7 +163,7 @@ func (r *Reconciler) updateStatus(ctx context.Context, instance *operatorv1alpha
 		merr := &util.MultiErr{}
 
 		// handle the deletion of k8s resources
-		k8sError := r.deleteK8sReousceFromStatus(ctx, originalStatus.(map[string]operatorv1alpha1.CrStatus), service, &op)
+		k8sError := r.deleteK8sReousceFromStatus(ctx, instance, originalStatus.(map[string]operatorv1alpha1.CrStatus), service, &op)
 		if k8sError != nil {
 			merr.Add(k8sError)
 		}
@@ -203,9 +198,8 @@ func (r *Reconciler) updateStatus(ctx context.Context, instance *operatorv1alpha
 		}
 
 		// update the status for custom resources
-		almExamples := csv.ObjectMeta.Annotations["alm-examples"]
 		if almExamples == "" {
-			klog.Warningf("Notfound alm-examples in the ClusterServiceVersion %s/%s", csv.Namespace, csv.Name)
+			klog.Warningf("Notfound alm-examples in the %s", sourceName)
 			continue
 		}
 		// Create a slice for crTemplates
@@ -214,7 +208,7 @@ func (r *Reconciler) updateStatus(ctx context.Context, instance *operatorv1alpha
 		// Convert CR template string to slice
 		err = json.Unmarshal([]byte(almExamples), &crTemplates)
 		if err != nil {
-			return errors.Wrapf(err, "failed to convert alm-examples in the Subscription %s/%s to slice", sub.Namespace, sub.Name)
+			return errors.Wrapf(err, "failed to convert alm-examples in the %s to slice", sourceName)
 		}
 
 		// Merge OperandConfig and ClusterServiceVersion alm-examples
@@ -266,8 +260,63 @@ func (r *Reconciler) updateStatus(ctx context.Context, instance *operatorv1alpha
 	return nil
 }
 
-// deleteK8sReousceFromStatus deletes the k8s resources from OperandConfig Status when they are not defined in OperandConfig Spec anymore
-func (r *Reconciler) deleteK8sReousceFromStatus(ctx context.Context, serviceStatus map[string]operatorv1alpha1.CrStatus, service *operatorv1alpha1.ConfigService, op *operatorv1alpha1.Operator) error {
+// getALMExamples gets the custom resource templates of the operator. They come from the CSV alm-examples annotation,
+// or from the OperandConfig when the operator is installed by an OLM v1 ClusterExtension.
+// It returns false if the operator isn't installed yet.
+func (r *Reconciler) getALMExamples(ctx context.Context, op *operatorv1alpha1.Operator, service *operatorv1alpha1.ConfigService) (string, string, bool, error) {
+	if op.IsClusterExtension() {
+		ce, err := r.GetClusterExtension(ctx, op.Name)
+		if apierrors.IsNotFound(err) {
+			klog.V(3).Infof("There is no ClusterExtension %s", op.Name)
+			return "", "", false, nil
+		}
+		if err != nil {
+			return "", "", false, errors.Wrapf(err, "failed to get ClusterExtension %s", op.Name)
+		}
+		if deploy.GetClusterExtensionPhase(ce) != operatorv1alpha1.OperatorRunning {
+			klog.Warningf("ClusterExtension %s isn't installed, retry...", op.Name)
+			return "", "", false, nil
+		}
+		almExamples, err := service.GetALMExamples()
+		if err != nil {
+			return "", "", false, errors.Wrapf(err, "failed to get the examples of service %s", service.Name)
+		}
+		return almExamples, "OperandConfig service " + service.Name, true, nil
+	}
+
+	namespace := r.GetOperatorNamespace(op.InstallMode, op.Namespace)
+	sub, err := r.GetSubscription(ctx, op.Name, namespace, op.PackageName)
+
+	if apierrors.IsNotFound(err) {
+		klog.V(3).Infof("There is no Subscription %s or %s in the namespace %s", op.Name, op.PackageName, namespace)
+		return "", "", false, nil
+	}
+
+	if err != nil {
+		return "", "", false, errors.Wrapf(err, "failed to get Subscription %s or %s in the namespace %s", op.Name, op.PackageName, namespace)
+	}
+
+	if _, ok := sub.Labels[constant.OpreqLabel]; !ok {
+		// Subscription existing and not managed by OperandRequest controller
+		klog.V(1).Infof("Subscription %s in the namespace %s isn't created by ODLM", sub.Name, sub.Namespace)
+	}
+
+	csv, err := r.GetClusterServiceVersion(ctx, sub)
+
+	if err != nil {
+		return "", "", false, errors.Wrapf(err, "failed to get ClusterServiceVersion for the Subscription %s/%s", namespace, sub.Name)
+	}
+
+	if csv == nil {
+		klog.Warningf("ClusterServiceVersion for the Subscription %s/%s doesn't exist, retry...", namespace, sub.Name)
+		return "", "", false, nil
+	}
+	return csv.ObjectMeta.Annotations["alm-examples"], "ClusterServiceVersion " + csv.Namespace + "/" + csv.Name, true, nil
+}
+
+// deleteK8sReousceFromStatus deletes the k8s resources from OperandConfig Status when they are not defined in OperandConfig Spec anymore.
+// The resources still being deleted stay in the status with the Deleting phase, so that their deletion is checked in the next reconciliation.
+func (r *Reconciler) deleteK8sReousceFromStatus(ctx context.Context, instance *operatorv1alpha1.OperandConfig, serviceStatus map[string]operatorv1alpha1.CrStatus, service *operatorv1alpha1.ConfigService, op *operatorv1alpha1.Operator) error {
 	merr := &util.MultiErr{}
 	reg, _ := regexp.Compile(`^(.*)\@(.*)\@(.*)\@(.*)`)
 	var existingResList []string
@@ -277,8 +326,9 @@ func (r *Reconciler) deleteK8sReousceFromStatus(ctx context.Context, serviceStat
 		}
 	}
 
+	timeout := r.EffectiveTimeouts(op, service).CRDeleteTimeout.Duration
 	for _, resKey := range existingResList {
-		separateRes := strings.Split(resKey, ".")
+		separateRes := strings.Split(resKey, "@")
 		k8sAPIVersion := separateRes[0]
 		k8sKind := separateRes[1]
 		k8sNamespace := separateRes[2]
@@ -293,9 +343,12 @@ func (r *Reconciler) deleteK8sReousceFromStatus(ctx context.Context, serviceStat
 		}
 		// start the deletion if the resource found in status but not in config spec
 		if !isInConfig {
-			err := r.deleteK8sReousce(ctx, k8sAPIVersion, k8sKind, k8sName, k8sNamespace)
+			deleted, err := r.deleteK8sReousce(ctx, k8sAPIVersion, k8sKind, k8sName, k8sNamespace, timeout)
 			if err != nil {
+				instance.Status.ServiceStatus[op.Name].CrStatus[resKey] = operatorv1alpha1.ServiceFailed
 				merr.Add(err)
+			} else if !deleted {
+				instance.Status.ServiceStatus[op.Name].CrStatus[resKey] = operatorv1alpha1.ServiceDeleting
 			}
 		}
 	}
@@ -305,7 +358,8 @@ func (r *Reconciler) deleteK8sReousceFromStatus(ctx context.Context, serviceStat
 	return nil
 }
 
-func (r *Reconciler) deleteK8sReousce(ctx context.Context, k8sAPIVersion, k8sKind, k8sName, k8sNamespace string) error {
+// deleteK8sReousce starts the deletion of the k8s resource labeled by ODLM, and returns true once it is gone
+func (r *Reconciler) deleteK8sReousce(ctx context.Context, k8sAPIVersion, k8sKind, k8sName, k8sNamespace string, timeout time.Duration) (bool, error) {
 	var k8sUnstruct unstructured.Unstructured
 	k8sUnstruct.SetAPIVersion(k8sAPIVersion)
 	k8sUnstruct.SetKind(k8sKind)
@@ -315,37 +369,26 @@ func (r *Reconciler) deleteK8sReousce(ctx context.Context, k8sAPIVersion, k8sKin
 	}, &k8sUnstruct)
 
 	if k8sGetError != nil && !apierrors.IsNotFound(k8sGetError) {
-		return errors.Wrapf(k8sGetError, "failed to get k8s resource -- Kind: %s, NamespacedName: %s/%s", k8sKind, k8sNamespace, k8sName)
+		return false, errors.Wrapf(k8sGetError, "failed to get k8s resource -- Kind: %s, NamespacedName: %s/%s", k8sKind, k8sNamespace, k8sName)
 	} else if apierrors.IsNotFound(k8sGetError) {
 		klog.V(3).Infof("There is no k8s resource -- Kind: %s, NamespacedName: %s/%s", k8sKind, k8sNamespace, k8sName)
-	} else {
-		if r.CheckLabel(k8sUnstruct, map[string]string{constant.OpreqLabel: "true"}) {
-			klog.V(3).Infof("Deleting k8s resource -- Kind: %s, NamespacedName: %s/%s", k8sKind, k8sNamespace, k8sName)
-			k8sDeleteError := r.Delete(ctx, &k8sUnstruct)
-			if k8sDeleteError != nil && !apierrors.IsNotFound(k8sDeleteError) {
-				return errors.Wrapf(k8sDeleteError, "failed to delete k8s resource -- Kind: %s, NamespacedName: %s/%s", k8sKind, k8sNamespace, k8sName)
-			}
-			waitErr := wait.PollImmediate(constant.DefaultCRDeletePeriod, constant.DefaultCRDeleteTimeout, func() (bool, error) {
-				klog.V(3).Infof("Waiting for k8s resource -- Kind: %s, NamespacedName: %s/%s removed ...", k8sKind, k8sNamespace, k8sName)
-				err := r.Client.Get(ctx, types.NamespacedName{
-					Name:      k8sName,
-					Namespace: k8sNamespace,
-				}, &k8sUnstruct)
-				if apierrors.IsNotFound(err) {
-					return true, nil
-				}
-				if err != nil {
-					return false, errors.Wrapf(err, "failed to get k8s resource -- Kind: %s, NamespacedName: %s/%s", k8sKind, k8sNamespace, k8sName)
-				}
-				return false, nil
-			})
-			if waitErr != nil {
-				return errors.Wrapf(waitErr, "failed to delete k8s resource -- Kind: %s, NamespacedName: %s/%s", k8sKind, k8sNamespace, k8sName)
-			}
-			klog.V(1).Infof("Finish deleting k8s resource -- Kind: %s, NamespacedName: %s/%s", k8sKind, k8sNamespace, k8sName)
-		}
+		return true, nil
 	}
-	return nil
+	if !r.CheckLabel(k8sUnstruct, map[string]string{constant.OpreqLabel: "true"}) {
+		return true, nil
+	}
+
+	klog.V(3).Infof("Deleting k8s resource -- Kind: %s, NamespacedName: %s/%s", k8sKind, k8sNamespace, k8sName)
+	deleted, err := r.DeleteResource(ctx, &k8sUnstruct, timeout)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to delete k8s resource -- Kind: %s, NamespacedName: %s/%s", k8sKind, k8sNamespace, k8sName)
+	}
+	if !deleted {
+		klog.V(3).Infof("Waiting for k8s resource -- Kind: %s, NamespacedName: %s/%s removed ...", k8sKind, k8sNamespace, k8sName)
+		return false, nil
+	}
+	klog.V(1).Infof("Finish deleting k8s resource -- Kind: %s, NamespacedName: %s/%s", k8sKind, k8sNamespace, k8sName)
+	return true, nil
 }
 
 func checkRegistryStatus(opName string, registryInstance *operatorv1alpha1.OperandRegistry) bool {
@@ -376,19 +419,57 @@ func (r *Reconciler) getRequestToConfigMapper(ctx context.Context) handler.MapFu
 
 		// If the OperandRequest exist, reconcile OperandConfigs specific in the OperandRequest instance.
 		for _, request := range opreqInstance.Spec.Requests {
-			registryKey := opreqInstance.GetRegistryKey(request)
-			req := reconcile.Request{NamespacedName: registryKey}
-			requests = append(requests, req)
+			for _, registryKey := range opreqInstance.GetRegistryKeys(request) {
+				req := reconcile.Request{NamespacedName: registryKey}
+				requests = append(requests, req)
+			}
 		}
 		return requests
 	}
 }
 
+// recordRevision records the applied spec of the OperandConfig as a ControllerRevision
+func (r *Reconciler) recordRevision(ctx context.Context, instance *operatorv1alpha1.OperandConfig) error {
+	// the history limit is not part of the revision, so a rollback doesn't change it
+	spec := instance.Spec.DeepCopy()
+	spec.RevisionHistoryLimit = nil
+	revision, err := r.RecordRevision(ctx, revisionKind, instance, spec, instance.GetRevisionHistoryLimit())
+	if err != nil {
+		return err
+	}
+	instance.Status.CurrentRevision = revision
+	return nil
+}
+
+// rollbackToRevision restores the spec of the OperandConfig from the revision and removes the rollback annotation
+func (r *Reconciler) rollbackToRevision(ctx context.Context, instance *operatorv1alpha1.OperandConfig, revision string) error {
+	spec := operatorv1alpha1.OperandConfigSpec{}
+	restored, err := r.RestoreRevision(ctx, revisionKind, instance, revision, &spec)
+	if err != nil {
+		return err
+	}
+	delete(instance.Annotations, constant.RollbackToRevisionAnnotation)
+	if restored != nil {
+		spec.RevisionHistoryLimit = instance.Spec.RevisionHistoryLimit
+		instance.Spec = spec
+	}
+	if err := r.Update(ctx, instance); err != nil {
+		return errors.Wrapf(err, "failed to roll back OperandConfig %s/%s to revision %s", instance.Namespace, instance.Name, revision)
+	}
+	if restored == nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "RollbackFailed", "Revision %s of the OperandConfig is not found", revision)
+		return nil
+	}
+	klog.Infof("OperandConfig %s/%s is rolled back to revision %d", instance.Namespace, instance.Name, restored.Revision)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, "RolledBack", "OperandConfig is rolled back to revision %d", restored.Revision)
+	return nil
+}
+
 // SetupWithManager adds OperandConfig controller to the manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	ctx := context.Background()
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&operatorv1alpha1.OperandConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		For(&operatorv1alpha1.OperandConfig{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
 		Watches(&source.Kind{Type: &operatorv1alpha1.OperandRequest{}}, handler.EnqueueRequestsFromMapFunc(r.getRequestToConfigMapper(ctx)), builder.WithPredicates(predicate.Funcs{
 			CreateFunc: func(e event.CreateEvent) bool {
 				return true
@@ -20,7 +20,13 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog"
@@ -34,9 +40,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
 	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
 )
 
+// revisionKind is the kind recorded in the labels of the ControllerRevisions of the OperandRegistry
+const revisionKind = "OperandRegistry"
+
 // Reconciler reconciles a OperandRegistry object
 type Reconciler struct {
 	*deploy.ODLMOperator
@@ -54,6 +64,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Restore the spec from the revision requested by the rollback annotation
+	if revision, ok := instance.Annotations[constant.RollbackToRevisionAnnotation]; ok {
+		return ctrl.Result{}, r.rollbackToRevision(ctx, instance, revision)
+	}
+
 	originalInstance := instance.DeepCopy()
 
 	// Always attempt to patch the status after each reconciliation.
@@ -68,21 +83,331 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 
 	klog.V(2).Infof("Reconciling OperandRegistry: %s", req.NamespacedName)
 
+	// Record the applied spec as a ControllerRevision
+	if err := r.recordRevision(ctx, instance); err != nil {
+		klog.Errorf("failed to record the revision of OperandRegistry %s : %v", req.NamespacedName.String(), err)
+		return ctrl.Result{}, err
+	}
+
+	// Roll out the current revision to the OperandRequests
+	rolloutRequeue, err := r.reconcileRollout(ctx, instance)
+	if err != nil {
+		klog.Errorf("failed to roll out OperandRegistry %s : %v", req.NamespacedName.String(), err)
+		return ctrl.Result{}, err
+	}
+
+	// Merge the operators of the base OperandRegistry
+	if err := r.mergeBase(ctx, instance); err != nil {
+		klog.Errorf("failed to merge the base of OperandRegistry %s : %v", req.NamespacedName.String(), err)
+		instance.UpdateRegistryPhase(operatorv1alpha1.RegistryFailed)
+		return ctrl.Result{RequeueAfter: r.GlobalTimeouts().RequeueDuration.Duration}, nil
+	}
+
+	// Check the effective operators set a package, which is only optional for the overrides of base operators
+	if !r.checkPackageNames(instance) {
+		klog.Errorf("OperandRegistry %s has operators without a packageName", req.NamespacedName.String())
+		instance.UpdateRegistryPhase(operatorv1alpha1.RegistryFailed)
+		return ctrl.Result{}, nil
+	}
+
 	// Update all the operator status
 	if err := r.updateStatus(ctx, instance); err != nil {
 		klog.Errorf("failed to update the status for OperandRegistry %s : %v", req.NamespacedName.String(), err)
 		return ctrl.Result{}, err
 	}
 
+	// Remove the rollbacks of the operators which are no longer in the OperandRegistry
+	for name := range instance.Status.Rollbacks {
+		if instance.GetOperator(name) == nil {
+			instance.SetRollback(name, nil)
+		}
+	}
+
+	// Check the CatalogSources of the requested operators
+	waiting, err := r.checkCatalogSource(ctx, instance)
+	if err != nil {
+		klog.Errorf("failed to check the CatalogSources for OperandRegistry %s : %v", req.NamespacedName.String(), err)
+		return ctrl.Result{}, err
+	}
+
+	// Check the packages and channels of the operators
+	if err := r.checkPackages(ctx, instance); err != nil {
+		klog.Errorf("failed to check the packages for OperandRegistry %s : %v", req.NamespacedName.String(), err)
+		return ctrl.Result{}, err
+	}
+
+	// Record the operators requested from several OperandRegistries
+	if err := r.checkConflicts(ctx, instance); err != nil {
+		klog.Errorf("failed to check the conflicts for OperandRegistry %s : %v", req.NamespacedName.String(), err)
+		return ctrl.Result{}, err
+	}
+
 	// Summarize instance status
-	if instance.Status.OperatorsStatus == nil || len(instance.Status.OperatorsStatus) == 0 {
-		instance.UpdateRegistryPhase(operatorv1alpha1.RegistryReady)
-	} else {
-		instance.UpdateRegistryPhase(operatorv1alpha1.RegistryRunning)
+	if waiting {
+		instance.UpdateRegistryPhase(operatorv1alpha1.RegistryWaiting)
+		klog.V(2).Infof("OperandRegistry %s is waiting for CatalogSources being ready", req.NamespacedName)
+		return ctrl.Result{RequeueAfter: r.GlobalTimeouts().RequeueDuration.Duration}, nil
 	}
+	instance.UpdateRegistryPhase(instance.SummarizePhase())
 
 	klog.V(2).Infof("Finished reconciling OperandRegistry: %s", req.NamespacedName)
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: rolloutRequeue}, nil
+}
+
+// checkCatalogSource records the CatalogSource chosen for each operator, checks the CatalogSource health
+// of the requested operators and returns true when any of them is waiting for its CatalogSource being ready
+func (r *Reconciler) checkCatalogSource(ctx context.Context, instance *operatorv1alpha1.OperandRegistry) (bool, error) {
+	// Get the OperandRegistry with the resolved CatalogSources
+	registry, err := r.GetOperandRegistry(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name})
+	if err != nil {
+		return false, err
+	}
+
+	instance.Status.CatalogSources = nil
+	for _, o := range registry.Spec.Operators {
+		if cs, ok := registry.Status.CatalogSources[o.Name]; ok && !o.IsClusterExtension() {
+			instance.SetCatalogSourceStatus(o.Name, cs)
+		}
+	}
+
+	// Record the default channels, and report the ones changed by the catalog
+	previousChannels := instance.Status.DefaultChannels
+	instance.Status.DefaultChannels = nil
+	for name, channel := range registry.Status.DefaultChannels {
+		if previous, ok := previousChannels[name]; ok && previous != channel {
+			klog.Infof("The default channel of operator %s in OperandRegistry %s/%s changed from %s to %s", name, instance.Namespace, instance.Name, previous, channel)
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, "DefaultChannelChanged", "The default channel of operator %s changed from %s to %s", name, previous, channel)
+		}
+		instance.SetDefaultChannel(name, channel)
+	}
+
+	waiting := false
+	checked := make(map[string]bool)
+	for _, o := range registry.Spec.Operators {
+		if _, ok := instance.Status.OperatorsStatus[o.Name]; !ok || o.IsClusterExtension() {
+			continue
+		}
+		if o.SourceName == "" || o.SourceNamespace == "" {
+			continue
+		}
+		csName := o.SourceNamespace + "/" + o.SourceName
+		if _, ok := checked[csName]; ok {
+			continue
+		}
+		healthy, state, err := r.CheckCatalogSourceHealth(ctx, o.SourceName, o.SourceNamespace)
+		if err != nil {
+			return false, err
+		}
+		checked[csName] = healthy
+		if !healthy {
+			klog.Warningf("CatalogSource %s of operator %s is %s", csName, o.Name, state)
+			instance.SetWaitingCondition(csName, state, operatorv1alpha1.ResourceTypeCatalogSource, corev1.ConditionTrue)
+			waiting = true
+			continue
+		}
+		instance.SetWaitingCondition(csName, state, operatorv1alpha1.ResourceTypeCatalogSource, corev1.ConditionFalse)
+	}
+	return waiting, nil
+}
+
+// checkPackageNames sets the PackageNotFound condition of the operators without a packageName,
+// and returns false when any of them doesn't set it
+func (r *Reconciler) checkPackageNames(instance *operatorv1alpha1.OperandRegistry) bool {
+	valid := true
+	for _, o := range instance.Spec.Operators {
+		if o.PackageName == "" {
+			instance.SetPackageNotFoundCondition(o.Name, "packageName is required", corev1.ConditionTrue)
+			valid = false
+		}
+	}
+	return valid
+}
+
+// checkPackages resolves the package and the channel of every operator from the PackageManifests, whether it is requested or not,
+// and sets the PackageNotFound or ChannelNotFound condition of the operators failing the check
+func (r *Reconciler) checkPackages(ctx context.Context, instance *operatorv1alpha1.OperandRegistry) error {
+	for i := range instance.Spec.Operators {
+		o := &instance.Spec.Operators[i]
+		if o.IsClusterExtension() {
+			continue
+		}
+		condType, reason, err := r.ValidatePackageChannel(ctx, o)
+		if err != nil {
+			return err
+		}
+		if condType != "" {
+			klog.Warningf("Operator %s of OperandRegistry %s/%s is invalid: %s", o.Name, instance.Namespace, instance.Name, reason)
+		}
+		packageStatus, channelStatus := corev1.ConditionFalse, corev1.ConditionFalse
+		packageReason, channelReason := "", ""
+		switch condType {
+		case operatorv1alpha1.ConditionPackageNotFound:
+			packageStatus, packageReason = corev1.ConditionTrue, reason
+		case operatorv1alpha1.ConditionChannelNotFound:
+			channelStatus, channelReason = corev1.ConditionTrue, reason
+		}
+		instance.SetPackageNotFoundCondition(o.Name, packageReason, packageStatus)
+		instance.SetChannelNotFoundCondition(o.Name, channelReason, channelStatus)
+	}
+	return nil
+}
+
+// checkConflicts sets a Conflict condition for each requested operator which is also requested from other OperandRegistries
+func (r *Reconciler) checkConflicts(ctx context.Context, instance *operatorv1alpha1.OperandRegistry) error {
+	for _, o := range instance.Spec.Operators {
+		if _, ok := instance.Status.OperatorsStatus[o.Name]; !ok {
+			instance.SetConflictCondition(o.Name, "", corev1.ConditionFalse)
+			continue
+		}
+		inventoryKey := types.NamespacedName{Namespace: o.Namespace, Name: o.Name}
+		var annotations map[string]string
+		if !o.IsClusterExtension() {
+			sub, err := r.GetSubscription(ctx, o.Name, r.GetOperatorNamespace(o.InstallMode, o.Namespace), o.PackageName)
+			if err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+			if err != nil || sub == nil {
+				instance.SetConflictCondition(o.Name, "", corev1.ConditionFalse)
+				continue
+			}
+			inventoryKey = types.NamespacedName{Namespace: sub.Namespace, Name: sub.Name}
+			annotations = sub.Annotations
+		}
+		registries, err := r.GetRequestingRegistries(ctx, inventoryKey, annotations)
+		if err != nil {
+			return err
+		}
+		if len(registries) <= 1 {
+			instance.SetConflictCondition(o.Name, "", corev1.ConditionFalse)
+			continue
+		}
+		managingRegistry, err := r.ResolveManagingRegistry(ctx, registries)
+		if err != nil {
+			return err
+		}
+		reason := r.ConflictReason(registries, managingRegistry)
+		klog.Warningf("Operator %s of OperandRegistry %s/%s is in conflict: %s", o.Name, instance.Namespace, instance.Name, reason)
+		instance.SetConflictCondition(o.Name, reason, corev1.ConditionTrue)
+	}
+	return nil
+}
+
+// getSubToRegistryMapper enqueues the OperandRegistries recorded in the owner annotations of the Subscription
+func (r *Reconciler) getSubToRegistryMapper() handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
+		return registryRequests(object.GetAnnotations())
+	}
+}
+
+// getCSVToRegistryMapper enqueues the OperandRegistries owning the Subscriptions of the ClusterServiceVersion
+func (r *Reconciler) getCSVToRegistryMapper() handler.MapFunc {
+	ctx := context.Background()
+	return func(object client.Object) []reconcile.Request {
+		subList := &olmv1alpha1.SubscriptionList{}
+		if err := r.Client.List(ctx, subList, client.InNamespace(object.GetNamespace())); err != nil {
+			klog.Errorf("failed to list Subscriptions in the namespace %s: %v", object.GetNamespace(), err)
+			return nil
+		}
+		requests := []reconcile.Request{}
+		for _, sub := range subList.Items {
+			if sub.Status.InstalledCSV == object.GetName() || sub.Status.CurrentCSV == object.GetName() {
+				requests = append(requests, registryRequests(sub.Annotations)...)
+			}
+		}
+		return requests
+	}
+}
+
+// registryRequests returns the OperandRegistries recorded in the owner annotations of a managed resource
+func registryRequests(annotations map[string]string) []reconcile.Request {
+	requests := []reconcile.Request{}
+	for anno := range annotations {
+		if !strings.HasSuffix(anno, "/registry") || !deploy.OwnerAnnotation.MatchString(anno) {
+			continue
+		}
+		nsName := strings.SplitN(strings.TrimSuffix(anno, "/registry"), ".", 2)
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: nsName[0], Name: nsName[1]}})
+	}
+	return requests
+}
+
+// getPackageManifestToRegistryMapper enqueues the OperandRegistries tracking the default channel of the package
+func (r *Reconciler) getPackageManifestToRegistryMapper() handler.MapFunc {
+	ctx := context.Background()
+	return func(object client.Object) []reconcile.Request {
+		registryList, err := r.ListOperandRegistry(ctx, nil)
+		if err != nil {
+			klog.Errorf("failed to list OperandRegistries: %v", err)
+			return nil
+		}
+		requests := []reconcile.Request{}
+		for _, registry := range registryList.Items {
+			for _, o := range registry.Spec.Operators {
+				if o.PackageName == object.GetName() && o.Channel == "" && !o.IsClusterExtension() {
+					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: registry.Name, Namespace: registry.Namespace}})
+					break
+				}
+			}
+		}
+		return requests
+	}
+}
+
+// mergeBase replaces the operators of the OperandRegistry extending a base with the effective ones and records them in the status.
+// The spec is only replaced in memory for the following checks, the status patch doesn't update it.
+func (r *Reconciler) mergeBase(ctx context.Context, instance *operatorv1alpha1.OperandRegistry) error {
+	baseKey := instance.GetBaseKey()
+	if baseKey == nil {
+		instance.Status.EffectiveOperators = nil
+		return nil
+	}
+	effective := instance.DeepCopy()
+	if err := r.MergeBaseRegistry(ctx, effective); err != nil {
+		baseErr := &deploy.BaseRegistryError{}
+		if errors.As(err, &baseErr) && baseErr.Reason != deploy.BaseReasonNotFound {
+			instance.SetInvalidBaseCondition(*baseKey, baseErr.Reason, corev1.ConditionTrue)
+			instance.SetBaseNotFoundCondition(*baseKey, "", corev1.ConditionFalse)
+		} else {
+			instance.SetBaseNotFoundCondition(*baseKey, err.Error(), corev1.ConditionTrue)
+			instance.SetInvalidBaseCondition(*baseKey, "", corev1.ConditionFalse)
+		}
+		instance.Status.EffectiveOperators = nil
+		return err
+	}
+	instance.SetBaseNotFoundCondition(*baseKey, "", corev1.ConditionFalse)
+	instance.SetInvalidBaseCondition(*baseKey, "", corev1.ConditionFalse)
+	instance.Spec = effective.Spec
+	instance.Status.EffectiveOperators = effective.Spec.Operators
+	return nil
+}
+
+// getBaseToRegistryMapper maps a base OperandRegistry to the OperandRegistries extending it
+func (r *Reconciler) getBaseToRegistryMapper() handler.MapFunc {
+	ctx := context.Background()
+	return func(object client.Object) []reconcile.Request {
+		registries, err := r.ListExtendingRegistries(ctx, types.NamespacedName{Namespace: object.GetNamespace(), Name: object.GetName()})
+		if err != nil {
+			klog.Warningf("failed to list the OperandRegistries extending %s/%s: %v", object.GetNamespace(), object.GetName(), err)
+		}
+		requests := []reconcile.Request{}
+		for _, registry := range registries {
+			requests = append(requests, reconcile.Request{NamespacedName: registry})
+		}
+		return requests
+	}
+}
+
+func (r *Reconciler) getCatalogSourceToRegistryMapper() handler.MapFunc {
+	ctx := context.Background()
+	return func(object client.Object) []reconcile.Request {
+		registries, _ := r.ListOperandRegistriesByCatalogSource(ctx, types.NamespacedName{Namespace: object.GetNamespace(), Name: object.GetName()})
+
+		requests := []reconcile.Request{}
+		for _, registry := range registries {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: registry.Name, Namespace: registry.Namespace}})
+		}
+		return requests
+	}
 }
 
 func (r *Reconciler) updateStatus(ctx context.Context, instance *operatorv1alpha1.OperandRegistry) error {
@@ -95,27 +420,78 @@ func (r *Reconciler) updateStatus(ctx context.Context, instance *operatorv1alpha
 
 	// Create an empty OperatorsStatus map
 	instance.Status.OperatorsStatus = make(map[string]operatorv1alpha1.OperatorStatus)
-	// Update OperandRegistry status from the OperandRequest list
+	// Derive the phase of each requested operator once
+	phases := make(map[string]operatorv1alpha1.OperatorPhase)
 	for _, item := range requestList {
 		requestKey := types.NamespacedName{Name: item.Name, Namespace: item.Namespace}
 		for _, req := range item.Spec.Requests {
-			registryKey := item.GetRegistryKey(req)
-			// Skip the status updating if the OperandRegistry doesn't match
-			if registryKey.Name != instance.Name || registryKey.Namespace != instance.Namespace {
-				continue
-			}
 			for _, operand := range req.Operands {
-				instance.SetOperatorStatus(operand.Name, "", reconcile.Request{NamespacedName: requestKey})
+				registryKey := item.GetOperandRegistryKey(req, operand.Name)
+				// Skip the status updating if the operand isn't resolved from the OperandRegistry
+				if registryKey.Name != instance.Name || registryKey.Namespace != instance.Namespace {
+					continue
+				}
+				phase, ok := phases[operand.Name]
+				if !ok {
+					if opt := instance.GetOperator(operand.Name); opt != nil {
+						if phase, err = r.GetOperatorPhase(ctx, opt); err != nil {
+							return err
+						}
+					}
+					phases[operand.Name] = phase
+				}
+				instance.SetOperatorStatus(operand.Name, phase, reconcile.Request{NamespacedName: requestKey})
 			}
 		}
 	}
 	return nil
 }
 
+// recordRevision records the applied spec of the OperandRegistry as a ControllerRevision,
+// with the effective spec of its base, so a change of the base is rolled out as a new revision
+func (r *Reconciler) recordRevision(ctx context.Context, instance *operatorv1alpha1.OperandRegistry) error {
+	data, err := r.GetRegistryRevision(ctx, instance)
+	if err != nil {
+		return err
+	}
+	revision, err := r.RecordRevision(ctx, revisionKind, instance, data, instance.GetRevisionHistoryLimit())
+	if err != nil {
+		return err
+	}
+	instance.Status.CurrentRevision = revision
+	return nil
+}
+
+// rollbackToRevision restores the spec of the OperandRegistry from the revision and removes the rollback annotation
+// The base pinned in the revision isn't restored, the OperandRegistry extends the current base again.
+func (r *Reconciler) rollbackToRevision(ctx context.Context, instance *operatorv1alpha1.OperandRegistry, revision string) error {
+	spec := operatorv1alpha1.OperandRegistrySpec{}
+	restored, err := r.RestoreRevision(ctx, revisionKind, instance, revision, &spec)
+	if err != nil {
+		return err
+	}
+	delete(instance.Annotations, constant.RollbackToRevisionAnnotation)
+	if restored != nil {
+		spec.RevisionHistoryLimit = instance.Spec.RevisionHistoryLimit
+		spec.RolloutStrategy = instance.Spec.RolloutStrategy
+		instance.Spec = spec
+	}
+	if err := r.Update(ctx, instance); err != nil {
+		return errors.Wrapf(err, "failed to roll back OperandRegistry %s/%s to revision %s", instance.Namespace, instance.Name, revision)
+	}
+	if restored == nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "RollbackFailed", "Revision %s of the OperandRegistry is not found", revision)
+		return nil
+	}
+	klog.Infof("OperandRegistry %s/%s is rolled back to revision %d", instance.Namespace, instance.Name, restored.Revision)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, "RolledBack", "OperandRegistry is rolled back to revision %d", restored.Revision)
+	return nil
+}
+
 // SetupWithManager adds OperandRegistry controller to the manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&operatorv1alpha1.OperandRegistry{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		For(&operatorv1alpha1.OperandRegistry{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
 		Watches(&source.Kind{Type: &operatorv1alpha1.OperandRequest{}}, handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
 			or := a.(*operatorv1alpha1.OperandRequest)
 			return or.GetAllRegistryReconcileRequest()
@@ -123,11 +499,35 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 			UpdateFunc: func(e event.UpdateEvent) bool {
 				oldObject := e.ObjectOld.(*operatorv1alpha1.OperandRequest)
 				newObject := e.ObjectNew.(*operatorv1alpha1.OperandRequest)
-				return !reflect.DeepEqual(oldObject.Status.Members, newObject.Status.Members)
+				return !reflect.DeepEqual(oldObject.Status.Members, newObject.Status.Members) ||
+					oldObject.Status.Phase != newObject.Status.Phase ||
+					!reflect.DeepEqual(oldObject.Status.RegistryRevisions, newObject.Status.RegistryRevisions)
 			},
 			DeleteFunc: func(e event.DeleteEvent) bool {
 				// Evaluates to false if the object has been confirmed deleted.
 				return !e.DeleteStateUnknown
 			},
-		})).Complete(r)
+		})).
+		// A change of the base OperandRegistry changes the effective operators of the OperandRegistries extending it
+		Watches(&source.Kind{Type: &operatorv1alpha1.OperandRegistry{}}, handler.EnqueueRequestsFromMapFunc(r.getBaseToRegistryMapper()), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &olmv1alpha1.CatalogSource{}}, handler.EnqueueRequestsFromMapFunc(r.getCatalogSourceToRegistryMapper()), builder.WithPredicates(deploy.CatalogSourceStatePredicate())).
+		// The PackageManifest informer is shared with the PackageManifest cache, which resolves the packages from it
+		Watches(&source.Kind{Type: &operatorsv1.PackageManifest{}}, handler.EnqueueRequestsFromMapFunc(r.getPackageManifestToRegistryMapper()), builder.WithPredicates(predicate.Funcs{
+			CreateFunc: func(e event.CreateEvent) bool {
+				return false
+			},
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldObject := e.ObjectOld.(*operatorsv1.PackageManifest)
+				newObject := e.ObjectNew.(*operatorsv1.PackageManifest)
+				return oldObject.Status.DefaultChannel != newObject.Status.DefaultChannel
+			},
+			DeleteFunc: func(e event.DeleteEvent) bool {
+				return false
+			},
+		})).
+		// A change of the owners of a Subscription may start or end a conflict between OperandRegistries,
+		// a change of its state or of its ClusterServiceVersion phase changes the phase of the operator
+		Watches(&source.Kind{Type: &olmv1alpha1.Subscription{}}, handler.EnqueueRequestsFromMapFunc(r.getSubToRegistryMapper()), builder.WithPredicates(deploy.SubscriptionStatePredicate())).
+		Watches(&source.Kind{Type: &olmv1alpha1.ClusterServiceVersion{}}, handler.EnqueueRequestsFromMapFunc(r.getCSVToRegistryMapper()), builder.WithPredicates(deploy.ClusterServiceVersionPhasePredicate())).
+		Complete(r)
 }
@@ -22,6 +22,8 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
@@ -160,6 +162,23 @@ var _ = Describe("OperandRegistry controller", func() {
 				return registryInstance.Status.Phase
 			}, timeout, interval).Should(Equal(operatorv1alpha1.RegistryRunning))
 
+			By("Checking the OperandRequests of the operators are recorded")
+			Eventually(func() []operatorv1alpha1.ReconcileRequest {
+				registryInstance := &operatorv1alpha1.OperandRegistry{}
+				Expect(k8sClient.Get(ctx, registryKey, registryInstance)).Should(Succeed())
+				return registryInstance.Status.OperatorsStatus["etcd"].ReconcileRequests
+			}, timeout, interval).Should(ContainElement(operatorv1alpha1.ReconcileRequest{Namespace: requestNamespaceName, Name: requestName}))
+
+			By("Checking the applied spec is recorded as a revision")
+			registryInstance := &operatorv1alpha1.OperandRegistry{}
+			Eventually(func() string {
+				Expect(k8sClient.Get(ctx, registryKey, registryInstance)).Should(Succeed())
+				return registryInstance.Status.CurrentRevision
+			}, timeout, interval).ShouldNot(BeEmpty())
+			revision := &appsv1.ControllerRevision{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespaceName, Name: registryInstance.Status.CurrentRevision}, revision)).Should(Succeed())
+			Expect(metav1.IsControlledBy(revision, registryInstance)).Should(BeTrue())
+
 			By("Cleaning up olm resources")
 			Expect(k8sClient.Delete(ctx, etcdSub)).Should(Succeed())
 			Expect(k8sClient.Delete(ctx, jenkinsSub)).Should(Succeed())
@@ -0,0 +1,196 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operandregistry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+)
+
+// reconcileRollout rolls out the current revision of the OperandRegistry to the OperandRequests in stages.
+// It returns the interval to check the progress of the rollout again, it is zero when the rollout isn't progressing.
+func (r *Reconciler) reconcileRollout(ctx context.Context, instance *operatorv1alpha1.OperandRegistry) (time.Duration, error) {
+	strategy := instance.Spec.RolloutStrategy
+	if strategy == nil {
+		instance.Status.Rollout = nil
+		return 0, nil
+	}
+	registryKey := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+
+	if startRollout(instance) {
+		klog.Infof("Rolling out revision %s of OperandRegistry %s", instance.Status.Rollout.Revision, registryKey.String())
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "RolloutStarted", "Rolling out revision %s", instance.Status.Rollout.Revision)
+	}
+
+	rollout := instance.Status.Rollout
+	switch rollout.Phase {
+	case operatorv1alpha1.RolloutCompleted:
+		return 0, nil
+	case operatorv1alpha1.RolloutPaused:
+		if _, ok := instance.Annotations[constant.ResumeRolloutAnnotation]; !ok {
+			return 0, nil
+		}
+		resumed := instance.DeepCopy()
+		delete(resumed.Annotations, constant.ResumeRolloutAnnotation)
+		if err := r.Patch(ctx, resumed, client.MergeFrom(instance)); err != nil {
+			return 0, err
+		}
+		klog.Infof("Resuming the rollout of revision %s of OperandRegistry %s", rollout.Revision, registryKey.String())
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "RolloutResumed", "Resuming the rollout of revision %s", rollout.Revision)
+		now := metav1.Now()
+		rollout.Phase = operatorv1alpha1.RolloutProgressing
+		rollout.Message = ""
+		rollout.BatchStartTime = &now
+	}
+
+	requestList, err := r.ListOperandRequestsByRegistry(ctx, registryKey)
+	if err != nil {
+		return 0, err
+	}
+
+	requeue := r.GlobalTimeouts().RequeueDuration.Duration
+	if pending := pendingRequests(rollout, requestList, registryKey); len(pending) != 0 {
+		timeout := strategy.GetBatchTimeout()
+		if rollout.BatchStartTime == nil || time.Since(rollout.BatchStartTime.Time) <= timeout {
+			klog.V(2).Infof("Waiting for OperandRequests %s being Running with revision %s of OperandRegistry %s", strings.Join(pending, ", "), rollout.Revision, registryKey.String())
+			return requeue, nil
+		}
+		rollout.Phase = operatorv1alpha1.RolloutPaused
+		rollout.Message = fmt.Sprintf("OperandRequests %s are not Running within %s", strings.Join(pending, ", "), timeout)
+		klog.Warningf("Pausing the rollout of revision %s of OperandRegistry %s: %s", rollout.Revision, registryKey.String(), rollout.Message)
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "RolloutPaused", "Pausing the rollout of revision %s: %s", rollout.Revision, rollout.Message)
+		return 0, nil
+	}
+
+	batch := nextBatch(rollout, strategy, requestList)
+	if len(batch) == 0 {
+		klog.Infof("Revision %s of OperandRegistry %s is rolled out", rollout.Revision, registryKey.String())
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "RolloutCompleted", "Revision %s is rolled out", rollout.Revision)
+		instance.Status.Rollout = &operatorv1alpha1.RolloutStatus{
+			Revision: rollout.Revision,
+			Phase:    operatorv1alpha1.RolloutCompleted,
+			Batch:    rollout.Batch,
+		}
+		return 0, nil
+	}
+
+	now := metav1.Now()
+	rollout.Batch++
+	rollout.BatchStartTime = &now
+	rollout.CurrentBatch = batch
+	rollout.Promoted = append(rollout.Promoted, batch...)
+	klog.Infof("Rolling out revision %s of OperandRegistry %s to OperandRequests %s", rollout.Revision, registryKey.String(), strings.Join(batch, ", "))
+	return requeue, nil
+}
+
+// startRollout starts rolling out the current revision when it isn't the revision of the rollout.
+// It returns true when a new rollout is started.
+func startRollout(instance *operatorv1alpha1.OperandRegistry) bool {
+	revision := instance.Status.CurrentRevision
+	rollout := instance.Status.Rollout
+	if rollout == nil {
+		// the current revision is applied by all the OperandRequests before the rollout strategy is set
+		instance.Status.Rollout = &operatorv1alpha1.RolloutStatus{Revision: revision, Phase: operatorv1alpha1.RolloutCompleted}
+		return false
+	}
+	if rollout.Revision == revision {
+		return false
+	}
+
+	previous := rollout.Revision
+	if rollout.Phase != operatorv1alpha1.RolloutCompleted {
+		// the interrupted rollout is restarted from the canaries with the new revision,
+		// the OperandRequests apply the revision of the last completed rollout until they are promoted
+		previous = rollout.PreviousRevision
+	}
+	if previous == "" || previous == revision {
+		instance.Status.Rollout = &operatorv1alpha1.RolloutStatus{Revision: revision, Phase: operatorv1alpha1.RolloutCompleted}
+		return false
+	}
+	instance.Status.Rollout = &operatorv1alpha1.RolloutStatus{
+		Revision:         revision,
+		PreviousRevision: previous,
+		Phase:            operatorv1alpha1.RolloutProgressing,
+	}
+	return true
+}
+
+// pendingRequests returns the OperandRequests of the current stage which are not Running with the revision of the rollout
+func pendingRequests(rollout *operatorv1alpha1.RolloutStatus, requests []operatorv1alpha1.OperandRequest, registryKey types.NamespacedName) []string {
+	requestMap := make(map[string]operatorv1alpha1.OperandRequest)
+	for _, req := range requests {
+		requestMap[types.NamespacedName{Namespace: req.Namespace, Name: req.Name}.String()] = req
+	}
+	var pending []string
+	for _, key := range rollout.CurrentBatch {
+		req, ok := requestMap[key]
+		if !ok {
+			// the OperandRequest is deleted
+			continue
+		}
+		if req.Status.Phase != operatorv1alpha1.ClusterPhaseRunning || req.Status.RegistryRevisions[registryKey.String()] != rollout.Revision {
+			pending = append(pending, key)
+		}
+	}
+	return pending
+}
+
+// nextBatch returns the OperandRequests of the next stage, the OperandRequests in the canary namespaces are the first stage
+func nextBatch(rollout *operatorv1alpha1.RolloutStatus, strategy *operatorv1alpha1.RolloutStrategy, requests []operatorv1alpha1.OperandRequest) []string {
+	promoted := make(map[string]bool)
+	for _, key := range rollout.Promoted {
+		promoted[key] = true
+	}
+	canaryNamespaces := make(map[string]bool)
+	for _, ns := range strategy.CanaryNamespaces {
+		canaryNamespaces[ns] = true
+	}
+
+	var candidates, canaries []string
+	for _, req := range requests {
+		key := types.NamespacedName{Namespace: req.Namespace, Name: req.Name}.String()
+		if promoted[key] {
+			continue
+		}
+		candidates = append(candidates, key)
+		if canaryNamespaces[req.Namespace] {
+			canaries = append(canaries, key)
+		}
+	}
+	sort.Strings(candidates)
+	sort.Strings(canaries)
+
+	if rollout.Batch == 0 && len(canaries) != 0 {
+		return canaries
+	}
+	if size := strategy.GetBatchSize(); len(candidates) > size {
+		return candidates[:size]
+	}
+	return candidates
+}
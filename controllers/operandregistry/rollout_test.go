@@ -0,0 +1,104 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operandregistry
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+)
+
+var (
+	rolloutRegistryKey = types.NamespacedName{Namespace: "ibm-common-services", Name: "common-service"}
+	rolloutBatchSize   = int32(2)
+	rolloutStrategy    = &operatorv1alpha1.RolloutStrategy{CanaryNamespaces: []string{"canary"}, BatchSize: &rolloutBatchSize}
+)
+
+func rolloutRequest(namespace, name string, phase operatorv1alpha1.ClusterPhase, revision string) operatorv1alpha1.OperandRequest {
+	return operatorv1alpha1.OperandRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status: operatorv1alpha1.OperandRequestStatus{
+			Phase:             phase,
+			RegistryRevisions: map[string]string{rolloutRegistryKey.String(): revision},
+		},
+	}
+}
+
+func TestStartRollout(t *testing.T) {
+	g := NewWithT(t)
+	// A rollout starts from the revision of the last completed rollout
+	registry := &operatorv1alpha1.OperandRegistry{}
+	registry.Status.CurrentRevision = "common-service-a"
+	g.Expect(startRollout(registry)).Should(BeFalse())
+	g.Expect(registry.Status.Rollout.Phase).Should(Equal(operatorv1alpha1.RolloutCompleted))
+
+	registry.Status.CurrentRevision = "common-service-b"
+	g.Expect(startRollout(registry)).Should(BeTrue())
+	g.Expect(registry.Status.Rollout.PreviousRevision).Should(Equal("common-service-a"))
+	g.Expect(registry.Status.Rollout.Phase).Should(Equal(operatorv1alpha1.RolloutProgressing))
+
+	// an interrupted rollout is restarted, a rollback to the previous revision completes it
+	registry.Status.CurrentRevision = "common-service-a"
+	g.Expect(startRollout(registry)).Should(BeFalse())
+	g.Expect(registry.Status.Rollout.Phase).Should(Equal(operatorv1alpha1.RolloutCompleted))
+}
+
+func TestNextBatch(t *testing.T) {
+	g := NewWithT(t)
+	requests := []operatorv1alpha1.OperandRequest{
+		rolloutRequest("ns-b", "req", operatorv1alpha1.ClusterPhaseRunning, "common-service-a"),
+		rolloutRequest("canary", "req", operatorv1alpha1.ClusterPhaseRunning, "common-service-a"),
+		rolloutRequest("ns-a", "req", operatorv1alpha1.ClusterPhaseRunning, "common-service-a"),
+		rolloutRequest("ns-c", "req", operatorv1alpha1.ClusterPhaseRunning, "common-service-a"),
+	}
+	// The canary namespaces are promoted first and then the batches
+	rollout := &operatorv1alpha1.RolloutStatus{Revision: "common-service-b", PreviousRevision: "common-service-a"}
+	g.Expect(nextBatch(rollout, rolloutStrategy, requests)).Should(Equal([]string{"canary/req"}))
+
+	rollout.Batch = 1
+	rollout.Promoted = []string{"canary/req"}
+	g.Expect(nextBatch(rollout, rolloutStrategy, requests)).Should(Equal([]string{"ns-a/req", "ns-b/req"}))
+}
+
+func TestPendingRequests(t *testing.T) {
+	g := NewWithT(t)
+	// The batch waits for the OperandRequests running with the revision
+	rollout := &operatorv1alpha1.RolloutStatus{Revision: "common-service-b", CurrentBatch: []string{"ns-a/req", "ns-b/req", "ns-deleted/req"}}
+	requests := []operatorv1alpha1.OperandRequest{
+		rolloutRequest("ns-a", "req", operatorv1alpha1.ClusterPhaseRunning, "common-service-b"),
+		rolloutRequest("ns-b", "req", operatorv1alpha1.ClusterPhaseRunning, "common-service-a"),
+	}
+	g.Expect(pendingRequests(rollout, requests, rolloutRegistryKey)).Should(Equal([]string{"ns-b/req"}))
+}
+
+func TestGetRolloutRevision(t *testing.T) {
+	g := NewWithT(t)
+	registry := &operatorv1alpha1.OperandRegistry{}
+	registry.Spec.RolloutStrategy = rolloutStrategy
+	registry.Status.Rollout = &operatorv1alpha1.RolloutStatus{
+		Revision:         "common-service-b",
+		PreviousRevision: "common-service-a",
+		Phase:            operatorv1alpha1.RolloutProgressing,
+		Promoted:         []string{"canary/req"},
+	}
+	g.Expect(registry.GetRolloutRevision(types.NamespacedName{Namespace: "canary", Name: "req"})).Should(Equal("common-service-b"))
+	g.Expect(registry.GetRolloutRevision(types.NamespacedName{Namespace: "ns-a", Name: "req"})).Should(Equal("common-service-a"))
+}
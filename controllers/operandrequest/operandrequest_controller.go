@@ -30,6 +30,7 @@ import (
 	"github.com/pkg/errors"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog"
@@ -63,7 +64,7 @@ type clusterObjects struct {
 // Note:
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
-func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reconcileErr error) {
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, reconcileErr error) {
 	// Fetch the OperandRequest instance
 	requestInstance := &operatorv1alpha1.OperandRequest{}
 	if err := r.Client.Get(ctx, req.NamespacedName, requestInstance); err != nil {
@@ -72,10 +73,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 	}
 
 	originalInstance := requestInstance.DeepCopy()
+	// The OperandRequest is gone once its finalizer is removed, there is no status to patch
+	finalizerRemoved := false
 
 	// Always attempt to patch the status after each reconciliation.
 	defer func() {
-		if reflect.DeepEqual(originalInstance.Status, requestInstance.Status) {
+		if finalizerRemoved || reflect.DeepEqual(originalInstance.Status, requestInstance.Status) {
 			return
 		}
 		if err := r.Client.Status().Patch(ctx, requestInstance, client.MergeFrom(originalInstance)); err != nil {
@@ -93,14 +96,22 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 			return ctrl.Result{}, err
 		}
 
+		// Keep the finalizer until all the resources are removed
+		if len(requestInstance.Status.Deletions) != 0 {
+			klog.V(2).Infof("Waiting for %d resources of OperandRequest %s being deleted ...", len(requestInstance.Status.Deletions), req.NamespacedName.String())
+			return ctrl.Result{RequeueAfter: constant.DefaultDeletionRequeueDuration}, nil
+		}
+
 		originalReq := requestInstance.DeepCopy()
 		// Update finalizer to allow delete CR
 		if requestInstance.RemoveFinalizer() {
 			err = r.Patch(ctx, requestInstance, client.MergeFrom(originalReq))
 			if err != nil {
 				klog.Errorf("failed to remove finalizer for OperandRequest %s: %v", req.NamespacedName.String(), err)
+				finalizerRemoved = apierrors.IsNotFound(err)
 				return ctrl.Result{}, client.IgnoreNotFound(err)
 			}
+			finalizerRemoved = true
 		}
 		return ctrl.Result{}, nil
 	}
@@ -134,26 +145,59 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{Requeue: true}, err
 	}
 
+	// Forget the resources which have been removed since the last reconciliation
+	if err := r.refreshDeletionStatus(ctx, requestInstance); err != nil {
+		klog.Errorf("failed to check the deletion progress for OperandRequest %s: %v", req.NamespacedName.String(), err)
+		return ctrl.Result{}, err
+	}
+
+	// Check the members against their progress deadline, whatever the result of the reconciliation
+	defer func() {
+		backoff, err := r.checkProgress(ctx, requestInstance)
+		if err != nil {
+			reconcileErr = utilerrors.NewAggregate([]error{reconcileErr, err})
+			return
+		}
+		// Retry the members exceeding their progress deadline with an exponential backoff instead of the requeue duration
+		if reconcileErr == nil && backoff > result.RequeueAfter && len(requestInstance.Status.Deletions) == 0 {
+			result.RequeueAfter = backoff
+		}
+	}()
+
+	// The OperandPolicies applying to the namespace are checked by both the operators and the operands
+	policy, err := r.GetRequestPolicy(ctx, requestInstance)
+	if err != nil {
+		klog.Errorf("failed to get the OperandPolicies of OperandRequest %s: %v", req.NamespacedName.String(), err)
+		return ctrl.Result{}, err
+	}
+
 	// Reconcile Operators
-	if err := r.reconcileOperator(ctx, requestInstance); err != nil {
+	if err := r.reconcileOperator(ctx, requestInstance, policy); err != nil {
 		klog.Errorf("failed to reconcile Operators for OperandRequest %s: %v", req.NamespacedName.String(), err)
 		return ctrl.Result{}, err
 	}
 
 	// Reconcile Operands
-	if merr := r.reconcileOperand(ctx, requestInstance); len(merr.Errors) != 0 {
+	if merr := r.reconcileOperand(ctx, requestInstance, policy); len(merr.Errors) != 0 {
 		klog.Errorf("failed to reconcile Operands for OperandRequest %s: %v", req.NamespacedName.String(), merr)
 		return ctrl.Result{}, merr
 	}
 
+	// Check the progress of the deletion without blocking the worker
+	if len(requestInstance.Status.Deletions) != 0 {
+		klog.V(2).Infof("Waiting for %d resources of OperandRequest %s being deleted ...", len(requestInstance.Status.Deletions), req.NamespacedName.String())
+		return ctrl.Result{RequeueAfter: constant.DefaultDeletionRequeueDuration}, nil
+	}
+
+	requeueDuration, syncPeriod := r.requeueIntervals(requestInstance)
 	// Check if all csv deploy succeed
 	if requestInstance.Status.Phase != operatorv1alpha1.ClusterPhaseRunning {
 		klog.V(2).Info("Waiting for all operators and operands to be deployed successfully ...")
-		return ctrl.Result{RequeueAfter: constant.DefaultRequeueDuration}, nil
+		return ctrl.Result{RequeueAfter: requeueDuration}, nil
 	}
 
 	klog.V(1).Infof("Finished reconciling OperandRequest: %s", req.NamespacedName)
-	return ctrl.Result{RequeueAfter: constant.DefaultSyncPeriod}, nil
+	return ctrl.Result{RequeueAfter: syncPeriod}, nil
 }
 
 func (r *Reconciler) checkPermission(ctx context.Context, req ctrl.Request) bool {
@@ -207,6 +251,9 @@ func (r *Reconciler) addFinalizer(ctx context.Context, cr *operatorv1alpha1.Oper
 
 func (r *Reconciler) checkFinalizer(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest) error {
 	klog.V(1).Infof("Deleting OperandRequest %s in the namespace %s", requestInstance.Name, requestInstance.Namespace)
+	if err := r.refreshDeletionStatus(ctx, requestInstance); err != nil {
+		return err
+	}
 	existingSub := &olmv1alpha1.SubscriptionList{}
 
 	opts := []client.ListOption{
@@ -216,7 +263,7 @@ func (r *Reconciler) checkFinalizer(ctx context.Context, requestInstance *operat
 	if err := r.Client.List(ctx, existingSub, opts...); err != nil {
 		return err
 	}
-	if len(existingSub.Items) == 0 {
+	if len(existingSub.Items) == 0 && len(requestInstance.Status.Deletions) == 0 {
 		return nil
 	}
 	// Delete all the subscriptions that created by current request
@@ -265,6 +312,24 @@ func (r *Reconciler) getSubToRequestMapper() handler.MapFunc {
 	}
 }
 
+func (r *Reconciler) getCatalogSourceToRequestMapper() handler.MapFunc {
+	ctx := context.Background()
+	return func(object client.Object) []ctrl.Request {
+		registries, _ := r.ListOperandRegistriesByCatalogSource(ctx, types.NamespacedName{Namespace: object.GetNamespace(), Name: object.GetName()})
+
+		requests := []ctrl.Request{}
+		for _, registry := range registries {
+			requestList, _ := r.ListOperandRequestsByRegistry(ctx, types.NamespacedName{Namespace: registry.Namespace, Name: registry.Name})
+			for _, request := range requestList {
+				namespaceName := types.NamespacedName{Name: request.Name, Namespace: request.Namespace}
+				req := ctrl.Request{NamespacedName: namespaceName}
+				requests = append(requests, req)
+			}
+		}
+		return requests
+	}
+}
+
 func (r *Reconciler) getConfigToRequestMapper() handler.MapFunc {
 	ctx := context.Background()
 	return func(object client.Object) []ctrl.Request {
@@ -280,6 +345,53 @@ func (r *Reconciler) getConfigToRequestMapper() handler.MapFunc {
 	}
 }
 
+// getPolicyToRequestMapper maps an OperandPolicy to the OperandRequests in the namespaces it applies to.
+// The previous and the new OperandPolicy of an update are both mapped, so the namespaces it no longer applies to are reconciled too.
+func (r *Reconciler) getPolicyToRequestMapper() handler.MapFunc {
+	ctx := context.Background()
+	return func(object client.Object) []ctrl.Request {
+		policy, ok := object.(*operatorv1alpha1.OperandPolicy)
+		if !ok {
+			return nil
+		}
+		requestList, err := r.ListOperandRequests(ctx, nil)
+		if err != nil {
+			klog.Warningf("failed to list OperandRequests: %v", err)
+			return nil
+		}
+
+		applies := make(map[string]bool)
+		requests := []ctrl.Request{}
+		for _, request := range requestList.Items {
+			applied, checked := applies[request.Namespace]
+			if !checked {
+				applied = r.policyAppliesTo(ctx, policy, request.Namespace)
+				applies[request.Namespace] = applied
+			}
+			if applied {
+				requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Name: request.Name, Namespace: request.Namespace}})
+			}
+		}
+		return requests
+	}
+}
+
+// policyAppliesTo returns true when the OperandPolicy applies to the namespace.
+// The namespace is reconciled when it can't be checked, the OperandRequests report the failure.
+func (r *Reconciler) policyAppliesTo(ctx context.Context, policy *operatorv1alpha1.OperandPolicy, namespace string) bool {
+	ns := &corev1.Namespace{}
+	if err := r.Reader.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		klog.Warningf("failed to get namespace %s: %v", namespace, err)
+		return !apierrors.IsNotFound(err)
+	}
+	applies, err := policy.AppliesTo(ns.Name, ns.Labels)
+	if err != nil {
+		klog.Warningf("invalid namespaceSelector of OperandPolicy %s: %v", policy.Name, err)
+		return true
+	}
+	return applies
+}
+
 // SetupWithManager adds OperandRequest controller to the manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -302,13 +414,18 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 			UpdateFunc: func(e event.UpdateEvent) bool {
 				oldObject := e.ObjectOld.(*operatorv1alpha1.OperandRegistry)
 				newObject := e.ObjectNew.(*operatorv1alpha1.OperandRegistry)
-				return !reflect.DeepEqual(oldObject.Spec, newObject.Spec)
+				// the OperandRequests receive the change of the OperandRegistry when the rollout promotes them,
+				// the change of the default channels resolved from the catalog, and the change of the base OperandRegistry
+				return !reflect.DeepEqual(oldObject.Spec, newObject.Spec) || !reflect.DeepEqual(oldObject.Status.Rollout, newObject.Status.Rollout) ||
+					!reflect.DeepEqual(oldObject.Status.DefaultChannels, newObject.Status.DefaultChannels) ||
+					!reflect.DeepEqual(oldObject.Status.EffectiveOperators, newObject.Status.EffectiveOperators)
 			},
 			DeleteFunc: func(e event.DeleteEvent) bool {
 				// Evaluates to false if the object has been confirmed deleted.
 				return !e.DeleteStateUnknown
 			},
 		})).
+		Watches(&source.Kind{Type: &olmv1alpha1.CatalogSource{}}, handler.EnqueueRequestsFromMapFunc(r.getCatalogSourceToRequestMapper()), builder.WithPredicates(deploy.CatalogSourceStatePredicate())).
 		Watches(&source.Kind{Type: &operatorv1alpha1.OperandConfig{}}, handler.EnqueueRequestsFromMapFunc(r.getConfigToRequestMapper()), builder.WithPredicates(predicate.Funcs{
 			DeleteFunc: func(e event.DeleteEvent) bool {
 				// Evaluates to false if the object has been confirmed deleted.
@@ -319,5 +436,6 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 				newObject := e.ObjectNew.(*operatorv1alpha1.OperandConfig)
 				return !reflect.DeepEqual(oldObject.Spec, newObject.Spec)
 			},
-		})).Complete(r)
+		})).
+		Watches(&source.Kind{Type: &operatorv1alpha1.OperandPolicy{}}, handler.EnqueueRequestsFromMapFunc(r.getPolicyToRequestMapper()), builder.WithPredicates(predicate.GenerationChangedPredicate{})).Complete(r)
 }
@@ -95,6 +95,18 @@ var _ = Describe("OperandRegistry controller", func() {
 		Expect(k8sClient.Status().Update(ctx, catalogSource)).Should(Succeed())
 	})
 
+	inventoryOwners := func() []operatorv1alpha1.ReconcileRequest {
+		inventory := &operatorv1alpha1.OperandInventory{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: "etcd", Namespace: operatorNamespaceName}, inventory); err != nil {
+			return nil
+		}
+		var owners []operatorv1alpha1.ReconcileRequest
+		for _, owner := range inventory.Spec.Owners {
+			owners = append(owners, owner.Request)
+		}
+		return owners
+	}
+
 	AfterEach(func() {
 		By("Deleting the CatalogSource")
 		Expect(k8sClient.Delete(ctx, catalogSource)).Should(Succeed())
@@ -256,6 +268,12 @@ var _ = Describe("OperandRegistry controller", func() {
 				return requestInstance2.Status.Phase
 			}, testutil.Timeout, testutil.Interval).Should(Equal(operatorv1alpha1.ClusterPhaseInstalling))
 
+			By("Checking both OperandRequests own the etcd Subscription")
+			Eventually(inventoryOwners, testutil.Timeout, testutil.Interval).Should(ConsistOf(
+				operatorv1alpha1.ReconcileRequest{Namespace: namespaceName, Name: name1},
+				operatorv1alpha1.ReconcileRequest{Namespace: namespaceName, Name: name2},
+			))
+
 			By("Setting status of the Subscriptions")
 			Eventually(func() error {
 				etcdSub := &olmv1alpha1.Subscription{}
@@ -332,6 +350,11 @@ var _ = Describe("OperandRegistry controller", func() {
 				return err
 			}, testutil.Timeout, testutil.Interval).Should(Succeed())
 
+			By("Checking the first OperandRequest no longer owns the etcd Subscription")
+			Eventually(inventoryOwners, testutil.Timeout, testutil.Interval).Should(ConsistOf(
+				operatorv1alpha1.ReconcileRequest{Namespace: namespaceName, Name: name2},
+			))
+
 			By("Disabling the etcd operator from second OperandRequest")
 			requestInstance2 := &operatorv1alpha1.OperandRequest{}
 			Expect(k8sClient.Get(ctx, requestKey2, requestInstance2)).Should(Succeed())
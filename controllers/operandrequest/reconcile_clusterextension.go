@@ -0,0 +1,238 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operandrequest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
+)
+
+// reconcileClusterExtension creates or updates the OLM v1 ClusterExtension for the operator
+func (r *Reconciler) reconcileClusterExtension(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, opt *operatorv1alpha1.Operator, registryKey types.NamespacedName, mu sync.Locker) error {
+	owner := operatorv1alpha1.NewInventoryOwner(types.NamespacedName{Namespace: requestInstance.Namespace, Name: requestInstance.Name}, registryKey, opt.Name)
+	inventoryKey := types.NamespacedName{Namespace: opt.Namespace, Name: opt.Name}
+
+	ce, err := r.GetClusterExtension(ctx, opt.Name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		// ClusterExtension does not exist, create a new one
+		ce = deploy.NewClusterExtension(opt.Name)
+		ce.SetLabels(map[string]string{constant.OpreqLabel: "true"})
+		setOwnerAnnotations(ce, (&operatorv1alpha1.OperandInventory{Spec: operatorv1alpha1.OperandInventorySpec{Owners: []operatorv1alpha1.InventoryOwner{owner}}}).GenerateAnnotations())
+		if err := deploy.SetClusterExtensionSpec(ce, opt); err != nil {
+			return err
+		}
+
+		klog.V(2).Info("Creating the ClusterExtension: " + opt.Name)
+		requestInstance.SetCreatingCondition(opt.Name, operatorv1alpha1.ResourceTypeClusterExtension, corev1.ConditionTrue, mu)
+		if err := r.Create(ctx, ce); err != nil && !apierrors.IsAlreadyExists(err) {
+			requestInstance.SetCreatingCondition(opt.Name, operatorv1alpha1.ResourceTypeClusterExtension, corev1.ConditionFalse, mu)
+			requestInstance.SetMemberStatus(opt.Name, operatorv1alpha1.OperatorFailed, "", mu)
+			return errors.Wrapf(err, "failed to create ClusterExtension %s", opt.Name)
+		}
+		// Record the OperandRequest in the OperandInventory of the ClusterExtension
+		if _, err := r.acquireInventory(ctx, operatorv1alpha1.InventoryKindClusterExtension, ce, inventoryKey, owner); err != nil {
+			return err
+		}
+		requestInstance.SetMemberStatus(opt.Name, operatorv1alpha1.OperatorInstalling, "", mu)
+		return nil
+	}
+
+	if !r.CheckLabel(*ce, map[string]string{constant.OpreqLabel: "true"}) {
+		// ClusterExtension existing and not managed by OperandRequest controller
+		klog.V(1).Infof("ClusterExtension %s isn't created by ODLM. Ignore update/delete it.", ce.GetName())
+		return nil
+	}
+
+	originalCE := ce.DeepCopy()
+	// record the OperandRequest in the OperandInventory and generate the annotations from the owners
+	ownerAnnotations, err := r.acquireInventory(ctx, operatorv1alpha1.InventoryKindClusterExtension, ce, inventoryKey, owner)
+	if err != nil {
+		return err
+	}
+	setOwnerAnnotations(ce, ownerAnnotations)
+	if err := deploy.SetClusterExtensionSpec(ce, opt); err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(ce.Object["spec"], originalCE.Object["spec"]) && equality.Semantic.DeepEqual(ce.GetAnnotations(), originalCE.GetAnnotations()) {
+		return nil
+	}
+
+	klog.V(2).Infof("Updating ClusterExtension %s ...", ce.GetName())
+	requestInstance.SetUpdatingCondition(opt.Name, operatorv1alpha1.ResourceTypeClusterExtension, corev1.ConditionTrue, mu)
+	if err := r.Patch(ctx, ce, client.MergeFrom(originalCE)); err != nil {
+		requestInstance.SetUpdatingCondition(opt.Name, operatorv1alpha1.ResourceTypeClusterExtension, corev1.ConditionFalse, mu)
+		requestInstance.SetMemberStatus(opt.Name, operatorv1alpha1.OperatorFailed, "", mu)
+		return errors.Wrapf(err, "failed to update ClusterExtension %s", opt.Name)
+	}
+	requestInstance.SetMemberStatus(opt.Name, operatorv1alpha1.OperatorUpdating, "", mu)
+	return nil
+}
+
+// checkClusterExtension maps the ClusterExtension installation status into the member status.
+// It returns true when the bundle is installed and the operands can be created.
+func (r *Reconciler) checkClusterExtension(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, opt *operatorv1alpha1.Operator, operandName string) (bool, error) {
+	ce, err := r.GetClusterExtension(ctx, opt.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.Warningf("There is no ClusterExtension %s", opt.Name)
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to get the ClusterExtension %s", opt.Name)
+	}
+
+	phase := deploy.GetClusterExtensionPhase(ce)
+	requestInstance.SetMemberStatus(operandName, phase, "", &r.Mutex)
+	bundle, version := deploy.GetClusterExtensionInstalledBundle(ce)
+	requestInstance.SetMemberInstallStatus(operandName, bundle, version, deploy.GetClusterExtensionConditions(ce), &r.Mutex)
+
+	if phase != operatorv1alpha1.OperatorRunning {
+		klog.Warningf("ClusterExtension %s is not installed yet, retry", opt.Name)
+		requestInstance.SetMemberBlockingReason(operandName, fmt.Sprintf("the ClusterExtension %s is %s", opt.Name, phase), &r.Mutex)
+		return false, nil
+	}
+	return true, nil
+}
+
+// deleteClusterExtension removes the operands and the ClusterExtension of the operator
+func (r *Reconciler) deleteClusterExtension(ctx context.Context, op *operatorv1alpha1.Operator, requestInstance *operatorv1alpha1.OperandRequest, registryInstance *operatorv1alpha1.OperandRegistry, configInstance *operatorv1alpha1.OperandConfig) error {
+	ce, err := r.GetClusterExtension(ctx, op.Name)
+	if apierrors.IsNotFound(err) {
+		klog.V(3).Infof("There is no ClusterExtension %s", op.Name)
+		requestInstance.RemoveOperandDeletionStatus(op.Name, &r.Mutex)
+		return nil
+	} else if err != nil {
+		klog.Errorf("Failed to get ClusterExtension %s", op.Name)
+		return err
+	}
+
+	if !r.CheckLabel(*ce, map[string]string{constant.OpreqLabel: "true"}) {
+		// ClusterExtension existing and not managed by OperandRequest controller
+		klog.V(2).Infof("ClusterExtension %s isn't created by ODLM", ce.GetName())
+		requestInstance.RemoveOperandDeletionStatus(op.Name, &r.Mutex)
+		return nil
+	}
+
+	// remove the OperandRequest from the owners of the ClusterExtension
+	originalCE := ce.DeepCopy()
+	owner := operatorv1alpha1.NewInventoryOwner(types.NamespacedName{Namespace: requestInstance.Namespace, Name: requestInstance.Name}, types.NamespacedName{Namespace: registryInstance.Namespace, Name: registryInstance.Name}, op.Name)
+	inventory, err := r.releaseInventory(ctx, types.NamespacedName{Namespace: op.Namespace, Name: op.Name}, owner)
+	if err != nil {
+		return err
+	}
+	if inventory != nil && len(inventory.Spec.Owners) != 0 {
+		setOwnerAnnotations(ce, inventory.GenerateAnnotations())
+		if err := r.Patch(ctx, ce, client.MergeFrom(originalCE)); err != nil {
+			requestInstance.SetUpdatingCondition(ce.GetName(), operatorv1alpha1.ResourceTypeClusterExtension, corev1.ConditionFalse, &r.Mutex)
+			return err
+		}
+		klog.V(1).Infof("Did not delete ClusterExtension %s which is still requested by %d OperandRequests", ce.GetName(), len(inventory.Spec.Owners))
+		requestInstance.RemoveOperandDeletionStatus(op.Name, &r.Mutex)
+		return nil
+	} else if inventory == nil {
+		// check and remove registry and config in annotation of ClusterExtension created before the OperandInventory
+		annotations := ce.GetAnnotations()
+		delete(annotations, registryInstance.Namespace+"."+registryInstance.Name+"/registry")
+		delete(annotations, registryInstance.Namespace+"."+registryInstance.Name+"/config")
+		ce.SetAnnotations(annotations)
+		for anno := range annotations {
+			if deploy.OwnerAnnotation.MatchString(anno) && strings.HasSuffix(anno, "/registry") {
+				// remove the associated registry from annotation of ClusterExtension
+				if err := r.Patch(ctx, ce, client.MergeFrom(originalCE)); err != nil {
+					requestInstance.SetUpdatingCondition(ce.GetName(), operatorv1alpha1.ResourceTypeClusterExtension, corev1.ConditionFalse, &r.Mutex)
+					return err
+				}
+				klog.V(1).Infof("Did not delete ClusterExtension %s which is requested by OperandRequest with different OperandRegistry", ce.GetName())
+				requestInstance.RemoveOperandDeletionStatus(op.Name, &r.Mutex)
+				return nil
+			}
+		}
+	}
+
+	// The ClusterExtension waits for its operands being deleted
+	if err := r.setPendingDeletion(requestInstance, op.Name, ce); err != nil {
+		return err
+	}
+
+	var almExamples string
+	if service := configInstance.GetService(op.Name); service != nil {
+		if almExamples, err = service.GetALMExamples(); err != nil {
+			return err
+		}
+	}
+	policy := op.GetDeletionPolicy()
+	timeouts := r.EffectiveTimeouts(op, configInstance.GetService(op.Name))
+	klog.V(2).Infof("Deleting all the Custom Resources for ClusterExtension %s", ce.GetName())
+	crDeleted, err := r.deleteAllCustomResource(ctx, almExamples, requestInstance, configInstance, op.Name, op.Namespace, policy, timeouts.CRDeleteTimeout.Duration)
+	if err != nil {
+		return err
+	}
+	klog.V(2).Infof("Deleting all the k8s Resources for ClusterExtension %s", ce.GetName())
+	k8sDeleted, err := r.deleteAllK8sResource(ctx, requestInstance, configInstance, op.Name, op.Namespace, policy, timeouts.CRDeleteTimeout.Duration)
+	if err != nil {
+		return err
+	}
+	if !crDeleted || !k8sDeleted {
+		klog.V(2).Infof("Waiting for the operands of operator %s being deleted ...", op.Name)
+		return nil
+	}
+	if policy != operatorv1alpha1.DeletionPolicyDelete {
+		klog.V(1).Infof("Operator %s has deletion policy %s. Skip the uninstall", op.Name, policy)
+		if err := r.retainOperator(ctx, ce, originalCE, types.NamespacedName{Namespace: op.Namespace, Name: op.Name}, policy); err != nil {
+			return err
+		}
+		requestInstance.RemoveOperandDeletionStatus(op.Name, &r.Mutex)
+		return nil
+	}
+	if r.CheckLabel(*ce, map[string]string{constant.NotUninstallLabel: "true"}) {
+		klog.V(1).Infof("Operator %s has label operator.ibm.com/opreq-do-not-uninstall. Skip the uninstall", op.Name)
+		requestInstance.RemoveOperandDeletionStatus(op.Name, &r.Mutex)
+		return nil
+	}
+
+	klog.V(2).Infof("Deleting the ClusterExtension %s", ce.GetName())
+	requestInstance.SetDeletingCondition(ce.GetName(), operatorv1alpha1.ResourceTypeClusterExtension, corev1.ConditionTrue, &r.Mutex)
+	deleted, err := r.deleteResource(ctx, requestInstance, op.Name, ce, timeouts.SubDeleteTimeout.Duration)
+	if err != nil {
+		requestInstance.SetDeletingCondition(ce.GetName(), operatorv1alpha1.ResourceTypeClusterExtension, corev1.ConditionFalse, &r.Mutex)
+		return errors.Wrap(err, "failed to delete ClusterExtension")
+	}
+	if !deleted {
+		klog.V(2).Infof("Waiting for ClusterExtension %s being removed ...", ce.GetName())
+		return nil
+	}
+
+	requestInstance.RemoveOperandDeletionStatus(op.Name, &r.Mutex)
+	klog.V(1).Infof("ClusterExtension %s is deleted", ce.GetName())
+	return nil
+}
@@ -0,0 +1,119 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operandrequest
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+)
+
+// deleteResource starts the deletion of a resource of the operand and records the progress in the OperandRequest status.
+// It returns true once the resource is gone, and never waits for the resource being removed.
+func (r *Reconciler) deleteResource(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, operand string, obj client.Object, timeout time.Duration) (bool, error) {
+	ds, err := r.newDeletionStatus(operand, obj, operatorv1alpha1.DeletionDeleting)
+	if err != nil {
+		return false, err
+	}
+
+	done, err := r.DeleteResource(ctx, obj, timeout)
+	if done {
+		requestInstance.RemoveDeletionStatus(ds, &r.Mutex)
+		return true, nil
+	}
+	ds.StartTime = obj.GetDeletionTimestamp()
+	if err != nil {
+		ds.Phase = operatorv1alpha1.DeletionFailed
+		ds.Message = err.Error()
+	}
+	requestInstance.SetDeletionStatus(ds, &r.Mutex)
+	return false, err
+}
+
+// setPendingDeletion records the resource of the operand is waiting for its operands being deleted
+func (r *Reconciler) setPendingDeletion(requestInstance *operatorv1alpha1.OperandRequest, operand string, obj client.Object) error {
+	ds, err := r.newDeletionStatus(operand, obj, operatorv1alpha1.DeletionPending)
+	if err != nil {
+		return err
+	}
+	now := metav1.Now()
+	for _, d := range requestInstance.Status.Deletions {
+		if d.Kind == ds.Kind && d.Namespace == ds.Namespace && d.Name == ds.Name && d.StartTime != nil {
+			now = *d.StartTime
+		}
+	}
+	ds.StartTime = &now
+	requestInstance.SetDeletionStatus(ds, &r.Mutex)
+	return nil
+}
+
+// removeDeletion removes the deletion progress of the resource which is not deleted anymore
+func (r *Reconciler) removeDeletion(requestInstance *operatorv1alpha1.OperandRequest, operand string, obj client.Object) error {
+	ds, err := r.newDeletionStatus(operand, obj, "")
+	if err != nil {
+		return err
+	}
+	requestInstance.RemoveDeletionStatus(ds, &r.Mutex)
+	return nil
+}
+
+func (r *Reconciler) newDeletionStatus(operand string, obj client.Object, phase operatorv1alpha1.DeletionPhase) (operatorv1alpha1.DeletionStatus, error) {
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return operatorv1alpha1.DeletionStatus{}, errors.Wrapf(err, "failed to get the kind of resource %s/%s", obj.GetNamespace(), obj.GetName())
+	}
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+	return operatorv1alpha1.DeletionStatus{
+		Operand:    operand,
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Phase:      phase,
+	}, nil
+}
+
+// refreshDeletionStatus forgets the resources in the Deletions status list which are already removed
+func (r *Reconciler) refreshDeletionStatus(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest) error {
+	for _, ds := range requestInstance.Status.Deletions {
+		res := &unstructured.Unstructured{}
+		res.SetAPIVersion(ds.APIVersion)
+		res.SetKind(ds.Kind)
+		err := r.Client.Get(ctx, types.NamespacedName{Namespace: ds.Namespace, Name: ds.Name}, res)
+		if err == nil {
+			continue
+		}
+		// The resource is gone together with its kind when the NoMatch error is returned
+		if !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return errors.Wrapf(err, "failed to get %s %s/%s", ds.Kind, ds.Namespace, ds.Name)
+		}
+		klog.V(2).Infof("%s %s/%s of operand %s is removed", ds.Kind, ds.Namespace, ds.Name, ds.Operand)
+		requestInstance.RemoveDeletionStatus(ds, &r.Mutex)
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operandrequest
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
+)
+
+// setOwnerAnnotations replaces the registry, config and request annotations of the managed resource
+func setOwnerAnnotations(obj metav1.Object, ownerAnnotations map[string]string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	for anno := range annotations {
+		if deploy.OwnerAnnotation.MatchString(anno) {
+			delete(annotations, anno)
+		}
+	}
+	for anno, value := range ownerAnnotations {
+		annotations[anno] = value
+	}
+	obj.SetAnnotations(annotations)
+}
+
+// acquireInventory records the OperandRequest as an owner in the OperandInventory of the managed resource.
+// It returns the registry, config and request annotations of the managed resource generated from the owners.
+func (r *Reconciler) acquireInventory(ctx context.Context, kind string, managed client.Object, inventoryKey types.NamespacedName, owner operatorv1alpha1.InventoryOwner) (map[string]string, error) {
+	inventory, err := r.GetOperandInventory(ctx, inventoryKey)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "failed to get OperandInventory %s", inventoryKey.String())
+		}
+		inventory = deploy.NewOperandInventory(kind, managed.GetName(), managed.GetNamespace(), inventoryKey.Namespace)
+		// Migrate the owners recorded in the annotations of the resources created before the OperandInventory
+		if err := r.seedInventoryOwners(ctx, inventory, managed.GetAnnotations(), owner.Operand); err != nil {
+			return nil, err
+		}
+		inventory.AddOwner(owner)
+		setInventoryOwnerReference(inventory, managed, kind)
+		klog.V(2).Infof("Creating OperandInventory %s", inventoryKey.String())
+		if err := r.Create(ctx, inventory); err != nil {
+			return nil, errors.Wrapf(err, "failed to create OperandInventory %s", inventoryKey.String())
+		}
+		return inventory.GenerateAnnotations(), nil
+	}
+
+	originalInventory := inventory.DeepCopy()
+	changed := inventory.AddOwner(owner)
+	if len(inventory.OwnerReferences) == 0 {
+		changed = setInventoryOwnerReference(inventory, managed, kind) || changed
+	}
+	if changed {
+		klog.V(2).Infof("Adding OperandRequest %s to OperandInventory %s", owner.GetRequestKey().String(), inventoryKey.String())
+		if err := r.Patch(ctx, inventory, client.MergeFromWithOptions(originalInventory, client.MergeFromWithOptimisticLock{})); err != nil {
+			return nil, errors.Wrapf(err, "failed to update OperandInventory %s", inventoryKey.String())
+		}
+	}
+	return inventory.GenerateAnnotations(), nil
+}
+
+// releaseInventory removes the OperandRequest from the owners in the OperandInventory of the managed resource,
+// together with the owners which no longer request the operand.
+// It returns nil when the managed resource was created before the OperandInventory.
+func (r *Reconciler) releaseInventory(ctx context.Context, inventoryKey types.NamespacedName, owner operatorv1alpha1.InventoryOwner) (*operatorv1alpha1.OperandInventory, error) {
+	inventory, err := r.GetOperandInventory(ctx, inventoryKey)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get OperandInventory %s", inventoryKey.String())
+	}
+
+	originalInventory := inventory.DeepCopy()
+	changed := inventory.RemoveOwner(owner)
+	var owners []operatorv1alpha1.InventoryOwner
+	for _, o := range inventory.Spec.Owners {
+		live, err := r.isLiveOwner(ctx, o)
+		if err != nil {
+			return nil, err
+		}
+		if !live {
+			klog.V(2).Infof("OperandRequest %s no longer requests operand %s, remove it from OperandInventory %s", o.GetRequestKey().String(), o.Operand, inventoryKey.String())
+			changed = true
+			continue
+		}
+		owners = append(owners, o)
+	}
+	inventory.Spec.Owners = owners
+
+	if changed {
+		klog.V(2).Infof("Removing OperandRequest %s from OperandInventory %s", owner.GetRequestKey().String(), inventoryKey.String())
+		if err := r.Patch(ctx, inventory, client.MergeFromWithOptions(originalInventory, client.MergeFromWithOptimisticLock{})); err != nil {
+			return nil, errors.Wrapf(err, "failed to update OperandInventory %s", inventoryKey.String())
+		}
+	}
+	return inventory, nil
+}
+
+// checkManagingRegistry returns whether the OperandRegistry manages the operator and its operands, and the managing one.
+// When several OperandRegistries request the operator, the managing one is chosen by the conflict policy
+// and the conflict is recorded as a condition of the OperandRequest.
+func (r *Reconciler) checkManagingRegistry(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, operand string, inventoryKey types.NamespacedName, annotations map[string]string, registryKey types.NamespacedName) (bool, string, error) {
+	registries, err := r.GetRequestingRegistries(ctx, inventoryKey, annotations)
+	if err != nil {
+		return false, "", err
+	}
+	if len(registries) == 0 {
+		return true, "", nil
+	}
+	managingRegistry, err := r.ResolveManagingRegistry(ctx, registries)
+	if err != nil {
+		return false, "", err
+	}
+	if len(registries) > 1 {
+		reason := r.ConflictReason(registries, managingRegistry)
+		klog.Warningf("Operator %s requested by OperandRequest %s/%s is in conflict: %s", operand, requestInstance.Namespace, requestInstance.Name, reason)
+		requestInstance.SetConflictCondition(operand, reason, corev1.ConditionTrue, &r.Mutex)
+	} else {
+		requestInstance.SetConflictCondition(operand, "", corev1.ConditionFalse, &r.Mutex)
+	}
+	if managingRegistry == nil {
+		return false, "no OperandRegistry", nil
+	}
+	return *managingRegistry == registryKey, managingRegistry.String(), nil
+}
+
+// isLiveOwner returns true when the OperandRequest of the owner exists and requests the operand from the OperandRegistry
+func (r *Reconciler) isLiveOwner(ctx context.Context, owner operatorv1alpha1.InventoryOwner) (bool, error) {
+	request, err := r.GetOperandRequest(ctx, owner.GetRequestKey())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, req := range request.Spec.Requests {
+		if !request.UsesRegistry(req, owner.GetRegistryKey()) {
+			continue
+		}
+		for _, operand := range req.Operands {
+			if operand.Name == owner.Operand {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// seedInventoryOwners adds the live OperandRequests recorded in the request annotations to the owners
+func (r *Reconciler) seedInventoryOwners(ctx context.Context, inventory *operatorv1alpha1.OperandInventory, annotations map[string]string, operand string) error {
+	var requestAnnotations []string
+	for anno := range annotations {
+		if strings.HasSuffix(anno, "/request") && deploy.OwnerAnnotation.MatchString(anno) {
+			requestAnnotations = append(requestAnnotations, anno)
+		}
+	}
+	sort.Strings(requestAnnotations)
+	for _, anno := range requestAnnotations {
+		nsName := strings.SplitN(strings.TrimSuffix(anno, "/request"), ".", 2)
+		request, err := r.GetOperandRequest(ctx, types.NamespacedName{Namespace: nsName[0], Name: nsName[1]})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		for _, req := range request.Spec.Requests {
+			for _, o := range req.Operands {
+				if o.Name == operand {
+					inventory.AddOwner(operatorv1alpha1.NewInventoryOwner(types.NamespacedName{Namespace: request.Namespace, Name: request.Name}, request.GetOperandRegistryKey(req, operand), operand))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isRolloutHeld returns true while a rollout of the OperandRegistry is in progress and an OperandRequest sharing the managed
+// resource through the OperandRegistry applies the previous revision. The managed resource is shared, so updating it for the
+// promoted OperandRequests would roll the change out to all of them: it receives the change once they are all promoted.
+func (r *Reconciler) isRolloutHeld(ctx context.Context, registryInstance *operatorv1alpha1.OperandRegistry, registryKey, inventoryKey types.NamespacedName) (bool, error) {
+	if !registryInstance.IsRolloutInProgress() {
+		return false, nil
+	}
+	inventory, err := r.GetOperandInventory(ctx, inventoryKey)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get OperandInventory %s", inventoryKey.String())
+	}
+	for _, owner := range inventory.Spec.Owners {
+		if owner.GetRegistryKey() == registryKey && registryInstance.IsRolloutPending(owner.GetRequestKey()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// detachInventory removes the owner reference of the OperandInventory, so it isn't garbage collected when the managed resource
+// is deleted to be recreated, as the Subscription deleted by a rollback. The recreated resource owns it again once acquired.
+func (r *Reconciler) detachInventory(ctx context.Context, inventoryKey types.NamespacedName) error {
+	inventory, err := r.GetOperandInventory(ctx, inventoryKey)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get OperandInventory %s", inventoryKey.String())
+	}
+	if len(inventory.OwnerReferences) == 0 {
+		return nil
+	}
+	originalInventory := inventory.DeepCopy()
+	inventory.SetOwnerReferences(nil)
+	klog.V(2).Infof("Detaching OperandInventory %s from its managed resource", inventoryKey.String())
+	if err := r.Patch(ctx, inventory, client.MergeFromWithOptions(originalInventory, client.MergeFromWithOptimisticLock{})); err != nil {
+		return errors.Wrapf(err, "failed to update OperandInventory %s", inventoryKey.String())
+	}
+	return nil
+}
+
+// setInventoryOwnerReference makes the OperandInventory garbage collected together with the managed resource
+func setInventoryOwnerReference(inventory *operatorv1alpha1.OperandInventory, managed client.Object, kind string) bool {
+	if managed.GetUID() == "" {
+		return false
+	}
+	apiVersion := "operators.coreos.com/v1alpha1"
+	if kind == operatorv1alpha1.InventoryKindClusterExtension {
+		apiVersion = deploy.ClusterExtensionGVK.GroupVersion().String()
+	}
+	inventory.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Name:       managed.GetName(),
+		UID:        managed.GetUID(),
+	}})
+	return true
+}
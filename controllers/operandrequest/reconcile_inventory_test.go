@@ -0,0 +1,99 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operandrequest
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/testutil"
+)
+
+func TestRolloutHeldUntilAllSharingRequestsPromoted(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	registryKey := types.NamespacedName{Namespace: "ibm-common-services", Name: "common-service"}
+	subKey := types.NamespacedName{Namespace: "ibm-common-services", Name: "etcd"}
+
+	inventory := deploy.NewOperandInventory(operatorv1alpha1.InventoryKindSubscription, subKey.Name, subKey.Namespace, subKey.Namespace)
+	inventory.AddOwner(operatorv1alpha1.NewInventoryOwner(types.NamespacedName{Namespace: "canary", Name: "req"}, registryKey, "etcd"))
+	inventory.AddOwner(operatorv1alpha1.NewInventoryOwner(types.NamespacedName{Namespace: "ns-a", Name: "req"}, registryKey, "etcd"))
+	c := testutil.NewFakeClient(inventory)
+	r := &Reconciler{ODLMOperator: &deploy.ODLMOperator{Client: c, Reader: c, Scheme: c.Scheme()}}
+	registry := func(phase operatorv1alpha1.RolloutPhase, promoted ...string) *operatorv1alpha1.OperandRegistry {
+		return &operatorv1alpha1.OperandRegistry{
+			Spec: operatorv1alpha1.OperandRegistrySpec{RolloutStrategy: &operatorv1alpha1.RolloutStrategy{}},
+			Status: operatorv1alpha1.OperandRegistryStatus{Rollout: &operatorv1alpha1.RolloutStatus{
+				Revision:         "common-service-b",
+				PreviousRevision: "common-service-a",
+				Phase:            phase,
+				Promoted:         promoted,
+			}},
+		}
+	}
+
+	// The Subscription is held until the rollout promotes all the OperandRequests sharing it
+	held, err := r.isRolloutHeld(ctx, registry(operatorv1alpha1.RolloutProgressing, "canary/req"), registryKey, subKey)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(held).Should(BeTrue())
+
+	held, err = r.isRolloutHeld(ctx, registry(operatorv1alpha1.RolloutProgressing, "canary/req", "ns-a/req"), registryKey, subKey)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(held).Should(BeFalse())
+
+	held, err = r.isRolloutHeld(ctx, registry(operatorv1alpha1.RolloutCompleted), registryKey, subKey)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(held).Should(BeFalse())
+}
+
+func TestDetachInventoryOfRolledBackSubscription(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	registryKey := types.NamespacedName{Namespace: "ibm-common-services", Name: "common-service"}
+	subKey := types.NamespacedName{Namespace: "ibm-common-services", Name: "etcd"}
+	subscription := func(uid types.UID) *olmv1alpha1.Subscription {
+		return &olmv1alpha1.Subscription{ObjectMeta: metav1.ObjectMeta{Name: subKey.Name, Namespace: subKey.Namespace, UID: uid}}
+	}
+
+	inventory := deploy.NewOperandInventory(operatorv1alpha1.InventoryKindSubscription, subKey.Name, subKey.Namespace, subKey.Namespace)
+	inventory.AddOwner(operatorv1alpha1.NewInventoryOwner(types.NamespacedName{Namespace: "ns-a", Name: "req"}, registryKey, "etcd"))
+	setInventoryOwnerReference(inventory, subscription("failed-uid"), operatorv1alpha1.InventoryKindSubscription)
+	c := testutil.NewFakeClient(inventory)
+	r := &Reconciler{ODLMOperator: &deploy.ODLMOperator{Client: c, Reader: c, Scheme: c.Scheme()}}
+
+	// The OperandInventory keeps its owners and is owned by the recreated Subscription
+	g.Expect(r.detachInventory(ctx, subKey)).Should(Succeed())
+	detached, err := r.GetOperandInventory(ctx, subKey)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(detached.OwnerReferences).Should(BeEmpty())
+
+	owner := operatorv1alpha1.NewInventoryOwner(types.NamespacedName{Namespace: "ns-b", Name: "req"}, registryKey, "etcd")
+	_, err = r.acquireInventory(ctx, operatorv1alpha1.InventoryKindSubscription, subscription("recreated-uid"), subKey, owner)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	acquired, err := r.GetOperandInventory(ctx, subKey)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(acquired.Spec.Owners).Should(HaveLen(2))
+	g.Expect(acquired.OwnerReferences).Should(HaveLen(1))
+	g.Expect(acquired.OwnerReferences[0].UID).Should(Equal(types.UID("recreated-uid")))
+}
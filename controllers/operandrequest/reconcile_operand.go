@@ -23,26 +23,29 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
-	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
 	constant "github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
 	util "github.com/IBM/operand-deployment-lifecycle-manager/controllers/util"
 )
 
-func (r *Reconciler) reconcileOperand(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest) *util.MultiErr {
+func (r *Reconciler) reconcileOperand(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, policy *deploy.RequestPolicy) *util.MultiErr {
 	klog.V(1).Infof("Reconciling Operands for OperandRequest: %s/%s", requestInstance.GetNamespace(), requestInstance.GetName())
 	// Update request status
 	defer func() {
@@ -55,89 +58,153 @@ func (r *Reconciler) reconcileOperand(ctx context.Context, requestInstance *oper
 		return merr
 	}
 	for _, req := range requestInstance.Spec.Requests {
-		registryKey := requestInstance.GetRegistryKey(req)
-		registryInstance, err := r.GetOperandRegistry(ctx, registryKey)
+		registries, err := r.GetOperandRegistriesForRequest(ctx, requestInstance, req)
 		if err != nil {
-			merr.Add(errors.Wrapf(err, "failed to get the OperandRegistry %s", registryKey.String()))
+			merr.Add(errors.Wrapf(err, "failed to get the OperandRegistry %s", requestInstance.GetRegistryKey(req).String()))
 			continue
 		}
-		regName := registryInstance.ObjectMeta.Name
-		regNs := registryInstance.ObjectMeta.Namespace
 
 		for i, operand := range req.Operands {
 
+			registry := deploy.SelectOperandRegistry(registries, requestInstance.Namespace, operand.Name)
+			registryKey, registryInstance := registry.Key, registry.Registry
 			opdRegistry := registryInstance.GetOperator(operand.Name)
 			if opdRegistry == nil {
-				klog.Warningf("Cannot find %s in the OperandRegistry instance %s in the namespace %s ", operand.Name, req.Registry, req.RegistryNamespace)
+				klog.Warningf("Cannot find %s in the OperandRegistry instance %s in the namespace %s ", operand.Name, registryKey.Name, registryKey.Namespace)
 				continue
 			}
+			// The operands of an operator not allowed by the OperandPolicies are not reconciled
+			if policy.CheckOperator(registryKey, operand.Name) != "" {
+				continue
+			}
+			requestInstance.SetMemberRegistry(operand.Name, registryKey, &r.Mutex)
 
 			operatorName := opdRegistry.Name
 
-			klog.V(3).Info("Looking for csv for the operator: ", operatorName)
+			timeouts, err := r.getTimeouts(ctx, registryKey, opdRegistry)
+			if err != nil {
+				merr.Add(err)
+				continue
+			}
+			requestInstance.SetMemberTimeouts(operand.Name, timeouts, &r.Mutex)
+			rollback := registryInstance.GetRollback(operatorName)
+			requestInstance.SetMemberRollback(operand.Name, rollback, &r.Mutex)
+
+			// almExamples holds the custom resource templates of the operator, it is empty when
+			// the operator is installed by an OLM v1 ClusterExtension without ClusterServiceVersion
+			var almExamples, sourceName string
+			if opdRegistry.IsClusterExtension() {
+				installed, err := r.checkClusterExtension(ctx, requestInstance, opdRegistry, operand.Name)
+				if err != nil {
+					merr.Add(err)
+					requestInstance.SetMemberStatus(operand.Name, operatorv1alpha1.OperatorFailed, "", &r.Mutex)
+					requestInstance.SetMemberBlockingReason(operand.Name, err.Error(), &r.Mutex)
+					continue
+				}
+				if !installed {
+					continue
+				}
+				// check the owners of the ClusterExtension, only the OperandRegistry chosen by the conflict policy reconciles it
+				managed, managingRegistry, err := r.checkManagingRegistry(ctx, requestInstance, operand.Name, types.NamespacedName{Namespace: opdRegistry.Namespace, Name: opdRegistry.Name}, nil, registryKey)
+				if err != nil {
+					merr.Add(err)
+					continue
+				}
+				if !managed {
+					klog.V(2).Infof("ClusterExtension %s is currently managed by %s", operatorName, managingRegistry)
+					continue
+				}
+				sourceName = operatorName
+			} else {
+				klog.V(3).Info("Looking for csv for the operator: ", operatorName)
 
-			// Looking for the CSV
-			namespace := r.GetOperatorNamespace(opdRegistry.InstallMode, opdRegistry.Namespace)
+				// Looking for the CSV
+				namespace := r.GetOperatorNamespace(opdRegistry.InstallMode, opdRegistry.Namespace)
 
-			sub, err := r.GetSubscription(ctx, operatorName, namespace, opdRegistry.PackageName)
+				sub, err := r.GetSubscription(ctx, operatorName, namespace, opdRegistry.PackageName)
 
-			if err != nil {
-				if apierrors.IsNotFound(err) || sub == nil {
-					klog.Warningf("There is no Subscription %s or %s in the namespace %s", operatorName, opdRegistry.PackageName, namespace)
-					continue
+				if err != nil {
+					if apierrors.IsNotFound(err) || sub == nil {
+						klog.Warningf("There is no Subscription %s or %s in the namespace %s", operatorName, opdRegistry.PackageName, namespace)
+						requestInstance.SetMemberBlockingReason(operand.Name, fmt.Sprintf("there is no Subscription %s in the namespace %s", operatorName, namespace), &r.Mutex)
+						continue
+					}
+					merr.Add(errors.Wrapf(err, "failed to get the Subscription %s in the namespace %s", operatorName, namespace))
+					return merr
 				}
-				merr.Add(errors.Wrapf(err, "failed to get the Subscription %s in the namespace %s", operatorName, namespace))
-				return merr
-			}
 
-			if _, ok := sub.Labels[constant.OpreqLabel]; !ok {
-				// Subscription existing and not managed by OperandRequest controller
-				klog.Warningf("Subscription %s in the namespace %s isn't created by ODLM", sub.Name, sub.Namespace)
-			}
+				if _, ok := sub.Labels[constant.OpreqLabel]; !ok {
+					// Subscription existing and not managed by OperandRequest controller
+					klog.Warningf("Subscription %s in the namespace %s isn't created by ODLM", sub.Name, sub.Namespace)
+				}
 
-			// check config annotation in subscription, identify the first ODLM has the priority to reconcile
-			var firstMatch string
-			reg, _ := regexp.Compile(`^(.*)\.(.*)\/config`)
-			for anno := range sub.Annotations {
-				if reg.MatchString(anno) {
-					firstMatch = anno
-					break
+				// check the owners of the subscription, only the OperandRegistry chosen by the conflict policy reconciles it
+				managed, managingRegistry, err := r.checkManagingRegistry(ctx, requestInstance, operand.Name, types.NamespacedName{Namespace: sub.Namespace, Name: sub.Name}, sub.Annotations, registryKey)
+				if err != nil {
+					merr.Add(err)
+					continue
+				}
+				if !managed {
+					klog.V(2).Infof("Subscription %s in the namespace %s is currently managed by %s", sub.Name, sub.Namespace, managingRegistry)
+					continue
 				}
-			}
 
-			if firstMatch != "" && firstMatch != regNs+"."+regName+"/config" {
-				klog.V(2).Infof("Subscription %s in the namespace %s is currently managed by %s", sub.Name, sub.Namespace, firstMatch)
-				continue
-			}
+				// The Subscription of a rolled back operator is pinned with the manual approval,
+				// approve the InstallPlan of the ClusterServiceVersion it is rolled back to
+				if rollback != nil {
+					if _, err := r.ApproveInstallPlan(ctx, sub, rollback.CSV); err != nil {
+						merr.Add(err)
+						continue
+					}
+				}
 
-			csv, err := r.GetClusterServiceVersion(ctx, sub)
+				csv, err := r.GetClusterServiceVersion(ctx, sub)
 
-			// If can't get CSV, requeue the request
-			if err != nil {
-				merr.Add(err)
-				requestInstance.SetMemberStatus(operand.Name, operatorv1alpha1.OperatorFailed, "", &r.Mutex)
-				continue
-			}
+				// If can't get CSV, requeue the request
+				if err != nil {
+					merr.Add(err)
+					requestInstance.SetMemberStatus(operand.Name, operatorv1alpha1.OperatorFailed, "", &r.Mutex)
+					requestInstance.SetMemberBlockingReason(operand.Name, err.Error(), &r.Mutex)
+					continue
+				}
 
-			if csv == nil {
-				klog.Warningf("ClusterServiceVersion for the Subscription %s in the namespace %s is not ready yet, retry", operatorName, namespace)
-				requestInstance.SetMemberStatus(operand.Name, operatorv1alpha1.OperatorInstalling, "", &r.Mutex)
-				continue
-			}
+				if csv == nil {
+					klog.Warningf("ClusterServiceVersion for the Subscription %s in the namespace %s is not ready yet, retry", operatorName, namespace)
+					requestInstance.SetMemberStatus(operand.Name, operatorv1alpha1.OperatorInstalling, "", &r.Mutex)
+					requestInstance.SetMemberBlockingReason(operand.Name, fmt.Sprintf("the Subscription %s/%s has no ClusterServiceVersion installed yet, its state is %q", namespace, operatorName, sub.Status.State), &r.Mutex)
+					continue
+				}
 
-			if csv.Status.Phase == olmv1alpha1.CSVPhaseFailed {
-				merr.Add(fmt.Errorf("the ClusterServiceVersion of Subscription %s/%s is Failed", namespace, operatorName))
-				requestInstance.SetMemberStatus(operand.Name, operatorv1alpha1.OperatorFailed, "", &r.Mutex)
-				continue
-			}
-			if csv.Status.Phase != olmv1alpha1.CSVPhaseSucceeded {
-				klog.Errorf("the ClusterServiceVersion of Subscription %s/%s is not Ready", namespace, operatorName)
-				requestInstance.SetMemberStatus(operand.Name, operatorv1alpha1.OperatorInstalling, "", &r.Mutex)
-				continue
-			}
+				if csv.Status.Phase == olmv1alpha1.CSVPhaseFailed {
+					rolledBack, err := r.rollbackOperator(ctx, requestInstance, registryKey, registryInstance, opdRegistry, sub, csv)
+					if err != nil {
+						merr.Add(err)
+					} else if rolledBack {
+						requestInstance.SetMemberStatus(operand.Name, operatorv1alpha1.OperatorInstalling, "", &r.Mutex)
+						requestInstance.SetMemberBlockingReason(operand.Name, fmt.Sprintf("the ClusterServiceVersion %s is Failed, rolling back to %s", csv.Name, sub.Annotations[constant.LastSucceededCSVAnnotation]), &r.Mutex)
+						continue
+					}
+					merr.Add(fmt.Errorf("the ClusterServiceVersion of Subscription %s/%s is Failed", namespace, operatorName))
+					requestInstance.SetMemberStatus(operand.Name, operatorv1alpha1.OperatorFailed, "", &r.Mutex)
+					requestInstance.SetMemberBlockingReason(operand.Name, fmt.Sprintf("the ClusterServiceVersion %s is Failed: %s", csv.Name, csv.Status.Message), &r.Mutex)
+					continue
+				}
+				if csv.Status.Phase != olmv1alpha1.CSVPhaseSucceeded {
+					klog.Errorf("the ClusterServiceVersion of Subscription %s/%s is not Ready", namespace, operatorName)
+					requestInstance.SetMemberStatus(operand.Name, operatorv1alpha1.OperatorInstalling, "", &r.Mutex)
+					requestInstance.SetMemberBlockingReason(operand.Name, fmt.Sprintf("the ClusterServiceVersion %s is %s: %s", csv.Name, csv.Status.Phase, csv.Status.Message), &r.Mutex)
+					continue
+				}
 
-			klog.V(3).Info("Generating customresource base on ClusterServiceVersion: ", csv.GetName())
-			requestInstance.SetMemberStatus(operand.Name, operatorv1alpha1.OperatorRunning, "", &r.Mutex)
+				if err := r.recordSucceededCSV(ctx, opdRegistry, sub, csv); err != nil {
+					merr.Add(err)
+				}
+
+				klog.V(3).Info("Generating customresource base on ClusterServiceVersion: ", csv.GetName())
+				requestInstance.SetMemberStatus(operand.Name, operatorv1alpha1.OperatorRunning, "", &r.Mutex)
+				almExamples = csv.GetAnnotations()["alm-examples"]
+				sourceName = csv.GetName()
+			}
 
 			// Merge and Generate CR
 			if operand.Kind == "" {
@@ -149,10 +216,18 @@ func (r *Reconciler) reconcileOperand(ctx context.Context, requestInstance *oper
 						klog.V(2).Infof("There is no service: %s from the OperandConfig instance: %s/%s, Skip creating CR for it", operand.Name, req.RegistryNamespace, req.Registry)
 						continue
 					}
-					err = r.reconcileCRwithConfig(ctx, opdConfig, opdRegistry.Namespace, csv)
+					// On OLM v1 the custom resource templates come from the OperandConfig
+					if opdRegistry.IsClusterExtension() {
+						if almExamples, err = opdConfig.GetALMExamples(); err != nil {
+							merr.Add(errors.Wrapf(err, "failed to get the examples of service %s from the OperandConfig %s", operand.Name, registryKey.String()))
+							continue
+						}
+					}
+					err = r.reconcileCRwithConfig(ctx, requestInstance, opdConfig, opdRegistry.Namespace, almExamples, sourceName)
 					if err != nil {
 						merr.Add(err)
 						requestInstance.SetMemberStatus(operand.Name, "", operatorv1alpha1.ServiceFailed, &r.Mutex)
+						requestInstance.SetMemberBlockingReason(operand.Name, err.Error(), &r.Mutex)
 					}
 				} else if apierrors.IsNotFound(err) {
 					klog.Infof("Not Found OperandConfig: %s/%s", operand.Name, err)
@@ -162,10 +237,11 @@ func (r *Reconciler) reconcileOperand(ctx context.Context, requestInstance *oper
 				}
 
 			} else {
-				err = r.reconcileCRwithRequest(ctx, requestInstance, operand, types.NamespacedName{Name: requestInstance.Name, Namespace: requestInstance.Namespace}, i)
+				err = r.reconcileCRwithRequest(ctx, requestInstance, operand, types.NamespacedName{Name: requestInstance.Name, Namespace: requestInstance.Namespace}, i, registryKey, policy)
 				if err != nil {
 					merr.Add(err)
 					requestInstance.SetMemberStatus(operand.Name, "", operatorv1alpha1.ServiceFailed, &r.Mutex)
+					requestInstance.SetMemberBlockingReason(operand.Name, err.Error(), &r.Mutex)
 				}
 			}
 			requestInstance.SetMemberStatus(operand.Name, "", operatorv1alpha1.ServiceRunning, &r.Mutex)
@@ -179,7 +255,7 @@ func (r *Reconciler) reconcileOperand(ctx context.Context, requestInstance *oper
 }
 
 // reconcileCRwithConfig merge and create custom resource base on OperandConfig and CSV alm-examples
-func (r *Reconciler) reconcileCRwithConfig(ctx context.Context, service *operatorv1alpha1.ConfigService, namespace string, csv *olmv1alpha1.ClusterServiceVersion) error {
+func (r *Reconciler) reconcileCRwithConfig(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, service *operatorv1alpha1.ConfigService, namespace, almExamples, sourceName string) error {
 	merr := &util.MultiErr{}
 
 	// Create k8s resources required by service
@@ -223,7 +299,7 @@ func (r *Reconciler) reconcileCRwithConfig(ctx context.Context, service *operato
 				if r.CheckLabel(k8sRes, map[string]string{constant.OpreqLabel: "true"}) && res.Force {
 					// Update k8s resource
 					klog.V(3).Info("Found existing k8s resource: " + res.Name)
-					if err := r.updateK8sResource(ctx, k8sRes, res.Data, res.Labels, res.Annotations); err != nil {
+					if err := r.updateK8sResource(ctx, requestInstance, service.Name, k8sRes, res.Data, res.Labels, res.Annotations); err != nil {
 						merr.Add(err)
 					}
 				} else {
@@ -237,7 +313,10 @@ func (r *Reconciler) reconcileCRwithConfig(ctx context.Context, service *operato
 		}
 	}
 
-	almExamples := csv.GetAnnotations()["alm-examples"]
+	if almExamples == "" {
+		klog.Warningf("Notfound custom resource templates for the service %s from %s", service.Name, sourceName)
+		return nil
+	}
 
 	// Convert CR template string to slice
 	var almExampleList []interface{}
@@ -286,7 +365,7 @@ func (r *Reconciler) reconcileCRwithConfig(ctx context.Context, service *operato
 		} else {
 			if r.CheckLabel(crFromALM, map[string]string{constant.OpreqLabel: "true"}) {
 				// Update or Delete Custom Resource
-				if err := r.existingCustomResource(ctx, crFromALM, spec.(map[string]interface{}), service, namespace); err != nil {
+				if err := r.existingCustomResource(ctx, requestInstance, crFromALM, spec.(map[string]interface{}), service, namespace); err != nil {
 					merr.Add(err)
 					continue
 				}
@@ -301,7 +380,7 @@ func (r *Reconciler) reconcileCRwithConfig(ctx context.Context, service *operato
 
 	for cr, found := range foundMap {
 		if !found {
-			klog.Warningf("Custom resource %v doesn't exist in the alm-example of %v", cr, csv.GetName())
+			klog.Warningf("Custom resource %v doesn't exist in the alm-example of %v", cr, sourceName)
 		}
 	}
 
@@ -309,7 +388,9 @@ func (r *Reconciler) reconcileCRwithConfig(ctx context.Context, service *operato
 }
 
 // reconcileCRwithRequest merge and create custom resource base on OperandRequest and CSV alm-examples
-func (r *Reconciler) reconcileCRwithRequest(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, operand operatorv1alpha1.Operand, requestKey types.NamespacedName, index int) error {
+// The OperandPolicies of the namespace must allow the kind of the custom resource.
+func (r *Reconciler) reconcileCRwithRequest(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, operand operatorv1alpha1.Operand, requestKey types.NamespacedName, index int,
+	registryKey types.NamespacedName, policy *deploy.RequestPolicy) error {
 	merr := &util.MultiErr{}
 
 	// Create an unstructured object for CR and check its value
@@ -323,6 +404,13 @@ func (r *Reconciler) reconcileCRwithRequest(ctx context.Context, requestInstance
 		return fmt.Errorf("The Kind of operand is empty for operator " + operand.Name)
 	}
 
+	if reason := policy.CheckKind(registryKey, operand.Name, operand.APIVersion, operand.Kind); reason != "" {
+		klog.Warningf("Skip creating the custom resource %s of operator %s: %s", operand.Kind, operand.Name, reason)
+		requestInstance.SetNotAllowedCondition(operand.Name+"/"+operand.Kind, reason, operatorv1alpha1.ResourceTypeOperand, corev1.ConditionTrue, &r.Mutex)
+		return nil
+	}
+	requestInstance.SetNotAllowedCondition(operand.Name+"/"+operand.Kind, "", operatorv1alpha1.ResourceTypeOperand, corev1.ConditionFalse, &r.Mutex)
+
 	var name string
 	if operand.InstanceName == "" {
 		crInfo := sha256.Sum256([]byte(operand.APIVersion + operand.Kind + strconv.Itoa(index)))
@@ -331,29 +419,42 @@ func (r *Reconciler) reconcileCRwithRequest(ctx context.Context, requestInstance
 		name = operand.InstanceName
 	}
 
+	// The custom resources of a ClusterOperandRequest can be created in another namespace
+	namespace, crNamespace := requestKey.Namespace, ""
+	if operand.InstanceNamespace != "" && operand.InstanceNamespace != requestKey.Namespace {
+		generated, err := r.IsGeneratedFromClusterRequest(ctx, requestInstance)
+		if err != nil {
+			return err
+		}
+		if !generated {
+			return fmt.Errorf("The instanceNamespace of operand %s is only allowed in a ClusterOperandRequest", operand.Name)
+		}
+		namespace, crNamespace = operand.InstanceNamespace, operand.InstanceNamespace
+	}
+
 	crFromRequest.SetName(name)
-	crFromRequest.SetNamespace(requestKey.Namespace)
+	crFromRequest.SetNamespace(namespace)
 	crFromRequest.SetAPIVersion(operand.APIVersion)
 	crFromRequest.SetKind(operand.Kind)
 
 	err := r.Client.Get(ctx, types.NamespacedName{
 		Name:      name,
-		Namespace: requestKey.Namespace,
+		Namespace: namespace,
 	}, &crFromRequest)
 
 	if err != nil && !apierrors.IsNotFound(err) {
-		merr.Add(errors.Wrapf(err, "failed to get custom resource %s/%s", requestKey.Namespace, name))
+		merr.Add(errors.Wrapf(err, "failed to get custom resource %s/%s", namespace, name))
 	} else if apierrors.IsNotFound(err) {
 		// Create Custom resource
-		if err := r.createCustomResource(ctx, crFromRequest, requestKey.Namespace, operand.Kind, operand.Spec.Raw); err != nil {
+		if err := r.createCustomResource(ctx, crFromRequest, namespace, operand.Kind, operand.Spec.Raw); err != nil {
 			merr.Add(err)
 		}
-		requestInstance.SetMemberCRStatus(operand.Name, name, operand.Kind, operand.APIVersion, &r.Mutex)
+		requestInstance.SetMemberCRStatus(operand.Name, name, operand.Kind, operand.APIVersion, crNamespace, &r.Mutex)
 	} else {
 		if r.CheckLabel(crFromRequest, map[string]string{constant.OpreqLabel: "true"}) {
 			// Update or Delete Custom resource
 			klog.V(3).Info("Found existing custom resource: " + operand.Kind)
-			if err := r.updateCustomResource(ctx, crFromRequest, requestKey.Namespace, operand.Kind, operand.Spec.Raw, map[string]interface{}{}); err != nil {
+			if err := r.updateCustomResource(ctx, crFromRequest, namespace, operand.Kind, operand.Spec.Raw, map[string]interface{}{}); err != nil {
 				return err
 			}
 		} else {
@@ -368,7 +469,9 @@ func (r *Reconciler) reconcileCRwithRequest(ctx context.Context, requestInstance
 }
 
 // deleteAllCustomResource remove custom resource base on OperandConfig and CSV alm-examples
-func (r *Reconciler) deleteAllCustomResource(ctx context.Context, csv *olmv1alpha1.ClusterServiceVersion, requestInstance *operatorv1alpha1.OperandRequest, csc *operatorv1alpha1.OperandConfig, operandName, namespace string) error {
+// The custom resources are kept and no longer managed by ODLM when the deletion policy is Orphan.
+// It returns true once all the custom resources are gone.
+func (r *Reconciler) deleteAllCustomResource(ctx context.Context, almExamples string, requestInstance *operatorv1alpha1.OperandRequest, csc *operatorv1alpha1.OperandConfig, operandName, namespace string, policy operatorv1alpha1.DeletionPolicy, timeout time.Duration) (bool, error) {
 
 	customeResourceMap := make(map[string]operatorv1alpha1.OperandCRMember)
 	for _, member := range requestInstance.Status.Members {
@@ -382,6 +485,7 @@ func (r *Reconciler) deleteAllCustomResource(ctx context.Context, csv *olmv1alph
 	}
 
 	merr := &util.MultiErr{}
+	deleted := true
 	var (
 		wg sync.WaitGroup
 	)
@@ -404,26 +508,32 @@ func (r *Reconciler) deleteAllCustomResource(ctx context.Context, csv *olmv1alph
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			if err := r.deleteCustomResource(ctx, crShouldBeDeleted, requestInstance.Namespace); err != nil {
+			done, err := r.removeResource(ctx, requestInstance, operandName, crShouldBeDeleted, memberCRNamespace(requestInstance, opdMember), policy, timeout, r.deleteCustomResource)
+			if err != nil {
 				r.Mutex.Lock()
 				defer r.Mutex.Unlock()
 				merr.Add(err)
 				return
 			}
+			if !done {
+				r.Mutex.Lock()
+				defer r.Mutex.Unlock()
+				deleted = false
+				return
+			}
 			requestInstance.RemoveMemberCRStatus(operatorName, opdMember.Name, opdMember.Kind, &r.Mutex)
 		}()
 	}
 	wg.Wait()
 
 	if len(merr.Errors) != 0 {
-		return merr
+		return false, merr
 	}
 
 	service := csc.GetService(operandName)
-	if service == nil {
-		return nil
+	if service == nil || almExamples == "" {
+		return deleted, nil
 	}
-	almExamples := csv.GetAnnotations()["alm-examples"]
 	klog.V(2).Info("Delete all the custom resource from Subscription ", service.Name)
 
 	// Create a slice for crTemplates
@@ -432,7 +542,7 @@ func (r *Reconciler) deleteAllCustomResource(ctx context.Context, csv *olmv1alph
 	// Convert CR template string to slice
 	err := json.Unmarshal([]byte(almExamples), &almExamplesRaw)
 	if err != nil {
-		return errors.Wrapf(err, "failed to convert alm-examples in the Subscription %s to slice", service.Name)
+		return false, errors.Wrapf(err, "failed to convert alm-examples in the Subscription %s to slice", service.Name)
 	}
 
 	// Merge OperandConfig and ClusterServiceVersion alm-examples
@@ -466,10 +576,13 @@ func (r *Reconciler) deleteAllCustomResource(ctx context.Context, csv *olmv1alph
 					wg.Add(1)
 					go func() {
 						defer wg.Done()
-						if err := r.deleteCustomResource(ctx, crTemplate, namespace); err != nil {
-							r.Mutex.Lock()
-							defer r.Mutex.Unlock()
+						done, err := r.removeResource(ctx, requestInstance, operandName, crTemplate, namespace, policy, timeout, r.deleteCustomResource)
+						r.Mutex.Lock()
+						defer r.Mutex.Unlock()
+						if err != nil {
 							merr.Add(err)
+						} else if !done {
+							deleted = false
 						}
 					}()
 				}
@@ -480,10 +593,10 @@ func (r *Reconciler) deleteAllCustomResource(ctx context.Context, csv *olmv1alph
 	}
 	wg.Wait()
 	if len(merr.Errors) != 0 {
-		return merr
+		return false, merr
 	}
 
-	return nil
+	return deleted, nil
 }
 
 func (r *Reconciler) compareConfigandExample(ctx context.Context, crTemplate unstructured.Unstructured, service *operatorv1alpha1.ConfigService, namespace string) error {
@@ -526,7 +639,7 @@ func (r *Reconciler) createCustomResource(ctx context.Context, crTemplate unstru
 	return nil
 }
 
-func (r *Reconciler) existingCustomResource(ctx context.Context, existingCR unstructured.Unstructured, specFromALM map[string]interface{}, service *operatorv1alpha1.ConfigService, namespace string) error {
+func (r *Reconciler) existingCustomResource(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, existingCR unstructured.Unstructured, specFromALM map[string]interface{}, service *operatorv1alpha1.ConfigService, namespace string) error {
 	kind := existingCR.GetKind()
 
 	var found bool
@@ -542,8 +655,7 @@ func (r *Reconciler) existingCustomResource(ctx context.Context, existingCR unst
 		}
 	}
 	if !found {
-		err := r.deleteCustomResource(ctx, existingCR, namespace)
-		if err != nil {
+		if _, err := r.deleteCustomResource(ctx, requestInstance, service.Name, existingCR, namespace, r.memberTimeouts(requestInstance, service.Name).CRDeleteTimeout.Duration); err != nil {
 			return err
 		}
 	}
@@ -649,7 +761,9 @@ func (r *Reconciler) updateCustomResource(ctx context.Context, existingCR unstru
 	return nil
 }
 
-func (r *Reconciler) deleteCustomResource(ctx context.Context, existingCR unstructured.Unstructured, namespace string) error {
+// deleteCustomResource starts the deletion of the custom resource labeled by ODLM.
+// It returns true once the custom resource is gone, and the progress is recorded in the OperandRequest status.
+func (r *Reconciler) deleteCustomResource(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, operand string, existingCR unstructured.Unstructured, namespace string, timeout time.Duration) (bool, error) {
 
 	kind := existingCR.GetKind()
 	apiversion := existingCR.GetAPIVersion()
@@ -666,41 +780,36 @@ func (r *Reconciler) deleteCustomResource(ctx context.Context, existingCR unstru
 		Namespace: namespace,
 	}, &crShouldBeDeleted)
 	if err != nil && !apierrors.IsNotFound(err) {
-		return errors.Wrapf(err, "failed to get custom resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
+		return false, errors.Wrapf(err, "failed to get custom resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
 	}
 	if apierrors.IsNotFound(err) {
 		klog.V(3).Infof("There is no custom resource: %s from custom resource definition: %s", name, kind)
-	} else {
-		if r.CheckLabel(crShouldBeDeleted, map[string]string{constant.OpreqLabel: "true"}) && !r.CheckLabel(crShouldBeDeleted, map[string]string{constant.NotUninstallLabel: "true"}) {
-			klog.V(3).Infof("Deleting custom resource: %s from custom resource definition: %s", name, kind)
-			err := r.Delete(ctx, &crShouldBeDeleted)
-			if err != nil && !apierrors.IsNotFound(err) {
-				return errors.Wrapf(err, "failed to delete custom resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
-			}
-			err = wait.PollImmediate(constant.DefaultCRDeletePeriod, constant.DefaultCRDeleteTimeout, func() (bool, error) {
-				if strings.EqualFold(kind, "OperandRequest") {
-					return true, nil
-				}
-				klog.V(3).Infof("Waiting for CR %s is removed ...", kind)
-				err := r.Client.Get(ctx, types.NamespacedName{
-					Name:      name,
-					Namespace: namespace,
-				}, &existingCR)
-				if apierrors.IsNotFound(err) {
-					return true, nil
-				}
-				if err != nil {
-					return false, errors.Wrapf(err, "failed to get custom resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
-				}
-				return false, nil
-			})
-			if err != nil {
-				return errors.Wrapf(err, "failed to delete custom resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
-			}
-			klog.V(1).Infof("Finish deleting custom resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
-		}
+		return true, nil
 	}
-	return nil
+	if !r.CheckLabel(crShouldBeDeleted, map[string]string{constant.OpreqLabel: "true"}) || r.CheckLabel(crShouldBeDeleted, map[string]string{constant.NotUninstallLabel: "true"}) {
+		return true, nil
+	}
+
+	klog.V(3).Infof("Deleting custom resource: %s from custom resource definition: %s", name, kind)
+	done, err := r.deleteResource(ctx, requestInstance, operand, &crShouldBeDeleted, timeout)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to delete custom resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
+	}
+	// The OperandRequest is removed by its own finalizer, don't wait for it
+	if done || strings.EqualFold(kind, "OperandRequest") {
+		klog.V(1).Infof("Finish deleting custom resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
+		return true, nil
+	}
+	klog.V(3).Infof("Waiting for CR %s is removed ...", kind)
+	return false, nil
+}
+
+// memberCRNamespace returns the namespace of a custom resource created by the OperandRequest
+func memberCRNamespace(requestInstance *operatorv1alpha1.OperandRequest, cr operatorv1alpha1.OperandCRMember) string {
+	if cr.Namespace != "" {
+		return cr.Namespace
+	}
+	return requestInstance.Namespace
 }
 
 func (r *Reconciler) checkCustomResource(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest) error {
@@ -749,16 +858,21 @@ func (r *Reconciler) checkCustomResource(ctx context.Context, requestInstance *o
 		var (
 			operatorName = strings.Split(index, "/")[0]
 			opdMember    = opdMember
+			timeout      = r.memberTimeouts(requestInstance, operatorName).CRDeleteTimeout.Duration
 		)
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			if err := r.deleteCustomResource(ctx, crShouldBeDeleted, requestInstance.Namespace); err != nil {
+			deleted, err := r.deleteCustomResource(ctx, requestInstance, operatorName, crShouldBeDeleted, memberCRNamespace(requestInstance, opdMember), timeout)
+			if err != nil {
 				r.Mutex.Lock()
 				defer r.Mutex.Unlock()
 				merr.Add(err)
 				return
 			}
+			if !deleted {
+				return
+			}
 			requestInstance.RemoveMemberCRStatus(operatorName, opdMember.Name, opdMember.Kind, &r.Mutex)
 		}()
 	}
@@ -803,7 +917,7 @@ func (r *Reconciler) createK8sResource(ctx context.Context, k8sResTemplate unstr
 	return nil
 }
 
-func (r *Reconciler) updateK8sResource(ctx context.Context, existingK8sRes unstructured.Unstructured, k8sResConfig *runtime.RawExtension, newLabels, newAnnotations map[string]string) error {
+func (r *Reconciler) updateK8sResource(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, operand string, existingK8sRes unstructured.Unstructured, k8sResConfig *runtime.RawExtension, newLabels, newAnnotations map[string]string) error {
 	kind := existingK8sRes.GetKind()
 	apiversion := existingK8sRes.GetAPIVersion()
 	name := existingK8sRes.GetName()
@@ -852,9 +966,15 @@ func (r *Reconciler) updateK8sResource(ctx context.Context, existingK8sRes unstr
 			}
 			newAnnotations[constant.HashedData] = newHashedData
 
-			if err := r.deleteK8sResource(ctx, existingK8sRes, namespace); err != nil {
+			deleted, err := r.deleteK8sResource(ctx, requestInstance, operand, existingK8sRes, namespace, r.memberTimeouts(requestInstance, operand).CRDeleteTimeout.Duration)
+			if err != nil {
 				return errors.Wrap(err, "failed to update k8s resource")
 			}
+			// The k8s resource is recreated once the old one is removed
+			if !deleted {
+				klog.V(2).Infof("Waiting for k8s resource %s %s/%s being removed before recreating it ...", kind, namespace, name)
+				return nil
+			}
 			if err := r.createK8sResource(ctx, templatek8sRes, k8sResConfig, newLabels, newAnnotations); err != nil {
 				return errors.Wrap(err, "failed to update k8s resource")
 			}
@@ -948,7 +1068,9 @@ func (r *Reconciler) updateK8sResource(ctx context.Context, existingK8sRes unstr
 	return nil
 }
 
-func (r *Reconciler) deleteK8sResource(ctx context.Context, existingK8sRes unstructured.Unstructured, namespace string) error {
+// deleteK8sResource starts the deletion of the k8s resource labeled by ODLM.
+// It returns true once the k8s resource is gone, and the progress is recorded in the OperandRequest status.
+func (r *Reconciler) deleteK8sResource(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, operand string, existingK8sRes unstructured.Unstructured, namespace string, timeout time.Duration) (bool, error) {
 
 	kind := existingK8sRes.GetKind()
 	apiversion := existingK8sRes.GetAPIVersion()
@@ -965,52 +1087,44 @@ func (r *Reconciler) deleteK8sResource(ctx context.Context, existingK8sRes unstr
 		Namespace: namespace,
 	}, &k8sResShouldBeDeleted)
 	if err != nil && !apierrors.IsNotFound(err) {
-		return errors.Wrapf(err, "failed to get k8s resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
+		return false, errors.Wrapf(err, "failed to get k8s resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
 	}
 	if apierrors.IsNotFound(err) {
 		klog.V(3).Infof("There is no k8s resource: %s from kind: %s", name, kind)
-	} else {
-		if r.CheckLabel(k8sResShouldBeDeleted, map[string]string{constant.OpreqLabel: "true"}) && !r.CheckLabel(k8sResShouldBeDeleted, map[string]string{constant.NotUninstallLabel: "true"}) {
-			klog.V(3).Infof("Deleting k8s resource: %s from kind: %s", name, kind)
-			err := r.Delete(ctx, &k8sResShouldBeDeleted)
-			if err != nil && !apierrors.IsNotFound(err) {
-				return errors.Wrapf(err, "failed to delete k8s resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
-			}
-			err = wait.PollImmediate(constant.DefaultCRDeletePeriod, constant.DefaultCRDeleteTimeout, func() (bool, error) {
-				klog.V(3).Infof("Waiting for k8s resource %s is removed ...", kind)
-				err := r.Client.Get(ctx, types.NamespacedName{
-					Name:      name,
-					Namespace: namespace,
-				}, &existingK8sRes)
-				if apierrors.IsNotFound(err) {
-					return true, nil
-				}
-				if err != nil {
-					return false, errors.Wrapf(err, "failed to get k8s resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
-				}
-				return false, nil
-			})
-			if err != nil {
-				return errors.Wrapf(err, "failed to delete k8s resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
-			}
-			klog.V(1).Infof("Finish deleting k8s resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
-		}
+		return true, nil
 	}
-	return nil
+	if !r.CheckLabel(k8sResShouldBeDeleted, map[string]string{constant.OpreqLabel: "true"}) || r.CheckLabel(k8sResShouldBeDeleted, map[string]string{constant.NotUninstallLabel: "true"}) {
+		return true, nil
+	}
+
+	klog.V(3).Infof("Deleting k8s resource: %s from kind: %s", name, kind)
+	done, err := r.deleteResource(ctx, requestInstance, operand, &k8sResShouldBeDeleted, timeout)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to delete k8s resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
+	}
+	if done {
+		klog.V(1).Infof("Finish deleting k8s resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
+		return true, nil
+	}
+	klog.V(3).Infof("Waiting for k8s resource %s is removed ...", kind)
+	return false, nil
 }
 
 // deleteAllK8sResource remove k8s resource base on OperandConfig
-func (r *Reconciler) deleteAllK8sResource(ctx context.Context, csc *operatorv1alpha1.OperandConfig, operandName, namespace string) error {
+// The k8s resources are kept and no longer managed by ODLM when the deletion policy is Orphan.
+// It returns true once all the k8s resources are gone.
+func (r *Reconciler) deleteAllK8sResource(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, csc *operatorv1alpha1.OperandConfig, operandName, namespace string, policy operatorv1alpha1.DeletionPolicy, timeout time.Duration) (bool, error) {
 
 	service := csc.GetService(operandName)
 	if service == nil {
-		return nil
+		return true, nil
 	}
 
 	var k8sResourceList []operatorv1alpha1.ConfigResource
 	k8sResourceList = append(k8sResourceList, service.Resources...)
 
 	merr := &util.MultiErr{}
+	deleted := true
 	var (
 		wg sync.WaitGroup
 	)
@@ -1032,18 +1146,67 @@ func (r *Reconciler) deleteAllK8sResource(ctx context.Context, csc *operatorv1al
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			if err := r.deleteK8sResource(ctx, k8sResShouldBeDeleted, k8sNamespace); err != nil {
-				r.Mutex.Lock()
-				defer r.Mutex.Unlock()
+			done, err := r.removeResource(ctx, requestInstance, operandName, k8sResShouldBeDeleted, k8sNamespace, policy, timeout, r.deleteK8sResource)
+			r.Mutex.Lock()
+			defer r.Mutex.Unlock()
+			if err != nil {
 				merr.Add(err)
-				return
+			} else if !done {
+				deleted = false
 			}
 		}()
 	}
 	wg.Wait()
 
 	if len(merr.Errors) != 0 {
-		return merr
+		return false, merr
+	}
+	return deleted, nil
+}
+
+// removeResource deletes the resource with the delete function, or orphans it when the deletion policy is Orphan.
+// It returns true once the resource is deleted or orphaned.
+func (r *Reconciler) removeResource(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, operand string, res unstructured.Unstructured, namespace string, policy operatorv1alpha1.DeletionPolicy,
+	timeout time.Duration, deleteFunc func(context.Context, *operatorv1alpha1.OperandRequest, string, unstructured.Unstructured, string, time.Duration) (bool, error)) (bool, error) {
+	if policy == operatorv1alpha1.DeletionPolicyOrphan {
+		return true, r.orphanResource(ctx, res, namespace)
+	}
+	return deleteFunc(ctx, requestInstance, operand, res, namespace, timeout)
+}
+
+// orphanResource removes the ODLM label and owner annotations from the resource, so that ODLM no longer manages it
+func (r *Reconciler) orphanResource(ctx context.Context, existingRes unstructured.Unstructured, namespace string) error {
+	kind := existingRes.GetKind()
+	name := existingRes.GetName()
+
+	resShouldBeOrphaned := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": existingRes.GetAPIVersion(),
+			"kind":       kind,
+		},
+	}
+	err := r.Client.Get(ctx, types.NamespacedName{
+		Name:      name,
+		Namespace: namespace,
+	}, &resShouldBeOrphaned)
+	if apierrors.IsNotFound(err) {
+		klog.V(3).Infof("There is no resource: %s from kind: %s", name, kind)
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to get resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
+	}
+	if !r.CheckLabel(resShouldBeOrphaned, map[string]string{constant.OpreqLabel: "true"}) {
+		return nil
+	}
+
+	originalRes := resShouldBeOrphaned.DeepCopy()
+	labels := resShouldBeOrphaned.GetLabels()
+	delete(labels, constant.OpreqLabel)
+	resShouldBeOrphaned.SetLabels(labels)
+	setOwnerAnnotations(&resShouldBeOrphaned, nil)
+	klog.V(2).Infof("Orphaning resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
+	if err := r.Patch(ctx, &resShouldBeOrphaned, client.MergeFrom(originalRes)); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to orphan resource -- Kind: %s, NamespacedName: %s/%s", kind, namespace, name)
 	}
 	return nil
 }
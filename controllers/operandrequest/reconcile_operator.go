@@ -21,7 +21,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
-	"strings"
 	"sync"
 	"time"
 
@@ -41,10 +40,11 @@ import (
 
 	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
 	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
 	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/util"
 )
 
-func (r *Reconciler) reconcileOperator(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest) error {
+func (r *Reconciler) reconcileOperator(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, policy *deploy.RequestPolicy) error {
 	klog.V(1).Infof("Reconciling Operators for OperandRequest: %s/%s", requestInstance.GetNamespace(), requestInstance.GetName())
 
 	// Update request status
@@ -55,7 +55,7 @@ func (r *Reconciler) reconcileOperator(ctx context.Context, requestInstance *ope
 
 	for _, req := range requestInstance.Spec.Requests {
 		registryKey := requestInstance.GetRegistryKey(req)
-		registryInstance, err := r.GetOperandRegistry(ctx, registryKey)
+		registries, err := r.GetOperandRegistriesForRequest(ctx, requestInstance, req)
 		if err != nil {
 			if apierrors.IsNotFound(err) {
 				r.Recorder.Eventf(requestInstance, corev1.EventTypeWarning, "NotFound", "NotFound OperandRegistry NamespacedName %s", registryKey.String())
@@ -80,6 +80,9 @@ func (r *Reconciler) reconcileOperator(ctx context.Context, requestInstance *ope
 			}
 			return err
 		}
+		for _, registry := range registries {
+			requestInstance.SetRegistryRevision(registry.Key, registry.Revision, &r.Mutex)
+		}
 		merr := &util.MultiErr{}
 
 		// Get the chunk size
@@ -100,15 +103,17 @@ func (r *Reconciler) reconcileOperator(ctx context.Context, requestInstance *ope
 				wg sync.WaitGroup
 			)
 			for _, operand := range req.Operands[i:j] {
+				// Each operand is resolved from the first OperandRegistry offering it
+				registry := deploy.SelectOperandRegistry(registries, requestInstance.Namespace, operand.Name)
 				wg.Add(1)
 				go func(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, registryInstance *operatorv1alpha1.OperandRegistry, operand operatorv1alpha1.Operand, registryKey types.NamespacedName, mu *sync.Mutex) {
 					defer wg.Done()
-					if err := r.reconcileSubscription(ctx, requestInstance, registryInstance, operand, registryKey, mu); err != nil {
+					if err := r.reconcileSubscription(ctx, requestInstance, registryInstance, operand, registryKey, policy, mu); err != nil {
 						mu.Lock()
 						defer mu.Unlock()
 						merr.Add(err)
 					}
-				}(ctx, requestInstance, registryInstance, operand, registryKey, &r.Mutex)
+				}(ctx, requestInstance, registry.Registry, operand, registry.Key, &r.Mutex)
 			}
 			wg.Wait()
 		}
@@ -134,7 +139,7 @@ func (r *Reconciler) reconcileOperator(ctx context.Context, requestInstance *ope
 	return nil
 }
 
-func (r *Reconciler) reconcileSubscription(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, registryInstance *operatorv1alpha1.OperandRegistry, operand operatorv1alpha1.Operand, registryKey types.NamespacedName, mu sync.Locker) error {
+func (r *Reconciler) reconcileSubscription(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, registryInstance *operatorv1alpha1.OperandRegistry, operand operatorv1alpha1.Operand, registryKey types.NamespacedName, policy *deploy.RequestPolicy, mu sync.Locker) error {
 	// Check the requested Operand if exist in specific OperandRegistry
 	opt := registryInstance.GetOperator(operand.Name)
 	if opt == nil {
@@ -147,6 +152,19 @@ func (r *Reconciler) reconcileSubscription(ctx context.Context, requestInstance
 		requestInstance.SetOutofScopeCondition(operand.Name, operatorv1alpha1.ResourceTypeSub, corev1.ConditionTrue, mu)
 		return nil
 	}
+	if reason := policy.CheckOperator(registryKey, operand.Name); reason != "" {
+		klog.Warningf("Operator %s can't be requested from namespace %s: %s", operand.Name, requestInstance.Namespace, reason)
+		requestInstance.SetNotAllowedCondition(operand.Name, reason, operatorv1alpha1.ResourceTypeOperator, corev1.ConditionTrue, mu)
+		return nil
+	}
+	requestInstance.SetNotAllowedCondition(operand.Name, "", operatorv1alpha1.ResourceTypeOperator, corev1.ConditionFalse, mu)
+
+	if opt.IsClusterExtension() {
+		return r.reconcileClusterExtension(ctx, requestInstance, opt, registryKey, mu)
+	}
+
+	// Pin the operator to the ClusterServiceVersion it is rolled back to
+	opt, liftRollback := pinRollback(registryInstance, opt)
 
 	// Check subscription if exist
 	namespace := r.GetOperatorNamespace(opt.InstallMode, opt.Namespace)
@@ -160,7 +178,7 @@ func (r *Reconciler) reconcileSubscription(ctx context.Context, requestInstance
 				return err
 			}
 			requestInstance.SetMemberStatus(opt.Name, operatorv1alpha1.OperatorInstalling, "", mu)
-			return nil
+			return r.liftRollback(ctx, registryInstance, registryKey, opt.Name, liftRollback)
 		}
 		return err
 	}
@@ -168,21 +186,36 @@ func (r *Reconciler) reconcileSubscription(ctx context.Context, requestInstance
 	// Subscription existing and managed by OperandRequest controller
 	if _, ok := sub.Labels[constant.OpreqLabel]; ok {
 		originalSub := sub.DeepCopy()
-		sub.Spec.CatalogSource = opt.SourceName
-		sub.Spec.Channel = opt.Channel
-		sub.Spec.CatalogSourceNamespace = opt.SourceNamespace
-		sub.Spec.Package = opt.PackageName
-		if opt.InstallPlanApproval != "" && sub.Spec.InstallPlanApproval != opt.InstallPlanApproval {
-			sub.Spec.InstallPlanApproval = opt.InstallPlanApproval
+		// record the OperandRequest in the OperandInventory and generate the annotations from the owners
+		owner := operatorv1alpha1.NewInventoryOwner(types.NamespacedName{Namespace: requestInstance.Namespace, Name: requestInstance.Name}, registryKey, operand.Name)
+		ownerAnnotations, err := r.acquireInventory(ctx, operatorv1alpha1.InventoryKindSubscription, sub, types.NamespacedName{Namespace: sub.Namespace, Name: sub.Name}, owner)
+		if err != nil {
+			return err
+		}
+		setOwnerAnnotations(sub, ownerAnnotations)
+		// Only the OperandRegistry chosen by the conflict policy updates the Subscription spec
+		managed, managingRegistry, err := r.checkManagingRegistry(ctx, requestInstance, operand.Name, types.NamespacedName{Namespace: sub.Namespace, Name: sub.Name}, sub.Annotations, registryKey)
+		if err != nil {
+			return err
+		}
+		held, err := r.isRolloutHeld(ctx, registryInstance, registryKey, types.NamespacedName{Namespace: sub.Namespace, Name: sub.Name})
+		if err != nil {
+			return err
 		}
-		sub.Spec.Config = opt.SubscriptionConfig
-		// add annotations to existing Subscriptions for upgrade case
-		if sub.Annotations == nil {
-			sub.Annotations = make(map[string]string)
+		if !managed {
+			klog.V(2).Infof("Subscription %s in the namespace %s is currently managed by %s, skip updating its spec", sub.Name, sub.Namespace, managingRegistry)
+		} else if held {
+			klog.V(2).Infof("Subscription %s in the namespace %s is shared with OperandRequests the rollout of %s hasn't promoted yet, skip updating its spec", sub.Name, sub.Namespace, registryKey.String())
+		} else {
+			sub.Spec.CatalogSource = opt.SourceName
+			sub.Spec.Channel = opt.Channel
+			sub.Spec.CatalogSourceNamespace = opt.SourceNamespace
+			sub.Spec.Package = opt.PackageName
+			if opt.InstallPlanApproval != "" && sub.Spec.InstallPlanApproval != opt.InstallPlanApproval {
+				sub.Spec.InstallPlanApproval = opt.InstallPlanApproval
+			}
+			sub.Spec.Config = opt.SubscriptionConfig
 		}
-		sub.Annotations[registryKey.Namespace+"."+registryKey.Name+"/registry"] = "true"
-		sub.Annotations[registryKey.Namespace+"."+registryKey.Name+"/config"] = "true"
-		sub.Annotations[requestInstance.Namespace+"."+requestInstance.Name+"/request"] = "true"
 		if compareSub(sub, originalSub) {
 			if err = r.updateSubscription(ctx, requestInstance, sub); err != nil {
 				requestInstance.SetMemberStatus(opt.Name, operatorv1alpha1.OperatorFailed, "", mu)
@@ -194,7 +227,7 @@ func (r *Reconciler) reconcileSubscription(ctx context.Context, requestInstance
 		// Subscription existing and not managed by OperandRequest controller
 		klog.V(1).Infof("Subscription %s in namespace %s isn't created by ODLM. Ignore update/delete it.", sub.Name, sub.Namespace)
 	}
-	return nil
+	return r.liftRollback(ctx, registryInstance, registryKey, opt.Name, liftRollback)
 }
 
 func (r *Reconciler) createSubscription(ctx context.Context, cr *operatorv1alpha1.OperandRequest, opt *operatorv1alpha1.Operator, key types.NamespacedName) error {
@@ -233,9 +266,27 @@ func (r *Reconciler) createSubscription(ctx context.Context, cr *operatorv1alpha
 	// Create subscription
 	klog.V(2).Info("Creating the Subscription: " + opt.Name)
 	if co.subscription.Spec.CatalogSource == "" || co.subscription.Spec.CatalogSourceNamespace == "" {
+		// Report why the package or the channel can't be resolved
+		if condType, reason, err := r.ValidatePackageChannel(ctx, opt); err == nil && condType != "" {
+			return fmt.Errorf("failed to find catalogsource for subscription %s/%s: %s", co.subscription.Namespace, co.subscription.Name, reason)
+		}
 		return fmt.Errorf("failed to find catalogsource for subscription %s/%s", co.subscription.Namespace, co.subscription.Name)
 	}
 
+	// Hold the Subscription creation until the CatalogSource is able to serve the package
+	csName := co.subscription.Spec.CatalogSourceNamespace + "/" + co.subscription.Spec.CatalogSource
+	healthy, state, err := r.CheckCatalogSourceHealth(ctx, co.subscription.Spec.CatalogSource, co.subscription.Spec.CatalogSourceNamespace)
+	if err != nil {
+		return err
+	}
+	if !healthy {
+		klog.Warningf("CatalogSource %s is %s, waiting for it being ready before creating Subscription %s/%s", csName, state, co.subscription.Namespace, co.subscription.Name)
+		cr.SetWaitingCondition(csName, state, operatorv1alpha1.ResourceTypeCatalogSource, corev1.ConditionTrue, &r.Mutex)
+		cr.SetMemberBlockingReason(opt.Name, fmt.Sprintf("the CatalogSource %s is %s", csName, state), &r.Mutex)
+		return nil
+	}
+	cr.SetWaitingCondition(csName, state, operatorv1alpha1.ResourceTypeCatalogSource, corev1.ConditionFalse, &r.Mutex)
+
 	sub := co.subscription
 	cr.SetCreatingCondition(sub.Name, operatorv1alpha1.ResourceTypeSub, corev1.ConditionTrue, &r.Mutex)
 
@@ -243,6 +294,12 @@ func (r *Reconciler) createSubscription(ctx context.Context, cr *operatorv1alpha
 		cr.SetCreatingCondition(sub.Name, operatorv1alpha1.ResourceTypeSub, corev1.ConditionFalse, &r.Mutex)
 		return err
 	}
+
+	// Record the OperandRequest in the OperandInventory of the Subscription
+	owner := operatorv1alpha1.NewInventoryOwner(types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, key, opt.Name)
+	if _, err := r.acquireInventory(ctx, operatorv1alpha1.InventoryKindSubscription, sub, types.NamespacedName{Namespace: sub.Namespace, Name: sub.Name}, owner); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -265,49 +322,74 @@ func (r *Reconciler) deleteSubscription(ctx context.Context, operandName string,
 		return nil
 	}
 
+	if op.IsClusterExtension() {
+		return r.deleteClusterExtension(ctx, op, requestInstance, registryInstance, configInstance)
+	}
+
 	namespace := r.GetOperatorNamespace(op.InstallMode, op.Namespace)
 	sub, err := r.GetSubscription(ctx, operandName, namespace, op.PackageName)
 	originalsub := sub.DeepCopy()
 	if apierrors.IsNotFound(err) {
 		klog.V(3).Infof("There is no Subscription %s or %s in the namespace %s", operandName, op.PackageName, namespace)
+		requestInstance.RemoveOperandDeletionStatus(operandName, &r.Mutex)
 		return nil
 	} else if err != nil {
 		klog.Errorf("Failed to get Subscription %s or %s in the namespace %s", operandName, op.PackageName, namespace)
 		return err
 	}
 
-	if sub.Labels == nil {
-		// Subscription existing and not managed by OperandRequest controller
-		klog.V(2).Infof("Subscription %s in the namespace %s isn't created by ODLM", sub.Name, sub.Namespace)
-		return nil
-	}
-
 	if _, ok := sub.Labels[constant.OpreqLabel]; !ok {
 		// Subscription existing and not managed by OperandRequest controller
 		klog.V(2).Infof("Subscription %s in the namespace %s isn't created by ODLM", sub.Name, sub.Namespace)
+		requestInstance.RemoveOperandDeletionStatus(operandName, &r.Mutex)
 		return nil
 	}
 
-	// check and remove registry and config in annotation of subscription
+	// remove the OperandRequest from the owners of the subscription
 	regName := registryInstance.ObjectMeta.Name
 	regNs := registryInstance.ObjectMeta.Namespace
-	delete(sub.Annotations, regNs+"."+regName+"/registry")
-	delete(sub.Annotations, regNs+"."+regName+"/config")
-	reg, _ := regexp.Compile(`^(.*)\.(.*)\/registry`)
-	annoSlice := make([]string, 0)
-	for anno := range sub.Annotations {
-		if reg.MatchString(anno) {
-			annoSlice = append(annoSlice, anno)
-		}
+	owner := operatorv1alpha1.NewInventoryOwner(types.NamespacedName{Namespace: requestInstance.Namespace, Name: requestInstance.Name}, types.NamespacedName{Namespace: regNs, Name: regName}, operandName)
+	inventory, err := r.releaseInventory(ctx, types.NamespacedName{Namespace: sub.Namespace, Name: sub.Name}, owner)
+	if err != nil {
+		return err
 	}
-	if len(annoSlice) != 0 {
-		// remove the associated registry from annotation of subscription
-		if err := r.Patch(ctx, sub, client.MergeFrom(originalsub)); err != nil {
-			requestInstance.SetUpdatingCondition(sub.Name, operatorv1alpha1.ResourceTypeSub, corev1.ConditionFalse, &r.Mutex)
-			return err
+	if inventory != nil {
+		if len(inventory.Spec.Owners) != 0 {
+			setOwnerAnnotations(sub, inventory.GenerateAnnotations())
+			if err := r.Patch(ctx, sub, client.MergeFrom(originalsub)); err != nil {
+				requestInstance.SetUpdatingCondition(sub.Name, operatorv1alpha1.ResourceTypeSub, corev1.ConditionFalse, &r.Mutex)
+				return err
+			}
+			klog.V(1).Infof("Did not delete Subscription %s/%s which is still requested by %d OperandRequests", sub.Namespace, sub.Name, len(inventory.Spec.Owners))
+			requestInstance.RemoveOperandDeletionStatus(operandName, &r.Mutex)
+			return nil
+		}
+	} else {
+		// check and remove registry and config in annotation of subscription created before the OperandInventory
+		delete(sub.Annotations, regNs+"."+regName+"/registry")
+		delete(sub.Annotations, regNs+"."+regName+"/config")
+		reg, _ := regexp.Compile(`^(.*)\.(.*)\/registry`)
+		annoSlice := make([]string, 0)
+		for anno := range sub.Annotations {
+			if reg.MatchString(anno) {
+				annoSlice = append(annoSlice, anno)
+			}
+		}
+		if len(annoSlice) != 0 {
+			// remove the associated registry from annotation of subscription
+			if err := r.Patch(ctx, sub, client.MergeFrom(originalsub)); err != nil {
+				requestInstance.SetUpdatingCondition(sub.Name, operatorv1alpha1.ResourceTypeSub, corev1.ConditionFalse, &r.Mutex)
+				return err
+			}
+			klog.V(1).Infof("Did not delete Subscription %s/%s which is requested by OperandRequest with different OperandRegistry", sub.Namespace, sub.Name)
+			requestInstance.RemoveOperandDeletionStatus(operandName, &r.Mutex)
+			return nil
 		}
-		klog.V(1).Infof("Did not delete Subscription %s/%s which is requested by OperandRequest with different OperandRegistry", sub.Namespace, sub.Name)
-		return nil
+	}
+
+	// The Subscription waits for its operands being deleted
+	if err := r.setPendingDeletion(requestInstance, operandName, sub); err != nil {
+		return err
 	}
 
 	csv, err := r.GetClusterServiceVersion(ctx, sub)
@@ -316,25 +398,46 @@ func (r *Reconciler) deleteSubscription(ctx context.Context, operandName string,
 		return err
 	}
 
+	policy := op.GetDeletionPolicy()
+	timeouts := r.EffectiveTimeouts(op, configInstance.GetService(operandName))
 	if csv != nil {
 		klog.V(2).Infof("Deleting all the Custom Resources for CSV, Namespace: %s, Name: %s", csv.Namespace, csv.Name)
-		if err := r.deleteAllCustomResource(ctx, csv, requestInstance, configInstance, operandName, op.Namespace); err != nil {
+		crDeleted, err := r.deleteAllCustomResource(ctx, csv.GetAnnotations()["alm-examples"], requestInstance, configInstance, operandName, op.Namespace, policy, timeouts.CRDeleteTimeout.Duration)
+		if err != nil {
 			return err
 		}
 		klog.V(2).Infof("Deleting all the k8s Resources for CSV, Namespace: %s, Name: %s", csv.Namespace, csv.Name)
-		if err := r.deleteAllK8sResource(ctx, configInstance, operandName, op.Namespace); err != nil {
+		k8sDeleted, err := r.deleteAllK8sResource(ctx, requestInstance, configInstance, operandName, op.Namespace, policy, timeouts.CRDeleteTimeout.Duration)
+		if err != nil {
 			return err
 		}
-		if r.checkUninstallLabel(ctx, op.Name, namespace) {
-			klog.V(1).Infof("Operator %s has label operator.ibm.com/opreq-do-not-uninstall. Skip the uninstall", op.Name)
+		if !crDeleted || !k8sDeleted {
+			klog.V(2).Infof("Waiting for the operands of operator %s being deleted ...", op.Name)
 			return nil
 		}
+	}
+
+	if policy != operatorv1alpha1.DeletionPolicyDelete {
+		klog.V(1).Infof("Operator %s has deletion policy %s. Skip the uninstall", op.Name, policy)
+		if err := r.retainOperator(ctx, sub, originalsub, types.NamespacedName{Namespace: sub.Namespace, Name: sub.Name}, policy); err != nil {
+			return err
+		}
+		requestInstance.RemoveOperandDeletionStatus(operandName, &r.Mutex)
+		return nil
+	}
+
+	if r.checkUninstallLabel(ctx, op.Name, namespace) {
+		klog.V(1).Infof("Operator %s has label operator.ibm.com/opreq-do-not-uninstall. Skip the uninstall", op.Name)
+		requestInstance.RemoveOperandDeletionStatus(operandName, &r.Mutex)
+		return nil
+	}
 
+	if csv != nil {
 		klog.V(3).Info("Set Deleting Condition in the operandRequest")
 		requestInstance.SetDeletingCondition(csv.Name, operatorv1alpha1.ResourceTypeCsv, corev1.ConditionTrue, &r.Mutex)
 
 		klog.V(1).Infof("Deleting the ClusterServiceVersion, Namespace: %s, Name: %s", csv.Namespace, csv.Name)
-		if err := r.Delete(ctx, csv); err != nil {
+		if _, err := r.deleteResource(ctx, requestInstance, operandName, csv, timeouts.SubDeleteTimeout.Duration); err != nil {
 			requestInstance.SetDeletingCondition(csv.Name, operatorv1alpha1.ResourceTypeCsv, corev1.ConditionFalse, &r.Mutex)
 			return errors.Wrap(err, "failed to delete the ClusterServiceVersion")
 		}
@@ -343,19 +446,47 @@ func (r *Reconciler) deleteSubscription(ctx context.Context, operandName string,
 	klog.V(2).Infof("Deleting the Subscription, Namespace: %s, Name: %s", namespace, op.Name)
 	requestInstance.SetDeletingCondition(op.Name, operatorv1alpha1.ResourceTypeSub, corev1.ConditionTrue, &r.Mutex)
 
-	if err := r.Delete(ctx, sub); err != nil {
-		if apierrors.IsNotFound(err) {
-			klog.Warningf("Subscription %s was not found in namespace %s", op.Name, namespace)
-		} else {
-			requestInstance.SetDeletingCondition(op.Name, operatorv1alpha1.ResourceTypeSub, corev1.ConditionFalse, &r.Mutex)
-			return errors.Wrap(err, "failed to delete subscription")
-		}
+	deleted, err := r.deleteResource(ctx, requestInstance, operandName, sub, timeouts.SubDeleteTimeout.Duration)
+	if err != nil {
+		requestInstance.SetDeletingCondition(op.Name, operatorv1alpha1.ResourceTypeSub, corev1.ConditionFalse, &r.Mutex)
+		return errors.Wrap(err, "failed to delete subscription")
+	}
+	if !deleted {
+		klog.V(2).Infof("Waiting for Subscription %s/%s being removed ...", namespace, op.Name)
+		return nil
 	}
 
+	requestInstance.RemoveOperandDeletionStatus(operandName, &r.Mutex)
 	klog.V(1).Infof("Subscription %s/%s is deleted", namespace, op.Name)
 	return nil
 }
 
+// retainOperator keeps the Subscription or ClusterExtension of the operator and removes its owner annotations.
+// When the deletion policy is Orphan, it also removes the ODLM label and the OperandInventory, so that ODLM no longer manages it.
+func (r *Reconciler) retainOperator(ctx context.Context, managed, original client.Object, inventoryKey types.NamespacedName, policy operatorv1alpha1.DeletionPolicy) error {
+	setOwnerAnnotations(managed, nil)
+	if policy == operatorv1alpha1.DeletionPolicyOrphan {
+		labels := managed.GetLabels()
+		delete(labels, constant.OpreqLabel)
+		managed.SetLabels(labels)
+	}
+	if err := r.Patch(ctx, managed, client.MergeFrom(original)); err != nil {
+		return errors.Wrapf(err, "failed to update %s/%s with deletion policy %s", managed.GetNamespace(), managed.GetName(), policy)
+	}
+	if policy != operatorv1alpha1.DeletionPolicyOrphan {
+		return nil
+	}
+
+	inventory := &operatorv1alpha1.OperandInventory{}
+	inventory.SetName(inventoryKey.Name)
+	inventory.SetNamespace(inventoryKey.Namespace)
+	if err := r.Delete(ctx, inventory); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete OperandInventory %s", inventoryKey.String())
+	}
+	klog.V(1).Infof("%s/%s is orphaned and no longer managed by ODLM", managed.GetNamespace(), managed.GetName())
+	return nil
+}
+
 func (r *Reconciler) absentOperatorsAndOperands(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest) error {
 	needDeletedOperands, err := r.getNeedDeletedOperands(ctx, requestInstance)
 	if err != nil {
@@ -366,26 +497,35 @@ func (r *Reconciler) absentOperatorsAndOperands(ctx context.Context, requestInst
 		wg sync.WaitGroup
 	)
 
+	foundOperands := gset.NewSet()
 	for _, req := range requestInstance.Spec.Requests {
-		registryKey := requestInstance.GetRegistryKey(req)
-		registryInstance, err := r.GetOperandRegistry(ctx, registryKey)
+		registries, err := r.GetOperandRegistriesForRequest(ctx, requestInstance, req)
 		if err != nil {
 			return err
 		}
-		configInstance, err := r.GetOperandConfig(ctx, registryKey)
-		if err != nil {
-			if apierrors.IsNotFound(err) {
-				configInstance = &operatorv1alpha1.OperandConfig{}
-			} else {
-				return err
+		configs := make(map[types.NamespacedName]*operatorv1alpha1.OperandConfig)
+		for _, registry := range registries {
+			configInstance, err := r.GetOperandConfig(ctx, registry.Key)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					configInstance = &operatorv1alpha1.OperandConfig{}
+				} else {
+					return err
+				}
 			}
+			configs[registry.Key] = configInstance
 		}
 		merr := &util.MultiErr{}
-		remainingOp := needDeletedOperands.Clone()
 		for o := range needDeletedOperands.Iter() {
 			var (
 				o = o
 			)
+			// The operator is deleted from the OperandRegistry it was resolved from
+			registry := deletedOperandRegistry(requestInstance, req, registries, fmt.Sprintf("%v", o))
+			registryInstance, configInstance := registry.Registry, configs[registry.Key]
+			if registryInstance.GetOperator(fmt.Sprintf("%v", o)) != nil {
+				foundOperands.Add(o)
+			}
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
@@ -394,34 +534,57 @@ func (r *Reconciler) absentOperatorsAndOperands(ctx context.Context, requestInst
 					defer r.Mutex.Unlock()
 					merr.Add(err)
 				}
-				remainingOp.Remove(o)
 			}()
 		}
-		timeout := util.WaitTimeout(&wg, constant.DefaultSubDeleteTimeout)
-		if timeout {
-			merr.Add(fmt.Errorf("timeout for cleaning up subscription %v", strings.Trim(fmt.Sprint(remainingOp.ToSlice()), "[]")))
-		}
+		wg.Wait()
 		if len(merr.Errors) != 0 {
 			return merr
 		}
+	}
 
+	// Forget the deletion of the operators which are not in any OperandRegistry anymore
+	for o := range needDeletedOperands.Difference(foundOperands).Iter() {
+		requestInstance.RemoveOperandDeletionStatus(fmt.Sprintf("%v", o), &r.Mutex)
 	}
 	return nil
 }
 
+// deletedOperandRegistry returns the OperandRegistry a deleted operand was resolved from, as recorded in its member status,
+// or the first OperandRegistry offering it when it isn't recorded
+func deletedOperandRegistry(requestInstance *operatorv1alpha1.OperandRequest, req operatorv1alpha1.Request, registries []deploy.RequestRegistry, operandName string) *deploy.RequestRegistry {
+	registryKey := requestInstance.GetOperandRegistryKey(req, operandName)
+	for i, registry := range registries {
+		if registry.Key == registryKey && registry.Registry.GetOperator(operandName) != nil {
+			return &registries[i]
+		}
+	}
+	return deploy.SelectOperandRegistry(registries, requestInstance.Namespace, operandName)
+}
+
+// getNeedDeletedOperands returns the operands which are deployed or being deleted, and not requested anymore
 func (r *Reconciler) getNeedDeletedOperands(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest) (gset.Set, error) {
 	klog.V(3).Info("Getting the operater need to be delete")
 	deployedOperands := gset.NewSet()
 	for _, req := range requestInstance.Status.Members {
 		deployedOperands.Add(req.Name)
 	}
+	// The operators being deleted are no longer in the members
+	deletingOperands := gset.NewSet()
+	for _, operand := range requestInstance.GetDeletingOperators() {
+		deletingOperands.Add(operand)
+	}
 
 	currentOperands, err := r.getCurrentOperands(ctx, requestInstance)
 	if err != nil {
 		return nil, err
 	}
 
-	needDeleteOperands := deployedOperands.Difference(currentOperands)
+	// Stop deleting the operators which are requested again
+	for o := range deletingOperands.Intersect(currentOperands).Iter() {
+		requestInstance.RemoveOperandDeletionStatus(fmt.Sprintf("%v", o), &r.Mutex)
+	}
+
+	needDeleteOperands := deployedOperands.Union(deletingOperands).Difference(currentOperands)
 	return needDeleteOperands, nil
 }
 
@@ -429,22 +592,22 @@ func (r *Reconciler) getCurrentOperands(ctx context.Context, requestInstance *op
 	klog.V(3).Info("Getting the operaters have been deployed")
 	deployedOperands := gset.NewSet()
 	for _, req := range requestInstance.Spec.Requests {
-		registryKey := requestInstance.GetRegistryKey(req)
-		requestList, err := r.ListOperandRequestsByRegistry(ctx, registryKey)
-		if err != nil {
-			return nil, err
-		}
-		for _, item := range requestList {
-			if !item.DeletionTimestamp.IsZero() {
-				continue
+		for _, registryKey := range requestInstance.GetRegistryKeys(req) {
+			requestList, err := r.ListOperandRequestsByRegistry(ctx, registryKey)
+			if err != nil {
+				return nil, err
 			}
-			for _, existingReq := range item.Spec.Requests {
-				existRegistryKey := item.GetRegistryKey(existingReq)
-				if registryKey.String() != existRegistryKey.String() {
+			for _, item := range requestList {
+				if !item.DeletionTimestamp.IsZero() {
 					continue
 				}
-				for _, operand := range existingReq.Operands {
-					deployedOperands.Add(operand.Name)
+				for _, existingReq := range item.Spec.Requests {
+					if !item.UsesRegistry(existingReq, registryKey) {
+						continue
+					}
+					for _, operand := range existingReq.Operands {
+						deployedOperands.Add(operand.Name)
+					}
 				}
 			}
 		}
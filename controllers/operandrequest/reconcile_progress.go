@@ -0,0 +1,137 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operandrequest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+)
+
+var progressDeadlineExceeded = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "odlm_operandrequest_progress_deadline_exceeded_total",
+	Help: "Number of times a member of an OperandRequest has not reached Running within its progress deadline.",
+}, []string{"namespace", "operandrequest", "operand"})
+
+func init() {
+	metrics.Registry.MustRegister(progressDeadlineExceeded)
+}
+
+// checkProgress checks the members which aren't Running against their progress deadline.
+// A member exceeding the deadline gets a ProgressDeadlineExceeded condition, a Warning event and is counted in the metric.
+// It returns the interval to retry the members exceeding the deadline, which is zero when any other member is still progressing.
+func (r *Reconciler) checkProgress(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest) (time.Duration, error) {
+	deadlines, err := r.getProgressDeadlines(ctx, requestInstance)
+	if err != nil {
+		return 0, err
+	}
+
+	now := metav1.Now()
+	var backoff time.Duration
+	progressing := false
+	for _, m := range requestInstance.Status.Members {
+		if m.IsRunning() {
+			requestInstance.SetMemberProgress(m.Name, nil, &r.Mutex)
+			requestInstance.SetProgressDeadlineExceededCondition(m.Name, "", corev1.ConditionFalse, &r.Mutex)
+			continue
+		}
+
+		reason := m.BlockingReason
+		if reason == "" {
+			reason = fmt.Sprintf("operator phase is %q, operand phase is %q", m.Phase.OperatorPhase, m.Phase.OperandPhase)
+		}
+		startTime := m.ProgressStartTime
+		if startTime == nil {
+			startTime = &now
+			requestInstance.SetMemberProgress(m.Name, startTime, &r.Mutex)
+		}
+
+		deadline, ok := deadlines[m.Name]
+		elapsed := now.Sub(startTime.Time)
+		if !ok || elapsed < deadline {
+			progressing = true
+			continue
+		}
+
+		if requestInstance.SetProgressDeadlineExceededCondition(m.Name, reason, corev1.ConditionTrue, &r.Mutex) {
+			klog.Warningf("Operator %s of OperandRequest %s/%s has not reached Running within %s: %s", m.Name, requestInstance.Namespace, requestInstance.Name, deadline, reason)
+			r.Recorder.Eventf(requestInstance, corev1.EventTypeWarning, string(operatorv1alpha1.ConditionProgressDeadlineExceeded), "Operator %s has not reached Running within %s: %s", m.Name, deadline, reason)
+			progressDeadlineExceeded.WithLabelValues(requestInstance.Namespace, requestInstance.Name, m.Name).Inc()
+		}
+		if interval := progressBackoff(elapsed, r.memberTimeouts(requestInstance, m.Name)); backoff == 0 || interval < backoff {
+			backoff = interval
+		}
+	}
+	if progressing {
+		return 0, nil
+	}
+	return backoff, nil
+}
+
+// progressBackoff returns the interval to retry a member exceeding its progress deadline.
+// Waiting as long as the member has been progressing doubles the interval after each retry.
+func progressBackoff(elapsed time.Duration, timeouts operatorv1alpha1.Timeouts) time.Duration {
+	maxBackoff := constant.DefaultMaxProgressBackoff
+	if timeouts.SyncPeriod.Duration < maxBackoff {
+		maxBackoff = timeouts.SyncPeriod.Duration
+	}
+	if elapsed < timeouts.RequeueDuration.Duration {
+		return timeouts.RequeueDuration.Duration
+	}
+	if elapsed > maxBackoff {
+		return maxBackoff
+	}
+	return elapsed
+}
+
+// getProgressDeadlines returns the progress deadline of each requested operand.
+// The deadline of the OperandRequest takes precedence over the one of the OperandRegistry.
+func (r *Reconciler) getProgressDeadlines(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest) (map[string]time.Duration, error) {
+	deadlines := make(map[string]time.Duration)
+	for _, req := range requestInstance.Spec.Requests {
+		deadlineSeconds := requestInstance.Spec.ProgressDeadlineSeconds
+		if deadlineSeconds == nil {
+			registryKey := requestInstance.GetRegistryKey(req)
+			registryInstance, err := r.GetOperandRegistry(ctx, registryKey)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, errors.Wrapf(err, "failed to get the OperandRegistry %s", registryKey.String())
+			}
+			deadlineSeconds = registryInstance.Spec.ProgressDeadlineSeconds
+		}
+		if deadlineSeconds == nil {
+			continue
+		}
+		for _, operand := range req.Operands {
+			deadlines[operand.Name] = time.Duration(*deadlineSeconds) * time.Second
+		}
+	}
+	return deadlines, nil
+}
@@ -0,0 +1,63 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operandrequest
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+)
+
+func TestProgressBackoff(t *testing.T) {
+	g := NewWithT(t)
+	timeouts := operatorv1alpha1.Timeouts{
+		RequeueDuration: &metav1.Duration{Duration: 20 * time.Second},
+		SyncPeriod:      &metav1.Duration{Duration: time.Hour},
+	}
+
+	// The retry interval is doubled up to the maximum backoff
+	g.Expect(progressBackoff(5*time.Second, timeouts)).Should(Equal(20 * time.Second))
+	g.Expect(progressBackoff(10*time.Minute, timeouts)).Should(Equal(10 * time.Minute))
+	g.Expect(progressBackoff(20*time.Minute, timeouts)).Should(Equal(20 * time.Minute))
+	g.Expect(progressBackoff(5*time.Hour, timeouts)).Should(Equal(30 * time.Minute))
+}
+
+func TestProgressDeadlineExceededCondition(t *testing.T) {
+	g := NewWithT(t)
+	req := &operatorv1alpha1.OperandRequest{}
+	mu := &noopLocker{}
+
+	// The condition is only raised once until the member recovers
+	g.Expect(req.SetProgressDeadlineExceededCondition("etcd", "", corev1.ConditionFalse, mu)).Should(BeFalse())
+	g.Expect(req.Status.Conditions).Should(BeEmpty())
+	g.Expect(req.SetProgressDeadlineExceededCondition("etcd", "the CatalogSource is CONNECTING", corev1.ConditionTrue, mu)).Should(BeTrue())
+	g.Expect(req.SetProgressDeadlineExceededCondition("etcd", "the CatalogSource is TRANSIENT_FAILURE", corev1.ConditionTrue, mu)).Should(BeFalse())
+	g.Expect(req.Status.Conditions).Should(HaveLen(1))
+	g.Expect(req.Status.Conditions[0].Reason).Should(Equal("the CatalogSource is TRANSIENT_FAILURE"))
+	g.Expect(req.SetProgressDeadlineExceededCondition("etcd", "", corev1.ConditionFalse, mu)).Should(BeFalse())
+	g.Expect(req.Status.Conditions[0].Status).Should(Equal(corev1.ConditionFalse))
+}
+
+type noopLocker struct{}
+
+func (*noopLocker) Lock()   {}
+func (*noopLocker) Unlock() {}
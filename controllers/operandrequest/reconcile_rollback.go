@@ -0,0 +1,163 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operandrequest
+
+import (
+	"context"
+
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+)
+
+// pinRollback returns the registry entry of the operator pinned to the ClusterServiceVersion it is rolled back to.
+// It returns true when the rollback should be lifted, because the channel or the startingCSV of the registry entry
+// changed since the upgrade failed.
+func pinRollback(registryInstance *operatorv1alpha1.OperandRegistry, opt *operatorv1alpha1.Operator) (*operatorv1alpha1.Operator, bool) {
+	rb := registryInstance.GetRollback(opt.Name)
+	if rb == nil {
+		return opt, false
+	}
+	pinned := opt.DeepCopy()
+	if rb.FailedChannel != opt.Channel || rb.FailedStartingCSV != opt.StartingCSV {
+		// restore the approval of the registry entry, the Subscription was pinned with the manual approval
+		if pinned.InstallPlanApproval == "" {
+			pinned.InstallPlanApproval = olmv1alpha1.ApprovalAutomatic
+		}
+		return pinned, true
+	}
+	// the manual approval keeps OLM from upgrading the operator to the failed ClusterServiceVersion again
+	pinned.Channel = rb.Channel
+	pinned.StartingCSV = rb.CSV
+	pinned.InstallPlanApproval = olmv1alpha1.ApprovalManual
+	return pinned, false
+}
+
+// recordSucceededCSV records the succeeded ClusterServiceVersion and its channel in the annotations of the Subscription,
+// so that the operator can be rolled back to it when an upgrade fails.
+func (r *Reconciler) recordSucceededCSV(ctx context.Context, opt *operatorv1alpha1.Operator, sub *olmv1alpha1.Subscription, csv *olmv1alpha1.ClusterServiceVersion) error {
+	if opt.GetRollbackPolicy() != operatorv1alpha1.RollbackPolicyAutomatic {
+		return nil
+	}
+	if _, ok := sub.Labels[constant.OpreqLabel]; !ok {
+		return nil
+	}
+	if sub.Annotations[constant.LastSucceededCSVAnnotation] == csv.Name && sub.Annotations[constant.LastSucceededChannelAnnotation] == sub.Spec.Channel {
+		return nil
+	}
+
+	originalSub := sub.DeepCopy()
+	if sub.Annotations == nil {
+		sub.Annotations = make(map[string]string)
+	}
+	sub.Annotations[constant.LastSucceededCSVAnnotation] = csv.Name
+	sub.Annotations[constant.LastSucceededChannelAnnotation] = sub.Spec.Channel
+	if err := r.Patch(ctx, sub, client.MergeFrom(originalSub)); err != nil {
+		return errors.Wrapf(err, "failed to record the succeeded ClusterServiceVersion in Subscription %s/%s", sub.Namespace, sub.Name)
+	}
+	return nil
+}
+
+// rollbackOperator reinstalls the last succeeded ClusterServiceVersion of the operator when its upgrade failed.
+// The rollback is recorded in the OperandRegistry status, then the failed ClusterServiceVersion and the Subscription are deleted,
+// without its OperandInventory, and the Subscription is recreated with the last succeeded ClusterServiceVersion as the startingCSV.
+// It returns true when the operator is rolled back.
+func (r *Reconciler) rollbackOperator(ctx context.Context, requestInstance *operatorv1alpha1.OperandRequest, registryKey types.NamespacedName, registryInstance *operatorv1alpha1.OperandRegistry,
+	opt *operatorv1alpha1.Operator, sub *olmv1alpha1.Subscription, csv *olmv1alpha1.ClusterServiceVersion) (bool, error) {
+	if opt.GetRollbackPolicy() != operatorv1alpha1.RollbackPolicyAutomatic {
+		return false, nil
+	}
+	if _, ok := sub.Labels[constant.OpreqLabel]; !ok {
+		return false, nil
+	}
+	lastCSV := sub.Annotations[constant.LastSucceededCSVAnnotation]
+	if lastCSV == "" || lastCSV == csv.Name {
+		klog.Warningf("There is no succeeded ClusterServiceVersion to roll back operator %s to", opt.Name)
+		return false, nil
+	}
+
+	rb := registryInstance.GetRollback(opt.Name)
+	if rb != nil && rb.FailedCSV != csv.Name {
+		// the ClusterServiceVersion the operator is rolled back to also failed, don't roll back again
+		klog.Warningf("Operator %s is already rolled back from ClusterServiceVersion %s", opt.Name, rb.FailedCSV)
+		return false, nil
+	}
+	if rb == nil {
+		rb = &operatorv1alpha1.RollbackStatus{
+			FailedCSV:         csv.Name,
+			CSV:               lastCSV,
+			Channel:           sub.Annotations[constant.LastSucceededChannelAnnotation],
+			FailedChannel:     opt.Channel,
+			FailedStartingCSV: opt.StartingCSV,
+			Time:              metav1.Now(),
+		}
+		if err := r.setRegistryRollback(ctx, registryKey, opt.Name, rb); err != nil {
+			return false, err
+		}
+		registryInstance.SetRollback(opt.Name, rb)
+		r.Recorder.Eventf(requestInstance, corev1.EventTypeWarning, "RolledBack", "Operator %s is rolled back from the failed ClusterServiceVersion %s to %s", opt.Name, csv.Name, lastCSV)
+	}
+
+	klog.Warningf("Rolling back operator %s from the failed ClusterServiceVersion %s to %s", opt.Name, csv.Name, lastCSV)
+	if err := r.Delete(ctx, csv); err != nil && !apierrors.IsNotFound(err) {
+		return false, errors.Wrapf(err, "failed to delete the failed ClusterServiceVersion %s/%s", csv.Namespace, csv.Name)
+	}
+	// The OperandInventory keeps the owners of the Subscription across the rollback
+	if err := r.detachInventory(ctx, types.NamespacedName{Namespace: sub.Namespace, Name: sub.Name}); err != nil {
+		return false, err
+	}
+	if err := r.Delete(ctx, sub); err != nil && !apierrors.IsNotFound(err) {
+		return false, errors.Wrapf(err, "failed to delete Subscription %s/%s", sub.Namespace, sub.Name)
+	}
+	requestInstance.SetMemberRollback(opt.Name, rb, &r.Mutex)
+	return true, nil
+}
+
+// setRegistryRollback records the rollback of the operator in the OperandRegistry status, a nil rollback removes it.
+func (r *Reconciler) setRegistryRollback(ctx context.Context, registryKey types.NamespacedName, name string, rb *operatorv1alpha1.RollbackStatus) error {
+	registryInstance := &operatorv1alpha1.OperandRegistry{}
+	if err := r.Client.Get(ctx, registryKey, registryInstance); err != nil {
+		return errors.Wrapf(err, "failed to get the OperandRegistry %s", registryKey.String())
+	}
+	originalInstance := registryInstance.DeepCopy()
+	registryInstance.SetRollback(name, rb)
+	if err := r.Client.Status().Patch(ctx, registryInstance, client.MergeFrom(originalInstance)); err != nil {
+		return errors.Wrapf(err, "failed to record the rollback of operator %s in the OperandRegistry %s", name, registryKey.String())
+	}
+	return nil
+}
+
+// liftRollback removes the rollback of the operator from the OperandRegistry once its Subscription follows the registry entry again
+func (r *Reconciler) liftRollback(ctx context.Context, registryInstance *operatorv1alpha1.OperandRegistry, registryKey types.NamespacedName, name string, lift bool) error {
+	if !lift {
+		return nil
+	}
+	klog.Infof("Lifting the rollback of operator %s, the OperandRegistry %s is changed", name, registryKey.String())
+	if err := r.setRegistryRollback(ctx, registryKey, name, nil); err != nil {
+		return err
+	}
+	registryInstance.SetRollback(name, nil)
+	return nil
+}
@@ -0,0 +1,78 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operandrequest
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+)
+
+func rollbackOperator() *operatorv1alpha1.Operator {
+	return &operatorv1alpha1.Operator{
+		Name:           "jenkins",
+		Channel:        "beta",
+		RollbackPolicy: operatorv1alpha1.RollbackPolicyAutomatic,
+	}
+}
+
+func TestPinRollbackWithoutRollback(t *testing.T) {
+	g := NewWithT(t)
+	opt := rollbackOperator()
+
+	pinned, lift := pinRollback(&operatorv1alpha1.OperandRegistry{}, opt)
+	g.Expect(lift).Should(BeFalse())
+	g.Expect(pinned).Should(Equal(opt))
+}
+
+func TestPinRollbackToLastSucceededCSV(t *testing.T) {
+	g := NewWithT(t)
+	opt := rollbackOperator()
+	registry := &operatorv1alpha1.OperandRegistry{}
+	registry.SetRollback("jenkins", &operatorv1alpha1.RollbackStatus{
+		FailedCSV:     "jenkins-operator.v0.5.0",
+		CSV:           "jenkins-operator.v0.4.0",
+		Channel:       "alpha",
+		FailedChannel: "beta",
+	})
+
+	pinned, lift := pinRollback(registry, opt)
+	g.Expect(lift).Should(BeFalse())
+	g.Expect(pinned.Channel).Should(Equal("alpha"))
+	g.Expect(pinned.StartingCSV).Should(Equal("jenkins-operator.v0.4.0"))
+	g.Expect(pinned.InstallPlanApproval).Should(Equal(olmv1alpha1.ApprovalManual))
+	g.Expect(opt.Channel).Should(Equal("beta"))
+}
+
+func TestPinRollbackLiftedByChannelChange(t *testing.T) {
+	g := NewWithT(t)
+	registry := &operatorv1alpha1.OperandRegistry{}
+	registry.SetRollback("jenkins", &operatorv1alpha1.RollbackStatus{
+		FailedCSV:     "jenkins-operator.v0.5.0",
+		CSV:           "jenkins-operator.v0.4.0",
+		Channel:       "alpha",
+		FailedChannel: "alpha",
+	})
+
+	pinned, lift := pinRollback(registry, rollbackOperator())
+	g.Expect(lift).Should(BeTrue())
+	g.Expect(pinned.Channel).Should(Equal("beta"))
+	g.Expect(pinned.InstallPlanApproval).Should(Equal(olmv1alpha1.ApprovalAutomatic))
+}
@@ -0,0 +1,71 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operandrequest
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+)
+
+// getTimeouts returns the effective timeouts of the operator, the global timeouts overridden
+// by the OperandRegistry operator and then by the service in the OperandConfig.
+func (r *Reconciler) getTimeouts(ctx context.Context, registryKey types.NamespacedName, opt *operatorv1alpha1.Operator) (operatorv1alpha1.Timeouts, error) {
+	configInstance, err := r.GetOperandConfig(ctx, registryKey)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.EffectiveTimeouts(opt, nil), nil
+		}
+		return operatorv1alpha1.Timeouts{}, errors.Wrapf(err, "failed to get the OperandConfig %s", registryKey.String())
+	}
+	return r.EffectiveTimeouts(opt, configInstance.GetService(opt.Name)), nil
+}
+
+// memberTimeouts returns the effective timeouts recorded in the member status of the operand,
+// and falls back to the global timeouts when they are not recorded yet.
+func (r *Reconciler) memberTimeouts(requestInstance *operatorv1alpha1.OperandRequest, operand string) operatorv1alpha1.Timeouts {
+	return r.GlobalTimeouts().Merge(requestInstance.GetMemberTimeouts(operand))
+}
+
+// requeueIntervals returns the shortest requeue duration and sync period among the members of the OperandRequest,
+// or the global ones when no member records its timeouts.
+func (r *Reconciler) requeueIntervals(requestInstance *operatorv1alpha1.OperandRequest) (requeueDuration, syncPeriod time.Duration) {
+	global := r.GlobalTimeouts()
+	found := false
+	for _, m := range requestInstance.Status.Members {
+		if m.Timeouts == nil {
+			continue
+		}
+		timeouts := global.Merge(m.Timeouts)
+		if !found || timeouts.RequeueDuration.Duration < requeueDuration {
+			requeueDuration = timeouts.RequeueDuration.Duration
+		}
+		if !found || timeouts.SyncPeriod.Duration < syncPeriod {
+			syncPeriod = timeouts.SyncPeriod.Duration
+		}
+		found = true
+	}
+	if !found {
+		return global.RequeueDuration.Duration, global.SyncPeriod.Duration
+	}
+	return requeueDuration, syncPeriod
+}
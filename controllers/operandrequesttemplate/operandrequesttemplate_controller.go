@@ -0,0 +1,165 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operandrequesttemplate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
+)
+
+// Reconciler generates an OperandRequest from an OperandRequestTemplate in every namespace matching its namespace selector.
+// The generated OperandRequests are kept in sync with the template, and deleted when their namespace stops matching.
+type Reconciler struct {
+	*deploy.ODLMOperator
+}
+
+// Reconcile creates, updates or deletes the OperandRequests generated from the OperandRequestTemplate and rolls their status up
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reconcileErr error) {
+	instance := &operatorv1alpha1.OperandRequestTemplate{}
+	if err := r.Reader.Get(ctx, req.NamespacedName, instance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !instance.DeletionTimestamp.IsZero() {
+		// The generated OperandRequests are deleted by the garbage collector
+		return ctrl.Result{}, nil
+	}
+
+	originalInstance := instance.DeepCopy()
+
+	// Always attempt to patch the status after each reconciliation.
+	defer func() {
+		if reflect.DeepEqual(originalInstance.Status, instance.Status) {
+			return
+		}
+		if err := r.Client.Status().Patch(ctx, instance, client.MergeFrom(originalInstance)); err != nil {
+			reconcileErr = utilerrors.NewAggregate([]error{reconcileErr, fmt.Errorf("error while patching OperandRequestTemplate.Status: %v", err)})
+		}
+	}()
+
+	klog.V(2).Infof("Reconciling OperandRequestTemplate: %s", req.Name)
+
+	// An empty selector would generate the OperandRequests in every namespace of the cluster,
+	// the OperandRequests generated from the previous selector are left unchanged
+	if isEmptySelector(instance.Spec.NamespaceSelector) {
+		klog.Errorf("OperandRequestTemplate %s has an empty namespaceSelector", req.Name)
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "InvalidNamespaceSelector", "The namespaceSelector of OperandRequestTemplate %s is empty, it has to select the namespaces by their labels", req.Name)
+		instance.Status.Phase = operatorv1alpha1.ClusterPhaseFailed
+		return ctrl.Result{}, nil
+	}
+
+	namespaces, err := r.selectNamespaces(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	specs := make(map[string]operatorv1alpha1.OperandRequestSpec)
+	for ns := range namespaces {
+		specs[ns] = *instance.Spec.Template.DeepCopy()
+	}
+	if err := r.SyncGeneratedRequests(ctx, instance, constant.OpreqTemplateLabel, specs); err != nil {
+		return ctrl.Result{}, err
+	}
+	klog.V(2).Infof("Finished reconciling OperandRequestTemplate: %s", req.Name)
+	return ctrl.Result{}, nil
+}
+
+// selectNamespaces returns the namespaces matching the namespace selector of the OperandRequestTemplate.
+// A terminating namespace doesn't match, as no OperandRequest can be created in it,
+// and neither do the system namespaces.
+func (r *Reconciler) selectNamespaces(ctx context.Context, instance *operatorv1alpha1.OperandRequestTemplate) (map[string]bool, error) {
+	selector, err := metav1.LabelSelectorAsSelector(instance.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid namespaceSelector of OperandRequestTemplate %s", instance.Name)
+	}
+	namespaceList := &corev1.NamespaceList{}
+	if err := r.Reader.List(ctx, namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list the namespaces of OperandRequestTemplate %s", instance.Name)
+	}
+	namespaces := make(map[string]bool)
+	for _, ns := range namespaceList.Items {
+		if ns.Status.Phase == corev1.NamespaceTerminating || !ns.DeletionTimestamp.IsZero() || isSystemNamespace(ns.Name) {
+			continue
+		}
+		namespaces[ns.Name] = true
+	}
+	return namespaces, nil
+}
+
+// isEmptySelector returns true when the selector doesn't have any requirement, so it selects everything
+func isEmptySelector(selector *metav1.LabelSelector) bool {
+	return selector == nil || (len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0)
+}
+
+// isSystemNamespace returns true for the namespaces of Kubernetes and OpenShift
+func isSystemNamespace(name string) bool {
+	return strings.HasPrefix(name, "kube-") || strings.HasPrefix(name, "openshift-") || name == "openshift"
+}
+
+// getNamespaceToTemplateMapper maps a namespace to all the OperandRequestTemplates,
+// as the templates selecting the previous labels of the namespace are unknown
+func (r *Reconciler) getNamespaceToTemplateMapper() handler.MapFunc {
+	ctx := context.Background()
+	return func(object client.Object) []reconcile.Request {
+		templateList := &operatorv1alpha1.OperandRequestTemplateList{}
+		if err := r.Reader.List(ctx, templateList); err != nil {
+			klog.Warningf("failed to list OperandRequestTemplates: %v", err)
+			return nil
+		}
+		requests := []reconcile.Request{}
+		for _, template := range templateList.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: template.Name}})
+		}
+		return requests
+	}
+}
+
+// SetupWithManager adds OperandRequestTemplate controller to the manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&operatorv1alpha1.OperandRequestTemplate{}).
+		Watches(&source.Kind{Type: &operatorv1alpha1.OperandRequest{}}, handler.EnqueueRequestsFromMapFunc(deploy.GetGeneratedRequestMapper("OperandRequestTemplate"))).
+		Watches(&source.Kind{Type: &corev1.Namespace{}}, handler.EnqueueRequestsFromMapFunc(r.getNamespaceToTemplateMapper()), builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldObject := e.ObjectOld.(*corev1.Namespace)
+				newObject := e.ObjectNew.(*corev1.Namespace)
+				return !reflect.DeepEqual(oldObject.Labels, newObject.Labels) ||
+					oldObject.Status.Phase != newObject.Status.Phase
+			},
+		})).
+		Complete(r)
+}
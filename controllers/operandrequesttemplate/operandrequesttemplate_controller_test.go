@@ -0,0 +1,151 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operandrequesttemplate
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/testutil"
+)
+
+var _ = Describe("OperandRequestTemplate", func() {
+	ctx := context.Background()
+
+	newReconciler := func(objs ...client.Object) *Reconciler {
+		c := testutil.NewFakeClient(objs...)
+		return &Reconciler{ODLMOperator: &deploy.ODLMOperator{Client: c, Reader: c, Scheme: c.Scheme(), Recorder: record.NewFakeRecorder(10)}}
+	}
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	template := func() *operatorv1alpha1.OperandRequestTemplate {
+		return &operatorv1alpha1.OperandRequestTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant", UID: "tenant-uid"},
+			Spec: operatorv1alpha1.OperandRequestTemplateSpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}},
+				Template: operatorv1alpha1.OperandRequestSpec{Requests: []operatorv1alpha1.Request{{
+					Registry:          "common-service",
+					RegistryNamespace: "ibm-common-services",
+					Operands:          []operatorv1alpha1.Operand{{Name: "etcd"}},
+				}}},
+			},
+		}
+	}
+	reconcileTemplate := func(r *Reconciler) *operatorv1alpha1.OperandRequestTemplate {
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "tenant"}})
+		Expect(err).ShouldNot(HaveOccurred())
+		t := &operatorv1alpha1.OperandRequestTemplate{}
+		Expect(r.Client.Get(ctx, types.NamespacedName{Name: "tenant"}, t)).Should(Succeed())
+		return t
+	}
+	getRequest := func(r *Reconciler, ns string) (*operatorv1alpha1.OperandRequest, error) {
+		request := &operatorv1alpha1.OperandRequest{}
+		return request, r.Client.Get(ctx, types.NamespacedName{Namespace: ns, Name: "tenant"}, request)
+	}
+
+	It("Should generate an OperandRequest in every matching namespace", func() {
+		terminating := namespace("tenant-c", map[string]string{"tenant": "true"})
+		terminating.Status.Phase = corev1.NamespaceTerminating
+		r := newReconciler(template(),
+			namespace("tenant-a", map[string]string{"tenant": "true"}),
+			namespace("other", nil),
+			namespace("openshift-monitoring", map[string]string{"tenant": "true"}),
+			terminating,
+		)
+		t := reconcileTemplate(r)
+
+		request, err := getRequest(r, "tenant-a")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(request.Labels).Should(HaveKeyWithValue(constant.OpreqTemplateLabel, "tenant"))
+		Expect(metav1.IsControlledBy(request, t)).Should(BeTrue())
+		Expect(request.Spec).Should(Equal(template().Spec.Template))
+		for _, ns := range []string{"other", "openshift-monitoring", "tenant-c"} {
+			_, err = getRequest(r, ns)
+			Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+		}
+		Expect(t.Status.OperandRequests).Should(Equal([]operatorv1alpha1.GeneratedRequest{{Namespace: "tenant-a", Name: "tenant"}}))
+		Expect(t.Status.Phase).Should(Equal(operatorv1alpha1.ClusterPhaseNone))
+	})
+
+	labeledRequest := func(ns string, owned bool) *operatorv1alpha1.OperandRequest {
+		request := &operatorv1alpha1.OperandRequest{ObjectMeta: metav1.ObjectMeta{
+			Name:      "tenant",
+			Namespace: ns,
+			Labels:    map[string]string{constant.OpreqTemplateLabel: "tenant"},
+		}}
+		if owned {
+			request.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(template(), operatorv1alpha1.GroupVersion.WithKind("OperandRequestTemplate"))}
+		}
+		return request
+	}
+
+	It("Should sync the template and delete the OperandRequest of a namespace which stops matching", func() {
+		r := newReconciler(template(),
+			namespace("tenant-a", nil),
+			namespace("tenant-b", map[string]string{"tenant": "true"}),
+			labeledRequest("tenant-a", true), labeledRequest("tenant-b", true),
+		)
+		reconcileTemplate(r)
+
+		_, err := getRequest(r, "tenant-a")
+		Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+		request, err := getRequest(r, "tenant-b")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(request.Spec).Should(Equal(template().Spec.Template))
+	})
+
+	It("Should not delete an OperandRequest which only has the label of the OperandRequestTemplate", func() {
+		r := newReconciler(template(), namespace("tenant-a", nil), labeledRequest("tenant-a", false))
+		reconcileTemplate(r)
+
+		_, err := getRequest(r, "tenant-a")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("Should reject an empty namespace selector and leave the generated OperandRequests unchanged", func() {
+		empty := template()
+		empty.Spec.NamespaceSelector = &metav1.LabelSelector{}
+		r := newReconciler(empty, namespace("tenant-a", nil), namespace("other", nil), labeledRequest("tenant-a", true))
+		t := reconcileTemplate(r)
+
+		Expect(t.Status.Phase).Should(Equal(operatorv1alpha1.ClusterPhaseFailed))
+		_, err := getRequest(r, "tenant-a")
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = getRequest(r, "other")
+		Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+	})
+
+	It("Should not take over an OperandRequest which isn't generated from the OperandRequestTemplate", func() {
+		existing := &operatorv1alpha1.OperandRequest{ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "tenant-a"}}
+		r := newReconciler(template(), namespace("tenant-a", map[string]string{"tenant": "true"}), existing)
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "tenant"}})
+		Expect(err).Should(MatchError(ContainSubstring("already exists and isn't generated from OperandRequestTemplate tenant")))
+	})
+})
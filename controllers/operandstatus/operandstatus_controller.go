@@ -0,0 +1,437 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operandstatus
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
+)
+
+// Reconciler aggregates the status of an operator across the cluster into the OperandStatus named after its package
+type Reconciler struct {
+	*deploy.ODLMOperator
+}
+
+// requestedOperator is an operand of an OperandRequest resolved to the operator in its OperandRegistry
+type requestedOperator struct {
+	request     *operatorv1alpha1.OperandRequest
+	registryKey types.NamespacedName
+	operand     string
+	opt         *operatorv1alpha1.Operator
+}
+
+// Reconcile creates, updates or deletes the OperandStatus of the package in the reconcile request.
+// The OperandStatus is deleted when no OperandRequest requests the package anymore.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	klog.V(2).Infof("Reconciling OperandStatus: %s", req.Name)
+
+	observed, err := r.aggregate(ctx, req.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	instance := &operatorv1alpha1.OperandStatus{}
+	if err := r.Reader.Get(ctx, types.NamespacedName{Name: req.Name}, instance); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to get OperandStatus %s", req.Name)
+		}
+		if observed == nil {
+			return ctrl.Result{}, nil
+		}
+		instance = &operatorv1alpha1.OperandStatus{ObjectMeta: metav1.ObjectMeta{Name: req.Name}}
+		if err := r.Create(ctx, instance); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to create OperandStatus %s", req.Name)
+		}
+	} else if observed == nil {
+		klog.V(2).Infof("Package %s is no longer requested, delete its OperandStatus", req.Name)
+		return ctrl.Result{}, client.IgnoreNotFound(r.Delete(ctx, instance))
+	}
+
+	observed.LastUpdateTime = instance.Status.LastUpdateTime
+	if reflect.DeepEqual(instance.Status, *observed) {
+		return ctrl.Result{}, nil
+	}
+	now := metav1.Now()
+	observed.LastUpdateTime = &now
+	originalInstance := instance.DeepCopy()
+	instance.Status = *observed
+	if err := r.Client.Status().Patch(ctx, instance, client.MergeFrom(originalInstance)); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to update the status of OperandStatus %s", req.Name)
+	}
+	klog.V(2).Infof("Finished reconciling OperandStatus: %s", req.Name)
+	return ctrl.Result{}, nil
+}
+
+// aggregate returns the observed state of the package, it returns nil when no OperandRequest requests the package
+func (r *Reconciler) aggregate(ctx context.Context, packageName string) (*operatorv1alpha1.OperandStatusObserved, error) {
+	requested, err := r.listRequestedOperators(ctx, packageName)
+	if err != nil || len(requested) == 0 {
+		return nil, err
+	}
+
+	observed := &operatorv1alpha1.OperandStatusObserved{PackageName: packageName}
+	for _, ro := range requested {
+		requestStatus := operatorv1alpha1.OperandStatusRequest{
+			Request:  operatorv1alpha1.ReconcileRequest{Namespace: ro.request.Namespace, Name: ro.request.Name},
+			Registry: operatorv1alpha1.ReconcileRequest{Namespace: ro.registryKey.Namespace, Name: ro.registryKey.Name},
+			Operand:  ro.operand,
+		}
+		for _, m := range ro.request.Status.Members {
+			if m.Name != ro.operand {
+				continue
+			}
+			requestStatus.Phase = m.Phase
+			requestStatus.Resources = m.OperandCRList
+			if ro.opt.IsClusterExtension() && m.InstalledBundle != "" {
+				observed.InstalledCSV = m.InstalledBundle
+				observed.Version = m.InstalledVersion
+			}
+		}
+		observed.Requests = append(observed.Requests, requestStatus)
+	}
+
+	opt := requested[0].opt
+	if opt.IsClusterExtension() {
+		observed.Kind = operatorv1alpha1.InventoryKindClusterExtension
+	} else {
+		observed.Kind = operatorv1alpha1.InventoryKindSubscription
+		if err := r.observeSubscription(ctx, opt, observed); err != nil {
+			return nil, err
+		}
+	}
+
+	copies, err := r.listBindInfoCopies(ctx, requested)
+	if err != nil {
+		return nil, err
+	}
+	observed.BindInfoCopies = copies
+	return observed, nil
+}
+
+// listRequestedOperators returns the operands of the OperandRequests resolved to an operator of the package,
+// sorted by the namespace/name of the OperandRequest and by operand.
+// The OperandRequests are read from the status of the OperandRegistries providing the package, which records the
+// OperandRequests of each operator, so the OperandRequests of the whole cluster aren't listed for every package.
+func (r *Reconciler) listRequestedOperators(ctx context.Context, packageName string) ([]requestedOperator, error) {
+	registryList := &operatorv1alpha1.OperandRegistryList{}
+	if err := r.Client.List(ctx, registryList); err != nil {
+		return nil, errors.Wrap(err, "failed to list OperandRegistries")
+	}
+
+	var requested []requestedOperator
+	requests := make(map[types.NamespacedName]*operatorv1alpha1.OperandRequest)
+	for i := range registryList.Items {
+		registry := &registryList.Items[i]
+		registryKey := types.NamespacedName{Namespace: registry.Namespace, Name: registry.Name}
+		if err := r.MergeBaseRegistry(ctx, registry); err != nil {
+			// The OperandRegistry reports its base which can't be merged, its operators aren't installed
+			baseErr := &deploy.BaseRegistryError{}
+			if errors.As(err, &baseErr) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to merge the base of OperandRegistry %s", registryKey.String())
+		}
+		for j := range registry.Spec.Operators {
+			opt := &registry.Spec.Operators[j]
+			if opt.PackageName != packageName {
+				continue
+			}
+			for _, reconcileRequest := range registry.Status.OperatorsStatus[opt.Name].ReconcileRequests {
+				requestKey := types.NamespacedName{Namespace: reconcileRequest.Namespace, Name: reconcileRequest.Name}
+				request, ok := requests[requestKey]
+				if !ok {
+					request = &operatorv1alpha1.OperandRequest{}
+					if err := r.Client.Get(ctx, requestKey, request); err != nil {
+						if !apierrors.IsNotFound(err) {
+							return nil, errors.Wrapf(err, "failed to get OperandRequest %s", requestKey.String())
+						}
+						request = nil
+					}
+					requests[requestKey] = request
+				}
+				// The status of the OperandRegistry is updated after the OperandRequests
+				if request == nil || !requestsOperand(request, registryKey, opt.Name) {
+					continue
+				}
+				requested = append(requested, requestedOperator{request: request, registryKey: registryKey, operand: opt.Name, opt: opt})
+			}
+		}
+	}
+	sort.SliceStable(requested, func(i, j int) bool {
+		a, b := requested[i], requested[j]
+		if a.request.Namespace+"/"+a.request.Name != b.request.Namespace+"/"+b.request.Name {
+			return a.request.Namespace+"/"+a.request.Name < b.request.Namespace+"/"+b.request.Name
+		}
+		return a.operand < b.operand
+	})
+	return requested, nil
+}
+
+// requestsOperand returns true when the OperandRequest requests the operand from the OperandRegistry
+func requestsOperand(request *operatorv1alpha1.OperandRequest, registryKey types.NamespacedName, operand string) bool {
+	for _, req := range request.Spec.Requests {
+		for _, o := range req.Operands {
+			if o.Name == operand && request.GetOperandRegistryKey(req, o.Name) == registryKey {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// observeSubscription records the health of the Subscription and the installed ClusterServiceVersion
+func (r *Reconciler) observeSubscription(ctx context.Context, opt *operatorv1alpha1.Operator, observed *operatorv1alpha1.OperandStatusObserved) error {
+	sub, err := r.GetSubscription(ctx, opt.Name, r.GetOperatorNamespace(opt.InstallMode, opt.Namespace), opt.PackageName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if sub == nil {
+		return nil
+	}
+
+	observed.Subscription = subscriptionHealth(sub)
+	observed.InstalledCSV = sub.Status.InstalledCSV
+	if observed.InstalledCSV == "" {
+		return nil
+	}
+	csv := &olmv1alpha1.ClusterServiceVersion{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: sub.Namespace, Name: observed.InstalledCSV}, csv); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get ClusterServiceVersion %s/%s", sub.Namespace, observed.InstalledCSV)
+	}
+	observed.Version = csv.Spec.Version.String()
+	return nil
+}
+
+// subscriptionHealth returns the health of the Subscription from its failing conditions
+func subscriptionHealth(sub *olmv1alpha1.Subscription) *operatorv1alpha1.SubscriptionHealth {
+	health := &operatorv1alpha1.SubscriptionHealth{
+		Namespace: sub.Namespace,
+		Name:      sub.Name,
+		State:     string(sub.Status.State),
+		Healthy:   true,
+	}
+	var messages []string
+	for _, t := range []olmv1alpha1.SubscriptionConditionType{
+		olmv1alpha1.SubscriptionCatalogSourcesUnhealthy,
+		olmv1alpha1.SubscriptionInstallPlanFailed,
+	} {
+		c := sub.Status.GetCondition(t)
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		health.Healthy = false
+		if c.Message != "" {
+			messages = append(messages, c.Message)
+		} else {
+			messages = append(messages, string(t))
+		}
+	}
+	health.Message = strings.Join(messages, "; ")
+	return health
+}
+
+// listBindInfoCopies returns the Secrets and ConfigMaps copied by the OperandBindInfos of the requested operands.
+// The OperandBindInfos are listed by the label of their OperandRegistry, and the copies in the namespaces of the OperandRequests.
+func (r *Reconciler) listBindInfoCopies(ctx context.Context, requested []requestedOperator) ([]operatorv1alpha1.BindInfoCopy, error) {
+	// the namespaces of the OperandRequests of each operand of each OperandRegistry
+	namespaces := make(map[types.NamespacedName]map[string]map[string]bool)
+	for _, ro := range requested {
+		if namespaces[ro.registryKey] == nil {
+			namespaces[ro.registryKey] = make(map[string]map[string]bool)
+		}
+		if namespaces[ro.registryKey][ro.operand] == nil {
+			namespaces[ro.registryKey][ro.operand] = make(map[string]bool)
+		}
+		namespaces[ro.registryKey][ro.operand][ro.request.Namespace] = true
+	}
+
+	var copies []operatorv1alpha1.BindInfoCopy
+	for registryKey, operands := range namespaces {
+		bindInfoList := &operatorv1alpha1.OperandBindInfoList{}
+		opts := []client.ListOption{
+			client.MatchingLabels(map[string]string{registryKey.Namespace + "." + registryKey.Name + "/registry": "true"}),
+		}
+		if err := r.Client.List(ctx, bindInfoList, opts...); err != nil {
+			return nil, errors.Wrapf(err, "failed to list the OperandBindInfos of OperandRegistry %s", registryKey.String())
+		}
+		for _, bindInfo := range bindInfoList.Items {
+			if bindInfo.GetRegistryKey() != registryKey {
+				continue
+			}
+			for ns := range operands[bindInfo.Spec.Operand] {
+				nsCopies, err := r.listCopies(ctx, &bindInfo, ns)
+				if err != nil {
+					return nil, err
+				}
+				copies = append(copies, nsCopies...)
+			}
+		}
+	}
+	sort.SliceStable(copies, func(i, j int) bool {
+		a, b := copies[i], copies[j]
+		return a.Kind+"/"+a.Namespace+"/"+a.Name < b.Kind+"/"+b.Namespace+"/"+b.Name
+	})
+	return copies, nil
+}
+
+// listCopies returns the Secrets and ConfigMaps copied by the OperandBindInfo to the namespace
+func (r *Reconciler) listCopies(ctx context.Context, bindInfo *operatorv1alpha1.OperandBindInfo, namespace string) ([]operatorv1alpha1.BindInfoCopy, error) {
+	bindInfoRef := operatorv1alpha1.ReconcileRequest{Namespace: bindInfo.Namespace, Name: bindInfo.Name}
+	opts := []client.ListOption{
+		client.InNamespace(namespace),
+		client.MatchingLabels(map[string]string{bindInfo.Namespace + "." + bindInfo.Name + "/bindinfo": "true"}),
+	}
+	var copies []operatorv1alpha1.BindInfoCopy
+	secretList := &corev1.SecretList{}
+	if err := r.Client.List(ctx, secretList, opts...); err != nil {
+		return nil, errors.Wrapf(err, "failed to list the Secrets copied by OperandBindInfo %s/%s", bindInfo.Namespace, bindInfo.Name)
+	}
+	for _, secret := range secretList.Items {
+		copies = append(copies, operatorv1alpha1.BindInfoCopy{BindInfo: bindInfoRef, Kind: "Secret", Namespace: secret.Namespace, Name: secret.Name})
+	}
+	cmList := &corev1.ConfigMapList{}
+	if err := r.Client.List(ctx, cmList, opts...); err != nil {
+		return nil, errors.Wrapf(err, "failed to list the ConfigMaps copied by OperandBindInfo %s/%s", bindInfo.Namespace, bindInfo.Name)
+	}
+	for _, cm := range cmList.Items {
+		copies = append(copies, operatorv1alpha1.BindInfoCopy{BindInfo: bindInfoRef, Kind: "ConfigMap", Namespace: cm.Namespace, Name: cm.Name})
+	}
+	return copies, nil
+}
+
+// getRegistry gets the OperandRegistry with the operators merged onto the ones of its base
+func (r *Reconciler) getRegistry(ctx context.Context, registryKey types.NamespacedName) (*operatorv1alpha1.OperandRegistry, error) {
+	registry := &operatorv1alpha1.OperandRegistry{}
+	if err := r.Client.Get(ctx, registryKey, registry); err != nil {
+		return nil, err
+	}
+	if err := r.MergeBaseRegistry(ctx, registry); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// getPackageName returns the package of the operand in the OperandRegistry, or the empty string when it isn't found
+func (r *Reconciler) getPackageName(ctx context.Context, registryKey types.NamespacedName, operand string) string {
+	registry, err := r.getRegistry(ctx, registryKey)
+	if err != nil {
+		return ""
+	}
+	if opt := registry.GetOperator(operand); opt != nil {
+		return opt.PackageName
+	}
+	return ""
+}
+
+func (r *Reconciler) getRequestToPackageMapper() handler.MapFunc {
+	ctx := context.Background()
+	return func(object client.Object) []reconcile.Request {
+		request := object.(*operatorv1alpha1.OperandRequest)
+		packages := make(map[string]bool)
+		for _, req := range request.Spec.Requests {
+			for _, operand := range req.Operands {
+				if packageName := r.getPackageName(ctx, request.GetOperandRegistryKey(req, operand.Name), operand.Name); packageName != "" {
+					packages[packageName] = true
+				}
+			}
+		}
+		return packageRequests(packages)
+	}
+}
+
+func (r *Reconciler) getRegistryToPackageMapper() handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
+		registry := object.(*operatorv1alpha1.OperandRegistry)
+		operators := registry.Spec.Operators
+		if registry.Status.EffectiveOperators != nil {
+			operators = registry.Status.EffectiveOperators
+		}
+		packages := make(map[string]bool)
+		for _, o := range operators {
+			if o.PackageName != "" {
+				packages[o.PackageName] = true
+			}
+		}
+		return packageRequests(packages)
+	}
+}
+
+func (r *Reconciler) getBindInfoToPackageMapper() handler.MapFunc {
+	ctx := context.Background()
+	return func(object client.Object) []reconcile.Request {
+		bindInfo := object.(*operatorv1alpha1.OperandBindInfo)
+		packageName := r.getPackageName(ctx, bindInfo.GetRegistryKey(), bindInfo.Spec.Operand)
+		if packageName == "" {
+			return nil
+		}
+		return packageRequests(map[string]bool{packageName: true})
+	}
+}
+
+func getSubToPackageMapper() handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
+		sub := object.(*olmv1alpha1.Subscription)
+		if sub.Spec == nil || sub.Spec.Package == "" {
+			return nil
+		}
+		return packageRequests(map[string]bool{sub.Spec.Package: true})
+	}
+}
+
+func packageRequests(packages map[string]bool) []reconcile.Request {
+	requests := []reconcile.Request{}
+	for packageName := range packages {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: packageName}})
+	}
+	return requests
+}
+
+// SetupWithManager adds OperandStatus controller to the manager.
+// The OperandStatus has no spec, it is reconciled from the changes of the resources it aggregates.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("operandstatus").
+		Watches(&source.Kind{Type: &operatorv1alpha1.OperandRequest{}}, handler.EnqueueRequestsFromMapFunc(r.getRequestToPackageMapper())).
+		Watches(&source.Kind{Type: &operatorv1alpha1.OperandRegistry{}}, handler.EnqueueRequestsFromMapFunc(r.getRegistryToPackageMapper())).
+		Watches(&source.Kind{Type: &operatorv1alpha1.OperandBindInfo{}}, handler.EnqueueRequestsFromMapFunc(r.getBindInfoToPackageMapper())).
+		Watches(&source.Kind{Type: &olmv1alpha1.Subscription{}}, handler.EnqueueRequestsFromMapFunc(getSubToPackageMapper())).
+		Complete(r)
+}
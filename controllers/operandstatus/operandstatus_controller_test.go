@@ -0,0 +1,159 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operandstatus
+
+import (
+	"context"
+
+	semver "github.com/blang/semver/v4"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/operator-framework/api/pkg/lib/version"
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/testutil"
+)
+
+var _ = Describe("OperandStatus", func() {
+	ctx := context.Background()
+
+	newReconciler := func(objs ...client.Object) *Reconciler {
+		c := testutil.NewFakeClient(objs...)
+		return &Reconciler{ODLMOperator: &deploy.ODLMOperator{Client: c, Reader: c}}
+	}
+	registry := &operatorv1alpha1.OperandRegistry{
+		ObjectMeta: metav1.ObjectMeta{Name: "common-service", Namespace: "ibm-common-services"},
+		Spec: operatorv1alpha1.OperandRegistrySpec{Operators: []operatorv1alpha1.Operator{
+			{Name: "etcd", Namespace: "etcd-ns", PackageName: "etcd", Channel: "alpha"},
+		}},
+		Status: operatorv1alpha1.OperandRegistryStatus{OperatorsStatus: map[string]operatorv1alpha1.OperatorStatus{
+			"etcd": {ReconcileRequests: []operatorv1alpha1.ReconcileRequest{
+				{Namespace: "app-ns", Name: "example"},
+				{Namespace: "another-ns", Name: "example"},
+				{Namespace: "gone-ns", Name: "example"},
+			}},
+		}},
+	}
+	request := func(namespace string) *operatorv1alpha1.OperandRequest {
+		return &operatorv1alpha1.OperandRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: namespace},
+			Spec: operatorv1alpha1.OperandRequestSpec{Requests: []operatorv1alpha1.Request{{
+				Registry:          "common-service",
+				RegistryNamespace: "ibm-common-services",
+				Operands:          []operatorv1alpha1.Operand{{Name: "etcd"}},
+			}}},
+			Status: operatorv1alpha1.OperandRequestStatus{Members: []operatorv1alpha1.MemberStatus{{
+				Name:          "etcd",
+				Phase:         operatorv1alpha1.MemberPhase{OperatorPhase: operatorv1alpha1.OperatorRunning, OperandPhase: operatorv1alpha1.ServiceRunning},
+				OperandCRList: []operatorv1alpha1.OperandCRMember{{Name: "example-etcd", Kind: "EtcdCluster", APIVersion: "etcd.database.coreos.com/v1beta2"}},
+			}}},
+		}
+	}
+	sub := &olmv1alpha1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "etcd-ns"},
+		Spec:       &olmv1alpha1.SubscriptionSpec{Package: "etcd", Channel: "alpha"},
+		Status: olmv1alpha1.SubscriptionStatus{
+			State:        olmv1alpha1.SubscriptionStateAtLatest,
+			InstalledCSV: "etcdoperator.v0.9.4",
+			Conditions: []olmv1alpha1.SubscriptionCondition{{
+				Type:    olmv1alpha1.SubscriptionCatalogSourcesUnhealthy,
+				Status:  corev1.ConditionTrue,
+				Message: "targeted catalogsource openshift-marketplace/community-operators unhealthy",
+			}},
+		},
+	}
+	csv := &olmv1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "etcdoperator.v0.9.4", Namespace: "etcd-ns"},
+		Spec:       olmv1alpha1.ClusterServiceVersionSpec{Version: version.OperatorVersion{Version: semver.MustParse("0.9.4")}},
+	}
+	bindInfo := &operatorv1alpha1.OperandBindInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "etcd-bindinfo", Namespace: "ibm-common-services", Labels: map[string]string{"ibm-common-services.common-service/registry": "true"}},
+		Spec:       operatorv1alpha1.OperandBindInfoSpec{Operand: "etcd", Registry: "common-service"},
+	}
+	secretCopy := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "etcd-secret", Namespace: "app-ns", Labels: map[string]string{"ibm-common-services.etcd-bindinfo/bindinfo": "true"}},
+	}
+
+	// a copy left in a namespace without an OperandRequest of the operand
+	staleCopy := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "etcd-secret", Namespace: "stale-ns", Labels: map[string]string{"ibm-common-services.etcd-bindinfo/bindinfo": "true"}},
+	}
+
+	It("Should aggregate the requests, the Subscription and the bindinfo copies of the package", func() {
+		r := newReconciler(registry, request("app-ns"), request("another-ns"), sub, csv, bindInfo, secretCopy, staleCopy)
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "etcd"}})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		status := &operatorv1alpha1.OperandStatus{}
+		Expect(r.Client.Get(ctx, types.NamespacedName{Name: "etcd"}, status)).Should(Succeed())
+		Expect(status.Status.PackageName).Should(Equal("etcd"))
+		Expect(status.Status.Kind).Should(Equal(operatorv1alpha1.InventoryKindSubscription))
+		Expect(status.Status.InstalledCSV).Should(Equal("etcdoperator.v0.9.4"))
+		Expect(status.Status.Version).Should(Equal("0.9.4"))
+		Expect(status.Status.Subscription.Healthy).Should(BeFalse())
+		Expect(status.Status.Subscription.Message).Should(ContainSubstring("unhealthy"))
+		Expect(status.Status.Requests).Should(HaveLen(2))
+		Expect(status.Status.Requests[0].Request.Namespace).Should(Equal("another-ns"))
+		Expect(status.Status.Requests[1].Resources).Should(HaveLen(1))
+		Expect(status.Status.Requests[1].Phase.OperatorPhase).Should(Equal(operatorv1alpha1.OperatorRunning))
+		Expect(status.Status.BindInfoCopies).Should(Equal([]operatorv1alpha1.BindInfoCopy{{
+			BindInfo:  operatorv1alpha1.ReconcileRequest{Namespace: "ibm-common-services", Name: "etcd-bindinfo"},
+			Kind:      "Secret",
+			Namespace: "app-ns",
+			Name:      "etcd-secret",
+		}}))
+	})
+
+	It("Should aggregate the operators inherited from the base OperandRegistry", func() {
+		overlay := &operatorv1alpha1.OperandRegistry{
+			ObjectMeta: metav1.ObjectMeta{Name: "common-service-dev", Namespace: "ibm-common-services"},
+			Spec: operatorv1alpha1.OperandRegistrySpec{
+				Extends:   &operatorv1alpha1.RegistryReference{Name: "common-service"},
+				Operators: []operatorv1alpha1.Operator{{Name: "etcd", Channel: "beta"}},
+			},
+			Status: operatorv1alpha1.OperandRegistryStatus{OperatorsStatus: map[string]operatorv1alpha1.OperatorStatus{
+				"etcd": {ReconcileRequests: []operatorv1alpha1.ReconcileRequest{{Namespace: "dev-ns", Name: "example"}}},
+			}},
+		}
+		devRequest := request("dev-ns")
+		devRequest.Spec.Requests[0].Registry = "common-service-dev"
+		r := newReconciler(registry, overlay, devRequest)
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "etcd"}})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		status := &operatorv1alpha1.OperandStatus{}
+		Expect(r.Client.Get(ctx, types.NamespacedName{Name: "etcd"}, status)).Should(Succeed())
+		Expect(status.Status.Requests).Should(HaveLen(1))
+		Expect(status.Status.Requests[0].Registry).Should(Equal(operatorv1alpha1.ReconcileRequest{Namespace: "ibm-common-services", Name: "common-service-dev"}))
+		Expect(r.getPackageName(ctx, types.NamespacedName{Namespace: "ibm-common-services", Name: "common-service-dev"}, "etcd")).Should(Equal("etcd"))
+	})
+
+	It("Should delete the OperandStatus when the package is no longer requested", func() {
+		r := newReconciler(registry, &operatorv1alpha1.OperandStatus{ObjectMeta: metav1.ObjectMeta{Name: "etcd"}})
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "etcd"}})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(r.Client.Get(ctx, types.NamespacedName{Name: "etcd"}, &operatorv1alpha1.OperandStatus{})).ShouldNot(Succeed())
+	})
+})
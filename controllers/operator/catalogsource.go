@@ -0,0 +1,89 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	constant "github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+)
+
+// CatalogSourceStateNotFound is the state reported for a CatalogSource which doesn't exist
+const CatalogSourceStateNotFound = "NotFound"
+
+// CheckCatalogSourceHealth checks the gRPC connection state of the CatalogSource.
+// It returns false and the observed state when the CatalogSource can't serve the packages.
+// The CatalogSource is read with the API reader, as the isolated mode doesn't cache the marketplace namespace.
+func (m *ODLMOperator) CheckCatalogSourceHealth(ctx context.Context, name, namespace string) (healthy bool, state string, err error) {
+	cs := &olmv1alpha1.CatalogSource{}
+	if err := m.Reader.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, cs); err != nil {
+		if apierrors.IsNotFound(err) {
+			packageManifests.forgetCatalog(name, namespace)
+			return false, CatalogSourceStateNotFound, nil
+		}
+		return false, "", errors.Wrapf(err, "failed to get CatalogSource %s/%s", namespace, name)
+	}
+	packageManifests.observeCatalog(cs)
+	healthy, state = IsCatalogSourceHealthy(cs)
+	return healthy, state, nil
+}
+
+// IsCatalogSourceHealthy returns whether the gRPC connection of the CatalogSource is usable and its last observed state.
+// A CatalogSource without a gRPC connection state, e.g. a ConfigMap based one, is treated as healthy.
+func IsCatalogSourceHealthy(cs *olmv1alpha1.CatalogSource) (bool, string) {
+	state := getCatalogSourceState(cs)
+	switch state {
+	case "", constant.CatalogSourceStateReady, constant.CatalogSourceStateIdle:
+		return true, state
+	default:
+		return false, state
+	}
+}
+
+func getCatalogSourceState(cs *olmv1alpha1.CatalogSource) string {
+	if cs.Status.GRPCConnectionState == nil {
+		return ""
+	}
+	return cs.Status.GRPCConnectionState.LastObservedState
+}
+
+// CatalogSourceStatePredicate filters the CatalogSource events to the ones changing the gRPC connection state
+func CatalogSourceStatePredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldObject, okOld := e.ObjectOld.(*olmv1alpha1.CatalogSource)
+			newObject, okNew := e.ObjectNew.(*olmv1alpha1.CatalogSource)
+			if !okOld || !okNew {
+				return false
+			}
+			return getCatalogSourceState(oldObject) != getCatalogSourceState(newObject)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
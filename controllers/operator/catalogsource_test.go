@@ -0,0 +1,93 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"sort"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func catalogSourceWithState(state string) *olmv1alpha1.CatalogSource {
+	cs := &olmv1alpha1.CatalogSource{}
+	if state != "" {
+		cs.Status.GRPCConnectionState = &olmv1alpha1.GRPCConnectionState{LastObservedState: state}
+	}
+	return cs
+}
+
+var _ = Describe("CatalogSource", func() {
+
+	Context("Check the CatalogSource health", func() {
+		It("Should be healthy when the gRPC connection is ready or idle", func() {
+			healthy, state := IsCatalogSourceHealthy(catalogSourceWithState("READY"))
+			Expect(healthy).Should(BeTrue())
+			Expect(state).Should(Equal("READY"))
+			healthy, _ = IsCatalogSourceHealthy(catalogSourceWithState("IDLE"))
+			Expect(healthy).Should(BeTrue())
+		})
+
+		It("Should be healthy when there is no gRPC connection state", func() {
+			healthy, _ := IsCatalogSourceHealthy(catalogSourceWithState(""))
+			Expect(healthy).Should(BeTrue())
+		})
+
+		It("Should be unhealthy when the gRPC connection is failing", func() {
+			healthy, state := IsCatalogSourceHealthy(catalogSourceWithState("TRANSIENT_FAILURE"))
+			Expect(healthy).Should(BeFalse())
+			Expect(state).Should(Equal("TRANSIENT_FAILURE"))
+			healthy, _ = IsCatalogSourceHealthy(catalogSourceWithState("CONNECTING"))
+			Expect(healthy).Should(BeFalse())
+		})
+	})
+
+	Context("Sort the CatalogSources providing the same package", func() {
+		It("Should prefer the CatalogSource in the OperandRegistry namespace by default", func() {
+			candidates := []CatalogSource{
+				{Name: "community-operators", Namespace: "openshift-marketplace", OpNamespace: "ibm-common-services", RegistryNamespace: "ibm-common-services"},
+				{Name: "opencloud-operators", Namespace: "ibm-common-services", OpNamespace: "ibm-common-services", RegistryNamespace: "ibm-common-services"},
+			}
+			sort.Sort(sortableCatalogSource(candidates))
+			Expect(candidates[0].Name).Should(Equal("opencloud-operators"))
+			_, reason := compareCatalogSource(candidates[0], candidates[1])
+			Expect(reason).Should(ContainSubstring("OperandRegistry namespace"))
+		})
+
+		It("Should prefer the CatalogSource matching the earlier pattern, then the label selector, then the higher priority", func() {
+			candidates := []CatalogSource{
+				{Name: "opencloud-operators", Namespace: "ibm-common-services", RegistryNamespace: "ibm-common-services", PatternIndex: 2},
+				{Name: "certified-operators", Namespace: "openshift-marketplace", PatternIndex: 2, SelectorMatched: true},
+				{Name: "private-operators", Namespace: "private-catalogs", PatternIndex: 0, Pattern: "private-catalogs/*"},
+				{Name: "redhat-operators", Namespace: "openshift-marketplace", PatternIndex: 2, SelectorMatched: true, Priority: 10},
+			}
+			sort.Sort(sortableCatalogSource(candidates))
+			Expect(candidates[0].Name).Should(Equal("private-operators"))
+			Expect(candidates[1].Name).Should(Equal("redhat-operators"))
+			Expect(candidates[2].Name).Should(Equal("certified-operators"))
+			Expect(candidates[3].Name).Should(Equal("opencloud-operators"))
+
+			_, reason := compareCatalogSource(candidates[0], candidates[1])
+			Expect(reason).Should(ContainSubstring(`matches pattern "private-catalogs/*"`))
+			_, reason = compareCatalogSource(candidates[1], candidates[2])
+			Expect(reason).Should(ContainSubstring("highest priority 10"))
+			_, reason = compareCatalogSource(candidates[2], candidates[3])
+			Expect(reason).Should(ContainSubstring("label selector"))
+		})
+	})
+})
@@ -0,0 +1,136 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+
+	apiv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+)
+
+// ClusterExtensionGVK is the GroupVersionKind of the OLM v1 ClusterExtension
+var ClusterExtensionGVK = schema.GroupVersionKind{Group: "olm.operatorframework.io", Version: "v1", Kind: "ClusterExtension"}
+
+const (
+	// ClusterExtensionInstalled is the condition type set by OLM v1 when a bundle is installed
+	ClusterExtensionInstalled = "Installed"
+	// ClusterExtensionProgressing is the condition type set by OLM v1 while a bundle is rolling out
+	ClusterExtensionProgressing = "Progressing"
+	// ClusterExtensionReasonBlocked is the Progressing reason set by OLM v1 when the rollout can't proceed
+	ClusterExtensionReasonBlocked = "Blocked"
+)
+
+// NewClusterExtension returns an empty unstructured ClusterExtension with the given name
+func NewClusterExtension(name string) *unstructured.Unstructured {
+	ce := &unstructured.Unstructured{}
+	ce.SetGroupVersionKind(ClusterExtensionGVK)
+	ce.SetName(name)
+	return ce
+}
+
+// GetClusterExtension gets the cluster scoped ClusterExtension by name
+func (m *ODLMOperator) GetClusterExtension(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	klog.V(3).Infof("Fetch ClusterExtension: %s", name)
+	ce := NewClusterExtension(name)
+	if err := m.Reader.Get(ctx, types.NamespacedName{Name: name}, ce); err != nil {
+		return nil, err
+	}
+	return ce, nil
+}
+
+// SetClusterExtensionSpec sets the desired spec of the ClusterExtension from the OperandRegistry entry
+func SetClusterExtensionSpec(ce *unstructured.Unstructured, o *apiv1alpha1.Operator) error {
+	catalog := map[string]interface{}{
+		"packageName": o.PackageName,
+	}
+	if o.Channel != "" {
+		catalog["channels"] = []interface{}{o.Channel}
+	}
+	if o.Version != "" {
+		catalog["version"] = o.Version
+	}
+	spec := map[string]interface{}{
+		"namespace": o.Namespace,
+		"serviceAccount": map[string]interface{}{
+			"name": o.ServiceAccountName,
+		},
+		"source": map[string]interface{}{
+			"sourceType": "Catalog",
+			"catalog":    catalog,
+		},
+	}
+	return unstructured.SetNestedMap(ce.Object, spec, "spec")
+}
+
+// GetClusterExtensionInstalledBundle returns the name and version of the bundle installed by the ClusterExtension
+func GetClusterExtensionInstalledBundle(ce *unstructured.Unstructured) (string, string) {
+	name, _, _ := unstructured.NestedString(ce.Object, "status", "install", "bundle", "name")
+	version, _, _ := unstructured.NestedString(ce.Object, "status", "install", "bundle", "version")
+	return name, version
+}
+
+// GetClusterExtensionConditions converts the ClusterExtension conditions into ODLM conditions
+func GetClusterExtensionConditions(ce *unstructured.Unstructured) []apiv1alpha1.Condition {
+	rawConditions, _, _ := unstructured.NestedSlice(ce.Object, "status", "conditions")
+	var conditions []apiv1alpha1.Condition
+	for _, rc := range rawConditions {
+		c, ok := rc.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condType, _, _ := unstructured.NestedString(c, "type")
+		status, _, _ := unstructured.NestedString(c, "status")
+		reason, _, _ := unstructured.NestedString(c, "reason")
+		message, _, _ := unstructured.NestedString(c, "message")
+		transitionTime, _, _ := unstructured.NestedString(c, "lastTransitionTime")
+		conditions = append(conditions, apiv1alpha1.Condition{
+			Type:               apiv1alpha1.ConditionType(condType),
+			Status:             corev1.ConditionStatus(status),
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: transitionTime,
+			LastUpdateTime:     transitionTime,
+		})
+	}
+	return conditions
+}
+
+// GetClusterExtensionPhase derives the operator phase from the ClusterExtension conditions
+func GetClusterExtensionPhase(ce *unstructured.Unstructured) apiv1alpha1.OperatorPhase {
+	var installed, blocked bool
+	for _, c := range GetClusterExtensionConditions(ce) {
+		switch string(c.Type) {
+		case ClusterExtensionInstalled:
+			installed = c.Status == corev1.ConditionTrue
+		case ClusterExtensionProgressing:
+			blocked = c.Status == corev1.ConditionFalse && c.Reason == ClusterExtensionReasonBlocked
+		}
+	}
+	if installed {
+		return apiv1alpha1.OperatorRunning
+	}
+	if blocked {
+		return apiv1alpha1.OperatorFailed
+	}
+	return apiv1alpha1.OperatorInstalling
+}
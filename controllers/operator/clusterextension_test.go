@@ -0,0 +1,91 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	apiv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+)
+
+func clusterExtensionWithStatus(status map[string]interface{}) *unstructured.Unstructured {
+	ce := NewClusterExtension("etcd")
+	ce.Object["status"] = status
+	return ce
+}
+
+var _ = Describe("ClusterExtension", func() {
+
+	Context("Generate the ClusterExtension spec", func() {
+		It("Should set the package, channel and service account from the OperandRegistry", func() {
+			ce := NewClusterExtension("etcd")
+			Expect(SetClusterExtensionSpec(ce, &apiv1alpha1.Operator{
+				Name:               "etcd",
+				Namespace:          "etcd-ns",
+				PackageName:        "etcd",
+				Channel:            "singlenamespace-alpha",
+				ServiceAccountName: "etcd-installer",
+			})).Should(Succeed())
+
+			namespace, _, _ := unstructured.NestedString(ce.Object, "spec", "namespace")
+			Expect(namespace).Should(Equal("etcd-ns"))
+			serviceAccount, _, _ := unstructured.NestedString(ce.Object, "spec", "serviceAccount", "name")
+			Expect(serviceAccount).Should(Equal("etcd-installer"))
+			packageName, _, _ := unstructured.NestedString(ce.Object, "spec", "source", "catalog", "packageName")
+			Expect(packageName).Should(Equal("etcd"))
+			channels, _, _ := unstructured.NestedStringSlice(ce.Object, "spec", "source", "catalog", "channels")
+			Expect(channels).Should(Equal([]string{"singlenamespace-alpha"}))
+			_, found, _ := unstructured.NestedString(ce.Object, "spec", "source", "catalog", "version")
+			Expect(found).Should(BeFalse())
+		})
+	})
+
+	Context("Map the ClusterExtension status", func() {
+		It("Should be Running when the bundle is installed", func() {
+			ce := clusterExtensionWithStatus(map[string]interface{}{
+				"install": map[string]interface{}{
+					"bundle": map[string]interface{}{"name": "etcd.v0.9.4", "version": "0.9.4"},
+				},
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Installed", "status": "True", "reason": "Succeeded"},
+					map[string]interface{}{"type": "Progressing", "status": "True", "reason": "Succeeded"},
+				},
+			})
+			name, version := GetClusterExtensionInstalledBundle(ce)
+			Expect(name).Should(Equal("etcd.v0.9.4"))
+			Expect(version).Should(Equal("0.9.4"))
+			Expect(GetClusterExtensionConditions(ce)).Should(HaveLen(2))
+			Expect(GetClusterExtensionPhase(ce)).Should(Equal(apiv1alpha1.OperatorRunning))
+		})
+
+		It("Should be Failed when the rollout is blocked", func() {
+			ce := clusterExtensionWithStatus(map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Installed", "status": "False", "reason": "Failed"},
+					map[string]interface{}{"type": "Progressing", "status": "False", "reason": "Blocked"},
+				},
+			})
+			Expect(GetClusterExtensionPhase(ce)).Should(Equal(apiv1alpha1.OperatorFailed))
+		})
+
+		It("Should be Installing without conditions", func() {
+			Expect(GetClusterExtensionPhase(NewClusterExtension("etcd"))).Should(Equal(apiv1alpha1.OperatorInstalling))
+		})
+	})
+})
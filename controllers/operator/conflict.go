@@ -0,0 +1,165 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	constant "github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+)
+
+// ConflictPolicyKey is the key of the conflict policy in the ODLM ConfigMap, it is also the name of the manager flag.
+const ConflictPolicyKey = "registry-conflict-policy"
+
+// ConflictPolicy chooses the OperandRegistry managing an operator requested from several OperandRegistries.
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyOldest chooses the oldest OperandRegistry.
+	ConflictPolicyOldest ConflictPolicy = "Oldest"
+	// ConflictPolicyPriority chooses the OperandRegistry with the highest priority, then the oldest one.
+	ConflictPolicyPriority ConflictPolicy = "Priority"
+	// ConflictPolicyRefuse doesn't choose any OperandRegistry until the conflict is resolved.
+	ConflictPolicyRefuse ConflictPolicy = "Refuse"
+)
+
+var configAnnotation = regexp.MustCompile(`^([^./]+)\.([^/]+)/config$`)
+
+// GlobalConflictPolicy returns the policy resolving the conflicts between OperandRegistries,
+// the ConflictPolicy of the ODLMOperator or the Oldest policy when it isn't set.
+func (m *ODLMOperator) GlobalConflictPolicy() ConflictPolicy {
+	if m.ConflictPolicy == "" {
+		return ConflictPolicyOldest
+	}
+	return m.ConflictPolicy
+}
+
+// ParseConflictPolicy converts a string into a conflict policy, the empty string is converted into the empty policy.
+func ParseConflictPolicy(value string) (ConflictPolicy, error) {
+	switch policy := ConflictPolicy(value); policy {
+	case "", ConflictPolicyOldest, ConflictPolicyPriority, ConflictPolicyRefuse:
+		return policy, nil
+	default:
+		return "", errors.Errorf("%s must be one of %s, %s or %s, got %s", ConflictPolicyKey, ConflictPolicyOldest, ConflictPolicyPriority, ConflictPolicyRefuse, value)
+	}
+}
+
+// LoadConflictPolicy reads the conflict policy from the ODLM ConfigMap in the namespace, it returns the empty policy when it isn't set.
+// The ConfigMap is only read at startup, a change of the policy takes effect when ODLM restarts.
+func LoadConflictPolicy(ctx context.Context, reader client.Reader, namespace string) (ConflictPolicy, error) {
+	cm := &corev1.ConfigMap{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: constant.ODLMConfigMapName, Namespace: namespace}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "failed to get ConfigMap %s/%s", namespace, constant.ODLMConfigMapName)
+	}
+	policy, err := ParseConflictPolicy(cm.Data[ConflictPolicyKey])
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid ConfigMap %s/%s", namespace, constant.ODLMConfigMapName)
+	}
+	return policy, nil
+}
+
+// GetRequestingRegistries returns the OperandRegistries requesting the operator from the owners of the OperandInventory,
+// or from the config annotations of the resources created before the OperandInventory.
+func (m *ODLMOperator) GetRequestingRegistries(ctx context.Context, inventoryKey types.NamespacedName, annotations map[string]string) ([]types.NamespacedName, error) {
+	inventory, err := m.GetOperandInventory(ctx, inventoryKey)
+	if err == nil {
+		return inventory.GetRegistries(), nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "failed to get OperandInventory %s", inventoryKey.String())
+	}
+
+	var registries []types.NamespacedName
+	for anno := range annotations {
+		if nsName := configAnnotation.FindStringSubmatch(anno); nsName != nil {
+			registries = append(registries, types.NamespacedName{Namespace: nsName[1], Name: nsName[2]})
+		}
+	}
+	sort.Slice(registries, func(i, j int) bool {
+		return registries[i].String() < registries[j].String()
+	})
+	return registries, nil
+}
+
+// ResolveManagingRegistry chooses the OperandRegistry managing the operator among the requesting ones by the global conflict policy.
+// The OperandRegistries that no longer exist are ignored. It returns nil when no OperandRegistry manages the operator.
+func (m *ODLMOperator) ResolveManagingRegistry(ctx context.Context, registries []types.NamespacedName) (*types.NamespacedName, error) {
+	if len(registries) <= 1 {
+		if len(registries) == 0 {
+			return nil, nil
+		}
+		return &registries[0], nil
+	}
+
+	var candidates []operatorv1alpha1.OperandRegistry
+	for _, key := range registries {
+		registry := &operatorv1alpha1.OperandRegistry{}
+		if err := m.Client.Get(ctx, key, registry); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get OperandRegistry %s", key.String())
+		}
+		candidates = append(candidates, *registry)
+	}
+	return chooseRegistry(m.GlobalConflictPolicy(), candidates), nil
+}
+
+// ConflictReason describes which OperandRegistry manages an operator requested from several OperandRegistries.
+func (m *ODLMOperator) ConflictReason(registries []types.NamespacedName, managing *types.NamespacedName) string {
+	var names []string
+	for _, key := range registries {
+		names = append(names, key.String())
+	}
+	policy := m.GlobalConflictPolicy()
+	if managing == nil {
+		return fmt.Sprintf("OperandRegistries %s request it, none of them manages it by the %s policy", strings.Join(names, ", "), policy)
+	}
+	return fmt.Sprintf("OperandRegistries %s request it, %s manages it by the %s policy", strings.Join(names, ", "), managing.String(), policy)
+}
+
+// chooseRegistry returns the OperandRegistry chosen by the policy, it returns nil when the policy refuses to choose.
+func chooseRegistry(policy ConflictPolicy, candidates []operatorv1alpha1.OperandRegistry) *types.NamespacedName {
+	if len(candidates) == 0 || (len(candidates) > 1 && policy == ConflictPolicyRefuse) {
+		return nil
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if policy == ConflictPolicyPriority && a.GetPriority() != b.GetPriority() {
+			return a.GetPriority() > b.GetPriority()
+		}
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+		return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+	})
+	return &types.NamespacedName{Namespace: candidates[0].Namespace, Name: candidates[0].Name}
+}
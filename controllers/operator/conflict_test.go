@@ -0,0 +1,103 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	constant "github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/testutil"
+)
+
+var _ = Describe("Registry conflicts", func() {
+	ctx := context.Background()
+	created := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	registry := func(namespace, name string, age time.Duration, priority *int32) *operatorv1alpha1.OperandRegistry {
+		return &operatorv1alpha1.OperandRegistry{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, CreationTimestamp: metav1.NewTime(created.Add(-age))},
+			Spec:       operatorv1alpha1.OperandRegistrySpec{Priority: priority},
+		}
+	}
+	newOperator := func(objs ...client.Object) *ODLMOperator {
+		c := testutil.NewFakeClient(objs...)
+		return &ODLMOperator{Client: c, Reader: c}
+	}
+	high := int32(10)
+	older := types.NamespacedName{Namespace: "ns-a", Name: "common-service"}
+	newer := types.NamespacedName{Namespace: "ns-b", Name: "common-service"}
+
+	It("Should choose the managing OperandRegistry by the conflict policy", func() {
+		m := newOperator(registry("ns-a", "common-service", time.Hour, nil), registry("ns-b", "common-service", time.Minute, &high))
+		Expect(m.GlobalConflictPolicy()).Should(Equal(ConflictPolicyOldest))
+		managing, err := m.ResolveManagingRegistry(ctx, []types.NamespacedName{newer, older})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(*managing).Should(Equal(older))
+
+		m.ConflictPolicy = ConflictPolicyPriority
+		managing, err = m.ResolveManagingRegistry(ctx, []types.NamespacedName{older, newer})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(*managing).Should(Equal(newer))
+
+		m.ConflictPolicy = ConflictPolicyRefuse
+		managing, err = m.ResolveManagingRegistry(ctx, []types.NamespacedName{older, newer})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(managing).Should(BeNil())
+		Expect(m.ConflictReason([]types.NamespacedName{older, newer}, managing)).Should(ContainSubstring("none of them manages it by the Refuse policy"))
+	})
+
+	It("Should ignore the OperandRegistries that no longer exist", func() {
+		m := newOperator(registry("ns-b", "common-service", time.Minute, nil))
+		m.ConflictPolicy = ConflictPolicyRefuse
+		managing, err := m.ResolveManagingRegistry(ctx, []types.NamespacedName{older, newer})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(*managing).Should(Equal(newer))
+	})
+
+	It("Should list the requesting OperandRegistries from the config annotations without OperandInventory", func() {
+		m := newOperator()
+		registries, err := m.GetRequestingRegistries(ctx, types.NamespacedName{Namespace: "etcd-ns", Name: "etcd"}, map[string]string{
+			"ns-b.common-service/config":   "true",
+			"ns-a.common-service/config":   "true",
+			"ns-a.common-service/registry": "true",
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(registries).Should(Equal([]types.NamespacedName{older, newer}))
+	})
+
+	It("Should load the conflict policy from the ODLM ConfigMap", func() {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: constant.ODLMConfigMapName, Namespace: "ibm-common-services"},
+			Data:       map[string]string{ConflictPolicyKey: "Priority"},
+		}
+		policy, err := LoadConflictPolicy(ctx, newOperator(cm).Reader, "ibm-common-services")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(policy).Should(Equal(ConflictPolicyPriority))
+
+		_, err = ParseConflictPolicy("Newest")
+		Expect(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,64 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DeleteResource starts the deletion of the resource and returns without waiting for it being removed.
+// It returns true once the resource is gone. The timeout is tracked from the deletionTimestamp of the resource,
+// and an error is returned when the resource is still terminating after the timeout.
+func (m *ODLMOperator) DeleteResource(ctx context.Context, obj client.Object, timeout time.Duration) (bool, error) {
+	key := client.ObjectKeyFromObject(obj)
+	if err := m.Client.Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, errors.Wrapf(err, "failed to get resource %s", key.String())
+	}
+
+	if obj.GetDeletionTimestamp() == nil {
+		klog.V(3).Infof("Deleting resource %s", key.String())
+		if err := m.Client.Delete(ctx, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, errors.Wrapf(err, "failed to delete resource %s", key.String())
+		}
+		// The resource without finalizers is removed immediately
+		if err := m.Client.Get(ctx, key, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, errors.Wrapf(err, "failed to get resource %s", key.String())
+		}
+		return false, nil
+	}
+
+	if deletingTime := time.Since(obj.GetDeletionTimestamp().Time); deletingTime > timeout {
+		return false, errors.Errorf("timeout for deleting resource %s, it is still terminating after %s", key.String(), deletingTime.Round(time.Second))
+	}
+	klog.V(3).Infof("Waiting for resource %s being removed ...", key.String())
+	return false, nil
+}
@@ -0,0 +1,82 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/testutil"
+)
+
+func configMap(name string, finalizers []string, deletionTimestamp *metav1.Time) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "etcd-ns",
+			Finalizers:        finalizers,
+			DeletionTimestamp: deletionTimestamp,
+		},
+	}
+}
+
+var _ = Describe("DeleteResource", func() {
+	ctx := context.Background()
+	longAgo := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	var m *ODLMOperator
+	BeforeEach(func() {
+		m = &ODLMOperator{Client: testutil.NewFakeClient(
+			configMap("plain", nil, nil),
+			configMap("finalized", []string{"example.com/finalizer"}, nil),
+			configMap("stuck", []string{"example.com/finalizer"}, &longAgo),
+		)}
+	})
+
+	It("Should be done when the resource is removed immediately or doesn't exist", func() {
+		deleted, err := m.DeleteResource(ctx, configMap("plain", nil, nil), time.Minute)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(deleted).Should(BeTrue())
+
+		deleted, err = m.DeleteResource(ctx, configMap("absent", nil, nil), time.Minute)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(deleted).Should(BeTrue())
+	})
+
+	It("Should return without waiting for the finalizers", func() {
+		cm := configMap("finalized", nil, nil)
+		deleted, err := m.DeleteResource(ctx, cm, time.Minute)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(deleted).Should(BeFalse())
+		Expect(cm.GetDeletionTimestamp()).ShouldNot(BeNil())
+
+		deleted, err = m.DeleteResource(ctx, cm, time.Minute)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(deleted).Should(BeFalse())
+	})
+
+	It("Should time out from the deletion timestamp", func() {
+		deleted, err := m.DeleteResource(ctx, configMap("stuck", nil, nil), time.Minute)
+		Expect(err).Should(HaveOccurred())
+		Expect(deleted).Should(BeFalse())
+	})
+})
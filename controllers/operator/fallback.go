@@ -0,0 +1,77 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+
+	apiv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+)
+
+// RequestRegistry is an OperandRegistry of a request, with the spec rolled out to the OperandRequest
+type RequestRegistry struct {
+	Key      types.NamespacedName
+	Registry *apiv1alpha1.OperandRegistry
+	Revision string
+}
+
+// GetOperandRegistriesForRequest gets the OperandRegistry and the fallback OperandRegistries of a request, in order.
+// A fallback OperandRegistry which is not found is skipped, and the error of the OperandRegistry of the request
+// is returned when none of them is found.
+func (m *ODLMOperator) GetOperandRegistriesForRequest(ctx context.Context, requestInstance *apiv1alpha1.OperandRequest, req apiv1alpha1.Request) ([]RequestRegistry, error) {
+	requestKey := types.NamespacedName{Namespace: requestInstance.Namespace, Name: requestInstance.Name}
+	var registries []RequestRegistry
+	var notFoundErr error
+	for _, key := range requestInstance.GetRegistryKeys(req) {
+		reg, revision, err := m.GetOperandRegistryForRequest(ctx, key, requestKey)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			klog.V(2).Infof("OperandRegistry %s of OperandRequest %s is not found", key.String(), requestKey.String())
+			if notFoundErr == nil {
+				notFoundErr = err
+			}
+			continue
+		}
+		registries = append(registries, RequestRegistry{Key: key, Registry: reg, Revision: revision})
+	}
+	if len(registries) == 0 {
+		return nil, notFoundErr
+	}
+	return registries, nil
+}
+
+// SelectOperandRegistry returns the first OperandRegistry offering the operator to the namespace of the OperandRequest.
+// When none of them offers it, the first OperandRegistry is returned, which reports the operator as not found or out of scope.
+func SelectOperandRegistry(registries []RequestRegistry, requestNamespace, operandName string) *RequestRegistry {
+	for i, registry := range registries {
+		opt := registry.Registry.GetOperator(operandName)
+		if opt == nil {
+			continue
+		}
+		if opt.Scope == apiv1alpha1.ScopePrivate && requestNamespace != registry.Key.Namespace {
+			continue
+		}
+		return &registries[i]
+	}
+	return &registries[0]
+}
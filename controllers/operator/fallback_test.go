@@ -0,0 +1,105 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/testutil"
+)
+
+var _ = Describe("Fallback registries", func() {
+	ctx := context.Background()
+	registry := func(namespace, name string, operators ...operatorv1alpha1.Operator) *operatorv1alpha1.OperandRegistry {
+		for i := range operators {
+			operators[i].PackageName = operators[i].Name
+			operators[i].Channel = "stable"
+			operators[i].SourceName = "community-operators"
+			operators[i].SourceNamespace = "openshift-marketplace"
+		}
+		return &operatorv1alpha1.OperandRegistry{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       operatorv1alpha1.OperandRegistrySpec{Operators: operators},
+		}
+	}
+	request := &operatorv1alpha1.OperandRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "app"},
+		Spec: operatorv1alpha1.OperandRequestSpec{Requests: []operatorv1alpha1.Request{{
+			Registry: "team",
+			FallbackRegistries: []operatorv1alpha1.RegistryReference{
+				{Name: "missing"},
+				{Name: "common-service", Namespace: "ibm-common-services"},
+			},
+			Operands: []operatorv1alpha1.Operand{{Name: "etcd"}, {Name: "jenkins"}, {Name: "mongodb"}},
+		}}},
+	}
+
+	It("Should resolve each operand from the first OperandRegistry offering it in scope", func() {
+		c := testutil.NewFakeClient(
+			registry("app", "team", operatorv1alpha1.Operator{Name: "etcd"}),
+			registry("ibm-common-services", "common-service",
+				operatorv1alpha1.Operator{Name: "etcd", Scope: operatorv1alpha1.ScopePublic},
+				operatorv1alpha1.Operator{Name: "jenkins", Scope: operatorv1alpha1.ScopePublic},
+				operatorv1alpha1.Operator{Name: "mongodb"},
+			),
+		)
+		m := &ODLMOperator{Client: c, Reader: c}
+
+		registries, err := m.GetOperandRegistriesForRequest(ctx, request, request.Spec.Requests[0])
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(registries).Should(HaveLen(2))
+
+		team := types.NamespacedName{Namespace: "app", Name: "team"}
+		common := types.NamespacedName{Namespace: "ibm-common-services", Name: "common-service"}
+		Expect(SelectOperandRegistry(registries, request.Namespace, "etcd").Key).Should(Equal(team))
+		Expect(SelectOperandRegistry(registries, request.Namespace, "jenkins").Key).Should(Equal(common))
+		// The private mongodb operator isn't offered to the request, the OperandRegistry of the request reports it
+		Expect(SelectOperandRegistry(registries, request.Namespace, "mongodb").Key).Should(Equal(team))
+	})
+
+	It("Should return the not found error when none of the OperandRegistries exists", func() {
+		c := testutil.NewFakeClient()
+		m := &ODLMOperator{Client: c, Reader: c}
+
+		_, err := m.GetOperandRegistriesForRequest(ctx, request, request.Spec.Requests[0])
+		Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+	})
+
+	It("Should keep the OperandRegistry recorded in the member status", func() {
+		req := request.Spec.Requests[0]
+		Expect(request.GetRegistryKeys(req)).Should(Equal([]types.NamespacedName{
+			{Namespace: "app", Name: "team"},
+			{Namespace: "app", Name: "missing"},
+			{Namespace: "ibm-common-services", Name: "common-service"},
+		}))
+
+		recorded := request.DeepCopy()
+		recorded.Status.Members = []operatorv1alpha1.MemberStatus{
+			{Name: "jenkins", Registry: "ibm-common-services/common-service"},
+			{Name: "etcd", Registry: "other/removed"},
+		}
+		Expect(recorded.GetOperandRegistryKey(req, "jenkins")).Should(Equal(types.NamespacedName{Namespace: "ibm-common-services", Name: "common-service"}))
+		Expect(recorded.GetOperandRegistryKey(req, "etcd")).Should(Equal(types.NamespacedName{Namespace: "app", Name: "team"}))
+	})
+})
@@ -0,0 +1,170 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+)
+
+// IsGeneratedFromClusterRequest returns true when the OperandRequest is the one generated by an existing ClusterOperandRequest.
+// The labels of an OperandRequest can be set by the tenants of its namespace, so the OperandRequest has to be controlled
+// by the ClusterOperandRequest with the same UID, and has the name and the namespace of a generated OperandRequest.
+func (m *ODLMOperator) IsGeneratedFromClusterRequest(ctx context.Context, request *apiv1alpha1.OperandRequest) (bool, error) {
+	owner := metav1.GetControllerOf(request)
+	if owner == nil || owner.Kind != "ClusterOperandRequest" || owner.Name != request.Name {
+		return false, nil
+	}
+	if gv, err := schema.ParseGroupVersion(owner.APIVersion); err != nil || gv.Group != apiv1alpha1.GroupVersion.Group {
+		return false, nil
+	}
+
+	clusterRequest := &apiv1alpha1.ClusterOperandRequest{}
+	if err := m.Reader.Get(ctx, types.NamespacedName{Name: owner.Name}, clusterRequest); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to get ClusterOperandRequest %s", owner.Name)
+	}
+	if !metav1.IsControlledBy(request, clusterRequest) {
+		return false, nil
+	}
+	for _, req := range clusterRequest.Spec.Requests {
+		if req.RegistryNamespace == request.Namespace {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RequestGenerator is a cluster scoped resource generating an OperandRequest named after it in several namespaces
+type RequestGenerator interface {
+	client.Object
+	// SetGeneratedRequests records the generated OperandRequests in the status
+	SetGeneratedRequests(requests []apiv1alpha1.OperandRequest)
+}
+
+// SyncGeneratedRequests generates an OperandRequest with the spec of each namespace, deletes the OperandRequests the generator
+// no longer generates and records the generated ones in its status. The label of the generator only narrows the list,
+// the OperandRequests are generated when the generator is their controller, as the labels can be set by anyone.
+// An existing OperandRequest with the same name which isn't generated from the generator is left unchanged.
+func (m *ODLMOperator) SyncGeneratedRequests(ctx context.Context, generator RequestGenerator, label string, specs map[string]apiv1alpha1.OperandRequestSpec) error {
+	gvk, err := apiutil.GVKForObject(generator, m.Scheme)
+	if err != nil {
+		return err
+	}
+	generatorName := gvk.Kind + " " + generator.GetName()
+
+	requestList := &apiv1alpha1.OperandRequestList{}
+	if err := m.Client.List(ctx, requestList, client.MatchingLabels{label: generator.GetName()}); err != nil {
+		return errors.Wrapf(err, "failed to list the OperandRequests of %s", generatorName)
+	}
+	generated := make(map[string]*apiv1alpha1.OperandRequest)
+	for i := range requestList.Items {
+		request := &requestList.Items[i]
+		if !metav1.IsControlledBy(request, generator) {
+			continue
+		}
+		if _, ok := specs[request.Namespace]; ok {
+			generated[request.Namespace] = request
+			continue
+		}
+		klog.V(2).Infof("%s no longer generates an OperandRequest in namespace %s, delete OperandRequest %s/%s", generatorName, request.Namespace, request.Namespace, request.Name)
+		if err := m.Client.Delete(ctx, request); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete OperandRequest %s/%s", request.Namespace, request.Name)
+		}
+	}
+
+	namespaces := make([]string, 0, len(specs))
+	for ns := range specs {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	requests := make([]apiv1alpha1.OperandRequest, 0, len(namespaces))
+	for _, ns := range namespaces {
+		request, err := m.applyGeneratedRequest(ctx, generator, generatorName, label, ns, specs[ns], generated[ns])
+		if err != nil {
+			return err
+		}
+		requests = append(requests, *request)
+	}
+	generator.SetGeneratedRequests(requests)
+	return nil
+}
+
+// applyGeneratedRequest creates the OperandRequest generated in the namespace, or updates the existing one with the spec.
+// The OperandRequest is named after the generator, so the name is stable across the reconciliations.
+func (m *ODLMOperator) applyGeneratedRequest(ctx context.Context, generator RequestGenerator, generatorName, label, namespace string, spec apiv1alpha1.OperandRequestSpec, existing *apiv1alpha1.OperandRequest) (*apiv1alpha1.OperandRequest, error) {
+	if existing != nil {
+		if reflect.DeepEqual(existing.Spec, spec) {
+			return existing, nil
+		}
+		original := existing.DeepCopy()
+		existing.Spec = spec
+		klog.V(2).Infof("Updating OperandRequest %s/%s of %s", namespace, existing.Name, generatorName)
+		if err := m.Client.Patch(ctx, existing, client.MergeFrom(original)); err != nil {
+			return nil, errors.Wrapf(err, "failed to update OperandRequest %s/%s", namespace, existing.Name)
+		}
+		return existing, nil
+	}
+
+	request := &apiv1alpha1.OperandRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      generator.GetName(),
+			Namespace: namespace,
+			Labels:    map[string]string{label: generator.GetName()},
+		},
+		Spec: spec,
+	}
+	if err := controllerutil.SetControllerReference(generator, request, m.Scheme); err != nil {
+		return nil, err
+	}
+	klog.V(2).Infof("Creating OperandRequest %s/%s of %s", namespace, request.Name, generatorName)
+	if err := m.Client.Create(ctx, request); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil, errors.Errorf("OperandRequest %s/%s already exists and isn't generated from %s", namespace, request.Name, generatorName)
+		}
+		return nil, errors.Wrapf(err, "failed to create OperandRequest %s/%s", namespace, request.Name)
+	}
+	return request, nil
+}
+
+// GetGeneratedRequestMapper maps an OperandRequest to the generator of the kind which is its controller
+func GetGeneratedRequestMapper(kind string) handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
+		owner := metav1.GetControllerOf(object)
+		if owner == nil || owner.Kind != kind {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: owner.Name}}}
+	}
+}
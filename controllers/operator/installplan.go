@@ -0,0 +1,62 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ApproveInstallPlan approves the pending InstallPlan of the Subscription when it installs the ClusterServiceVersion.
+// It returns true when the InstallPlan is approved.
+func (m *ODLMOperator) ApproveInstallPlan(ctx context.Context, sub *olmv1alpha1.Subscription, csvName string) (bool, error) {
+	if sub.Status.InstallPlanRef == nil || sub.Status.InstallPlanRef.Name == "" {
+		return false, nil
+	}
+
+	ip := &olmv1alpha1.InstallPlan{}
+	ipKey := types.NamespacedName{Name: sub.Status.InstallPlanRef.Name, Namespace: sub.Namespace}
+	if err := m.Client.Get(ctx, ipKey, ip); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to get InstallPlan %s", ipKey.String())
+	}
+	if ip.Spec.Approved {
+		return false, nil
+	}
+
+	for _, name := range ip.Spec.ClusterServiceVersionNames {
+		if name != csvName {
+			continue
+		}
+		originalIP := ip.DeepCopy()
+		ip.Spec.Approved = true
+		if err := m.Client.Patch(ctx, ip, client.MergeFrom(originalIP)); err != nil {
+			return false, errors.Wrapf(err, "failed to approve InstallPlan %s", ipKey.String())
+		}
+		klog.V(1).Infof("Approved InstallPlan %s installing ClusterServiceVersion %s", ipKey.String(), csvName)
+		return true, nil
+	}
+	return false, nil
+}
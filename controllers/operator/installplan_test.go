@@ -0,0 +1,71 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/testutil"
+)
+
+var _ = Describe("ApproveInstallPlan", func() {
+	ctx := context.Background()
+
+	newOperator := func(ip *olmv1alpha1.InstallPlan) *ODLMOperator {
+		return &ODLMOperator{Client: testutil.NewFakeClient(ip)}
+	}
+	installPlan := func(csvNames ...string) *olmv1alpha1.InstallPlan {
+		return &olmv1alpha1.InstallPlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "install-abcde", Namespace: "etcd-ns"},
+			Spec: olmv1alpha1.InstallPlanSpec{
+				ClusterServiceVersionNames: csvNames,
+				Approval:                   olmv1alpha1.ApprovalManual,
+			},
+		}
+	}
+	sub := &olmv1alpha1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "etcd-ns"},
+		Status: olmv1alpha1.SubscriptionStatus{
+			InstallPlanRef: &corev1.ObjectReference{Name: "install-abcde", Namespace: "etcd-ns"},
+		},
+	}
+
+	It("Should approve the InstallPlan installing the ClusterServiceVersion", func() {
+		m := newOperator(installPlan("etcdoperator.v0.9.2"))
+		approved, err := m.ApproveInstallPlan(ctx, sub, "etcdoperator.v0.9.2")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(approved).Should(BeTrue())
+
+		ip := &olmv1alpha1.InstallPlan{}
+		Expect(m.Client.Get(ctx, types.NamespacedName{Name: "install-abcde", Namespace: "etcd-ns"}, ip)).Should(Succeed())
+		Expect(ip.Spec.Approved).Should(BeTrue())
+	})
+
+	It("Should not approve the InstallPlan of another ClusterServiceVersion", func() {
+		m := newOperator(installPlan("etcdoperator.v0.9.4"))
+		approved, err := m.ApproveInstallPlan(ctx, sub, "etcdoperator.v0.9.2")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(approved).Should(BeFalse())
+	})
+})
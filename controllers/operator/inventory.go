@@ -0,0 +1,58 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+	"regexp"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+
+	apiv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	constant "github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+)
+
+// OwnerAnnotation matches the registry, config and request annotations ODLM sets on the managed resources
+var OwnerAnnotation = regexp.MustCompile(`^(.*)\.(.*)\/(registry|config|request)$`)
+
+// NewOperandInventory returns an empty OperandInventory for the managed Subscription or ClusterExtension
+func NewOperandInventory(kind, name, namespace, inventoryNamespace string) *apiv1alpha1.OperandInventory {
+	return &apiv1alpha1.OperandInventory{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: inventoryNamespace,
+			Labels:    map[string]string{constant.OpreqLabel: "true"},
+		},
+		Spec: apiv1alpha1.OperandInventorySpec{
+			Kind:      kind,
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+}
+
+// GetOperandInventory gets the OperandInventory of the managed Subscription or ClusterExtension
+func (m *ODLMOperator) GetOperandInventory(ctx context.Context, key types.NamespacedName) (*apiv1alpha1.OperandInventory, error) {
+	klog.V(3).Infof("Fetch OperandInventory: %s", key.String())
+	inventory := &apiv1alpha1.OperandInventory{}
+	if err := m.Reader.Get(ctx, key, inventory); err != nil {
+		return nil, err
+	}
+	return inventory, nil
+}
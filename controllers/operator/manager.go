@@ -18,14 +18,19 @@ package operator
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"path"
 	"sort"
 
 	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
 	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
@@ -36,15 +41,24 @@ import (
 
 	apiv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
 	constant "github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+	util "github.com/IBM/operand-deployment-lifecycle-manager/controllers/util"
 )
 
 // ODLMOperator is the struct for ODLM controllers
 type ODLMOperator struct {
 	client.Client
+	// Reader reads from the API server. The cluster scoped resources, such as the namespaces, OperandPolicies,
+	// ClusterOperandRequests, OperandRequestTemplates and OperandStatuses, aren't cached in every install mode,
+	// so they are read with the Reader instead of the Client.
 	client.Reader
 	*rest.Config
 	Recorder record.EventRecorder
 	Scheme   *runtime.Scheme
+	// Timeouts override the default timeouts and requeue intervals. They are read from the manager flags
+	// and the ODLM ConfigMap at startup, and aren't changed afterwards.
+	Timeouts *apiv1alpha1.Timeouts
+	// ConflictPolicy resolves the conflicts between OperandRegistries, it is read at startup like the Timeouts
+	ConflictPolicy ConflictPolicy
 }
 
 // NewODLMOperator is the method to initialize an Operator struct
@@ -58,12 +72,71 @@ func NewODLMOperator(mgr manager.Manager, name string) *ODLMOperator {
 	}
 }
 
-// GetOperandRegistry gets the OperandRegistry instance with default value
+// GetOperandRegistry gets the OperandRegistry instance with default value,
+// the operators of an OperandRegistry extending a base are the effective merged ones
 func (m *ODLMOperator) GetOperandRegistry(ctx context.Context, key types.NamespacedName) (*apiv1alpha1.OperandRegistry, error) {
 	reg := &apiv1alpha1.OperandRegistry{}
 	if err := m.Client.Get(ctx, key, reg); err != nil {
 		return nil, err
 	}
+	if err := m.MergeBaseRegistry(ctx, reg); err != nil {
+		return nil, err
+	}
+	return reg, m.resolveOperandRegistry(ctx, key, reg)
+}
+
+// GetOperandRegistryForRequest gets the OperandRegistry with the spec rolled out to the OperandRequest,
+// and returns the name of the revision of the spec.
+// While a staged rollout is in progress, an OperandRequest not receiving the change yet gets the spec of the previous revision,
+// merged onto the base OperandRegistry pinned in that revision.
+func (m *ODLMOperator) GetOperandRegistryForRequest(ctx context.Context, key, requestKey types.NamespacedName) (*apiv1alpha1.OperandRegistry, string, error) {
+	reg := &apiv1alpha1.OperandRegistry{}
+	if err := m.Client.Get(ctx, key, reg); err != nil {
+		return nil, "", err
+	}
+	current, err := m.GetRegistryRevision(ctx, reg)
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := json.Marshal(current)
+	if err != nil {
+		return nil, "", err
+	}
+	revision := RevisionName(reg.Name, data)
+	if rolloutRevision := reg.GetRolloutRevision(requestKey); rolloutRevision != "" && rolloutRevision != revision {
+		cr := &appsv1.ControllerRevision{}
+		if err := m.Reader.Get(ctx, types.NamespacedName{Namespace: key.Namespace, Name: rolloutRevision}, cr); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, "", err
+			}
+			klog.Warningf("Revision %s of the OperandRegistry %s is not found, OperandRequest %s applies the current spec", rolloutRevision, key.String(), requestKey.String())
+		} else {
+			rolledOut := &RegistryRevision{}
+			if err := json.Unmarshal(cr.Data.Raw, rolledOut); err != nil {
+				return nil, "", errors.Wrapf(err, "failed to unmarshal the spec of ControllerRevision %s/%s", cr.Namespace, cr.Name)
+			}
+			klog.V(2).Infof("OperandRequest %s applies revision %s of the OperandRegistry %s", requestKey.String(), rolloutRevision, key.String())
+			rolledOut.RevisionHistoryLimit = reg.Spec.RevisionHistoryLimit
+			rolledOut.RolloutStrategy = reg.Spec.RolloutStrategy
+			reg.Spec = rolledOut.OperandRegistrySpec
+			revision = rolloutRevision
+			current = rolledOut
+		}
+	}
+	// A revision recorded without a base, because the base was missing or couldn't be merged, merges the current base
+	if current.Base != nil {
+		err = mergePinnedBase(ctx, reg, current.Base)
+	} else {
+		err = m.MergeBaseRegistry(ctx, reg)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return reg, revision, m.resolveOperandRegistry(ctx, key, reg)
+}
+
+// resolveOperandRegistry sets the default values of the operators and resolves their CatalogSources
+func (m *ODLMOperator) resolveOperandRegistry(ctx context.Context, key types.NamespacedName, reg *apiv1alpha1.OperandRegistry) error {
 	for i, o := range reg.Spec.Operators {
 		if o.Scope == "" {
 			reg.Spec.Operators[i].Scope = apiv1alpha1.ScopePrivate
@@ -74,20 +147,47 @@ func (m *ODLMOperator) GetOperandRegistry(ctx context.Context, key types.Namespa
 		if o.InstallPlanApproval == "" {
 			reg.Spec.Operators[i].InstallPlanApproval = olmv1alpha1.ApprovalAutomatic
 		}
+		if o.InstallBackend == "" {
+			reg.Spec.Operators[i].InstallBackend = apiv1alpha1.InstallBackendSubscription
+		}
+		// OLM v1 resolves the bundle from ClusterCatalogs, CatalogSource isn't used
+		if o.InstallBackend == apiv1alpha1.InstallBackendClusterExtension {
+			continue
+		}
+		// An operator without a package is reported by the OperandRegistry, there is nothing to look up
+		if o.PackageName == "" {
+			continue
+		}
 		if o.SourceName == "" || o.SourceNamespace == "" {
-			catalogSourceName, catalogSourceNs, err := m.GetCatalogSourceFromPackage(ctx, o.PackageName, o.Namespace, o.Channel, key.Namespace)
+			catalogSourceName, catalogSourceNs, reason, err := m.GetCatalogSourceFromPackage(ctx, o.PackageName, o.Namespace, o.Channel, key.Namespace, reg.Spec.CatalogSourcePolicy)
 			if err != nil {
-				return reg, err
+				return err
 			}
 
 			if catalogSourceName == "" || catalogSourceNs == "" {
 				klog.Warningf("no catalogsource found for %v", o.PackageName)
+				reason = "No CatalogSource provides the package"
 			}
 
 			reg.Spec.Operators[i].SourceName, reg.Spec.Operators[i].SourceNamespace = catalogSourceName, catalogSourceNs
+			reg.SetCatalogSourceStatus(o.Name, apiv1alpha1.CatalogSourceStatus{Name: catalogSourceName, Namespace: catalogSourceNs, Reason: reason})
+		} else {
+			reg.SetCatalogSourceStatus(o.Name, apiv1alpha1.CatalogSourceStatus{Name: o.SourceName, Namespace: o.SourceNamespace, Reason: "Specified in the OperandRegistry"})
+		}
+		// Track the default channel of the package in the chosen CatalogSource when the channel isn't set
+		if o.Channel == "" {
+			channel, err := m.GetDefaultChannel(ctx, o.PackageName, o.Namespace, reg.Spec.Operators[i].SourceName, reg.Spec.Operators[i].SourceNamespace)
+			if err != nil {
+				return err
+			}
+			if channel == "" {
+				klog.Warningf("no default channel found for %v", o.PackageName)
+			}
+			reg.Spec.Operators[i].Channel = channel
+			reg.SetDefaultChannel(o.Name, channel)
 		}
 	}
-	return reg, nil
+	return nil
 }
 
 type CatalogSource struct {
@@ -95,6 +195,14 @@ type CatalogSource struct {
 	Namespace         string
 	OpNamespace       string
 	RegistryNamespace string
+	// Pattern is the first pattern of the selection policy matching the CatalogSource
+	Pattern string
+	// PatternIndex is the index of Pattern, or the number of patterns when none of them matches
+	PatternIndex int
+	// SelectorMatched is true when the CatalogSource matches the label selector of the selection policy
+	SelectorMatched bool
+	// Priority is the spec.priority of the CatalogSource when the selection policy honors it
+	Priority int
 }
 
 type sortableCatalogSource []CatalogSource
@@ -102,62 +210,219 @@ type sortableCatalogSource []CatalogSource
 func (s sortableCatalogSource) Len() int      { return len(s) }
 func (s sortableCatalogSource) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
 func (s sortableCatalogSource) Less(i, j int) bool {
-	// Check if the catalogsource is in the same namespace as OperandRegistry
-	inRegistryNsI, inRegistryNsJ := s[i].Namespace == s[i].RegistryNamespace, s[j].Namespace == s[j].RegistryNamespace
-	if inRegistryNsI && !inRegistryNsJ {
-		return true
+	less, _ := compareCatalogSource(s[i], s[j])
+	return less
+}
+
+// compareCatalogSource returns true when the CatalogSource a is preferred over b,
+// and the criterion which decides the order
+func compareCatalogSource(a, b CatalogSource) (bool, string) {
+	// Check if the catalogsource matches an earlier pattern of the selection policy
+	if a.PatternIndex != b.PatternIndex {
+		if a.PatternIndex < b.PatternIndex {
+			return true, fmt.Sprintf("CatalogSource %s/%s matches pattern %q", a.Namespace, a.Name, a.Pattern)
+		}
+		return false, fmt.Sprintf("CatalogSource %s/%s matches pattern %q", b.Namespace, b.Name, b.Pattern)
 	}
-	if !inRegistryNsI && inRegistryNsJ {
-		return false
+	// Check if the catalogsource matches the label selector of the selection policy
+	if a.SelectorMatched != b.SelectorMatched {
+		if a.SelectorMatched {
+			return true, fmt.Sprintf("CatalogSource %s/%s matches the label selector", a.Namespace, a.Name)
+		}
+		return false, fmt.Sprintf("CatalogSource %s/%s matches the label selector", b.Namespace, b.Name)
 	}
-	// Check if the catalogsource is in the same namespace as operator
-	inOpNsI, inOpNsJ := s[i].Namespace == s[i].OpNamespace, s[j].Namespace == s[j].OpNamespace
-	if inOpNsI && !inOpNsJ {
-		return true
+	// Check if the catalogsource has a higher priority
+	if a.Priority != b.Priority {
+		if a.Priority > b.Priority {
+			return true, fmt.Sprintf("CatalogSource %s/%s has the highest priority %d", a.Namespace, a.Name, a.Priority)
+		}
+		return false, fmt.Sprintf("CatalogSource %s/%s has the highest priority %d", b.Namespace, b.Name, b.Priority)
 	}
-	if !inOpNsI && inOpNsJ {
-		return false
+	// Check if the catalogsource is in the same namespace as OperandRegistry
+	inRegistryNsA, inRegistryNsB := a.Namespace == a.RegistryNamespace, b.Namespace == b.RegistryNamespace
+	if inRegistryNsA != inRegistryNsB {
+		if inRegistryNsA {
+			return true, fmt.Sprintf("CatalogSource %s/%s is in the OperandRegistry namespace", a.Namespace, a.Name)
+		}
+		return false, fmt.Sprintf("CatalogSource %s/%s is in the OperandRegistry namespace", b.Namespace, b.Name)
+	}
+	// Check if the catalogsource is in the same namespace as operator
+	inOpNsA, inOpNsB := a.Namespace == a.OpNamespace, b.Namespace == b.OpNamespace
+	if inOpNsA != inOpNsB {
+		if inOpNsA {
+			return true, fmt.Sprintf("CatalogSource %s/%s is in the operator namespace", a.Namespace, a.Name)
+		}
+		return false, fmt.Sprintf("CatalogSource %s/%s is in the operator namespace", b.Namespace, b.Name)
 	}
 	// If their namespaces are the same, then compare the name of the catalogsource
-	if s[i].Namespace == s[j].Namespace {
-		return s[i].Name < s[j].Name
+	if a.Namespace == b.Namespace {
+		return a.Name < b.Name, "CatalogSources are sorted in alphabetical order"
 	}
-	return s[i].Namespace < s[j].Namespace
+	return a.Namespace < b.Namespace, "CatalogSources are sorted in alphabetical order"
 }
 
-func (m *ODLMOperator) GetCatalogSourceFromPackage(ctx context.Context, packageName, namespace, channel, registryNs string) (catalogSourceName string, catalogSourceNs string, err error) {
-	packageManifestList := &operatorsv1.PackageManifestList{}
-	opts := []client.ListOption{
-		client.MatchingFields{"metadata.name": packageName},
-		client.InNamespace(namespace),
+// applyCatalogSourcePolicy evaluates the selection policy on the CatalogSource candidate.
+// The candidates can be in any namespace, so the CatalogSource is read with the API reader instead of the cache.
+func (m *ODLMOperator) applyCatalogSourcePolicy(ctx context.Context, candidate *CatalogSource, policy *apiv1alpha1.CatalogSourcePolicy) error {
+	if policy == nil {
+		return nil
+	}
+	candidate.PatternIndex = len(policy.Patterns)
+	for i, pattern := range policy.Patterns {
+		if matched, err := path.Match(pattern, candidate.Namespace+"/"+candidate.Name); err != nil {
+			return errors.Wrapf(err, "invalid CatalogSource pattern %q", pattern)
+		} else if matched {
+			candidate.Pattern, candidate.PatternIndex = pattern, i
+			break
+		}
+	}
+	if policy.Selector == nil && !policy.HonorPriority {
+		return nil
+	}
+
+	cs := &olmv1alpha1.CatalogSource{}
+	if err := m.Reader.Get(ctx, types.NamespacedName{Name: candidate.Name, Namespace: candidate.Namespace}, cs); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get CatalogSource %s/%s", candidate.Namespace, candidate.Name)
 	}
-	if err := m.Reader.List(ctx, packageManifestList, opts...); err != nil {
-		return "", "", err
+	if policy.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(policy.Selector)
+		if err != nil {
+			return errors.Wrap(err, "invalid CatalogSource label selector")
+		}
+		candidate.SelectorMatched = selector.Matches(labels.Set(cs.Labels))
+	}
+	if policy.HonorPriority {
+		candidate.Priority = cs.Spec.Priority
+	}
+	return nil
+}
+
+// GetCatalogSourceFromPackage chooses the CatalogSource of the package by the selection policy
+// and returns the reason for the choice
+func (m *ODLMOperator) GetCatalogSourceFromPackage(ctx context.Context, packageName, namespace, channel, registryNs string, policy *apiv1alpha1.CatalogSourcePolicy) (catalogSourceName string, catalogSourceNs string, reason string, err error) {
+	sources, err := m.listPackageSources(ctx, packageName, namespace, channel)
+	if err != nil {
+		return "", "", "", err
 	}
-	number := len(packageManifestList.Items)
+	number := len(sources)
 
 	switch number {
 	case 0:
 		klog.Warningf("Not found PackageManifest %s in the namespace %s has channel %s", packageName, namespace, channel)
-		return "", "", nil
+		return "", "", "", nil
 	case 1:
-		return packageManifestList.Items[0].Status.CatalogSource, packageManifestList.Items[0].Status.CatalogSourceNamespace, nil
+		return sources[0].Name, sources[0].Namespace, "Only one CatalogSource provides the package", nil
 	default:
 		var catalogSourceCandidate []CatalogSource
-		for _, pm := range packageManifestList.Items {
-			if !channelCheck(channel, pm.Status.Channels) {
+		for _, source := range sources {
+			if !source.HasChannel {
 				continue
 			}
-			catalogSourceCandidate = append(catalogSourceCandidate, CatalogSource{Name: pm.Status.CatalogSource, Namespace: pm.Status.CatalogSourceNamespace, OpNamespace: namespace, RegistryNamespace: registryNs})
+			candidate := CatalogSource{Name: source.Name, Namespace: source.Namespace, OpNamespace: namespace, RegistryNamespace: registryNs}
+			if err := m.applyCatalogSourcePolicy(ctx, &candidate, policy); err != nil {
+				return "", "", "", err
+			}
+			catalogSourceCandidate = append(catalogSourceCandidate, candidate)
 		}
-		if len(catalogSourceCandidate) == 0 {
+		switch len(catalogSourceCandidate) {
+		case 0:
 			klog.Errorf("Not found PackageManifest %s in the namespace %s has channel %s", packageName, namespace, channel)
-			return "", "", nil
+			return "", "", "", nil
+		case 1:
+			return catalogSourceCandidate[0].Name, catalogSourceCandidate[0].Namespace, fmt.Sprintf("Only one CatalogSource provides the channel %s", channel), nil
 		}
 		// Sort CatalogSources by priority
 		sort.Sort(sortableCatalogSource(catalogSourceCandidate))
-		return catalogSourceCandidate[0].Name, catalogSourceCandidate[0].Namespace, nil
+		_, reason = compareCatalogSource(catalogSourceCandidate[0], catalogSourceCandidate[1])
+		return catalogSourceCandidate[0].Name, catalogSourceCandidate[0].Namespace, reason, nil
+	}
+}
+
+// listPackageSources lists the CatalogSources providing the package in the namespace.
+// The result is served from the PackageManifest cache when it is available.
+func (m *ODLMOperator) listPackageSources(ctx context.Context, packageName, namespace, channel string) ([]packageSource, error) {
+	key := packageKey{packageName: packageName, channel: channel, namespace: namespace}
+	if sources, ok := packageManifests.get(key); ok {
+		return sources, nil
+	}
+
+	packageManifestList, err := m.listPackageManifests(ctx, packageName, namespace)
+	if err != nil {
+		return nil, err
 	}
+	sources := make([]packageSource, 0, len(packageManifestList.Items))
+	for _, pm := range packageManifestList.Items {
+		var channels []string
+		for _, c := range pm.Status.Channels {
+			channels = append(channels, c.Name)
+		}
+		sources = append(sources, packageSource{
+			Name:      pm.Status.CatalogSource,
+			Namespace: pm.Status.CatalogSourceNamespace,
+			// Any CatalogSource providing the package provides its default channel
+			HasChannel:     channel == "" || channelCheck(channel, pm.Status.Channels),
+			Channels:       channels,
+			DefaultChannel: pm.Status.DefaultChannel,
+		})
+	}
+	packageManifests.set(key, sources)
+	return sources, nil
+}
+
+// listPackageManifests lists the PackageManifests of the package available in the namespace.
+// The PackageManifests of all the namespaces are read from the informer when it is set up, they are available in the namespace
+// when their CatalogSource is in the namespace or in the global catalog namespace, as the package server lists them.
+// Otherwise, they are listed in the namespace with the API reader.
+func (m *ODLMOperator) listPackageManifests(ctx context.Context, packageName, namespace string) (*operatorsv1.PackageManifestList, error) {
+	packageManifestList := &operatorsv1.PackageManifestList{}
+	if !packageManifests.isInformed() {
+		opts := []client.ListOption{
+			client.MatchingFields{"metadata.name": packageName},
+			client.InNamespace(namespace),
+		}
+		if err := m.Reader.List(ctx, packageManifestList, opts...); err != nil {
+			return nil, err
+		}
+		return packageManifestList, nil
+	}
+
+	if err := m.Client.List(ctx, packageManifestList, client.MatchingFields{"metadata.name": packageName}); err != nil {
+		return nil, err
+	}
+	globalNamespace := util.GetGlobalCatalogNamespace()
+	items := make([]operatorsv1.PackageManifest, 0, len(packageManifestList.Items))
+	for _, pm := range packageManifestList.Items {
+		if pm.Status.CatalogSourceNamespace == namespace || pm.Status.CatalogSourceNamespace == globalNamespace {
+			items = append(items, pm)
+		}
+	}
+	packageManifestList.Items = items
+	return packageManifestList, nil
+}
+
+// ListOperandRegistriesByCatalogSource lists all the OperandRegistries having operators
+// installed from the specific CatalogSource, or operators whose CatalogSource is resolved at runtime
+func (m *ODLMOperator) ListOperandRegistriesByCatalogSource(ctx context.Context, key types.NamespacedName) ([]apiv1alpha1.OperandRegistry, error) {
+	registryList, err := m.ListOperandRegistry(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	var registries []apiv1alpha1.OperandRegistry
+	for _, registry := range registryList.Items {
+		for _, o := range registry.Spec.Operators {
+			if o.IsClusterExtension() {
+				continue
+			}
+			if o.SourceName == "" || o.SourceNamespace == "" || (o.SourceName == key.Name && o.SourceNamespace == key.Namespace) {
+				registries = append(registries, registry)
+				break
+			}
+		}
+	}
+	return registries, nil
 }
 
 func channelCheck(channelName string, channelList []operatorsv1.PackageChannel) (found bool) {
@@ -181,6 +446,22 @@ func (m *ODLMOperator) ListOperandRegistry(ctx context.Context, label map[string
 	if err := m.Client.List(ctx, registryList, opts...); err != nil {
 		return nil, err
 	}
+	// Merge the bases before setting the default values, the defaults of an overlay operator don't override its base
+	listed := make(map[types.NamespacedName]apiv1alpha1.OperandRegistry)
+	for _, item := range registryList.Items {
+		listed[types.NamespacedName{Namespace: item.Namespace, Name: item.Name}] = *item.DeepCopy()
+	}
+	getBase := func(ctx context.Context, key types.NamespacedName) (*apiv1alpha1.OperandRegistry, error) {
+		if base, ok := listed[key]; ok {
+			return base.DeepCopy(), nil
+		}
+		return m.getBaseRegistry(ctx, key)
+	}
+	for index := range registryList.Items {
+		if err := mergeBaseRegistry(ctx, &registryList.Items[index], getBase, map[types.NamespacedName]bool{}); err != nil {
+			klog.Warningf("failed to merge the base of OperandRegistry %s/%s: %v", registryList.Items[index].Namespace, registryList.Items[index].Name, err)
+		}
+	}
 	for index, item := range registryList.Items {
 		for i, o := range item.Spec.Operators {
 			if o.Scope == "" {
@@ -192,6 +473,9 @@ func (m *ODLMOperator) ListOperandRegistry(ctx context.Context, label map[string
 			if o.InstallPlanApproval == "" {
 				registryList.Items[index].Spec.Operators[i].InstallPlanApproval = olmv1alpha1.ApprovalAutomatic
 			}
+			if o.InstallBackend == "" {
+				registryList.Items[index].Spec.Operators[i].InstallBackend = apiv1alpha1.InstallBackendSubscription
+			}
 		}
 	}
 
@@ -256,11 +540,9 @@ func (m *ODLMOperator) ListOperandRequestsByRegistry(ctx context.Context, key ty
 	// Set default value for all the OperandRequest
 	for _, item := range requestCandidates.Items {
 		for _, r := range item.Spec.Requests {
-			if r.RegistryNamespace == "" {
-				r.RegistryNamespace = item.GetNamespace()
-			}
-			if r.Registry == key.Name && r.RegistryNamespace == key.Namespace {
+			if item.UsesRegistry(r, key) {
 				requestList = append(requestList, item)
+				break
 			}
 		}
 	}
@@ -277,11 +559,9 @@ func (m *ODLMOperator) ListOperandRequestsByConfig(ctx context.Context, key type
 	// Set default value for all the OperandRequest
 	for _, item := range requestCandidates.Items {
 		for _, r := range item.Spec.Requests {
-			if r.RegistryNamespace == "" {
-				r.RegistryNamespace = item.GetNamespace()
-			}
-			if r.Registry == key.Name && r.RegistryNamespace == key.Namespace {
+			if item.UsesRegistry(r, key) {
 				requestList = append(requestList, item)
+				break
 			}
 		}
 	}
@@ -0,0 +1,270 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+
+	apiv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+)
+
+// The reasons why the base OperandRegistries can't be merged
+const (
+	BaseReasonNotFound       = "BaseNotFound"
+	BaseReasonCycle          = "InheritanceCycle"
+	BaseReasonInvalidOverlay = "InvalidOverlay"
+)
+
+// BaseRegistryError is the error of an OperandRegistry whose base OperandRegistries can't be merged.
+// The error of a missing base wraps its NotFound error, so the callers handle it like a missing OperandRegistry.
+type BaseRegistryError struct {
+	Reason  string
+	Message string
+	Err     error
+}
+
+func (e *BaseRegistryError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the error causing the failure
+func (e *BaseRegistryError) Unwrap() error {
+	return e.Err
+}
+
+// registryGetter gets an OperandRegistry by its key
+type registryGetter func(ctx context.Context, key types.NamespacedName) (*apiv1alpha1.OperandRegistry, error)
+
+// MergeBaseRegistry merges the operators of the OperandRegistry onto the ones of the base OperandRegistry it extends,
+// following the chain of bases. The spec of the OperandRegistry is replaced by the effective one.
+func (m *ODLMOperator) MergeBaseRegistry(ctx context.Context, reg *apiv1alpha1.OperandRegistry) error {
+	return mergeBaseRegistry(ctx, reg, m.getBaseRegistry, map[types.NamespacedName]bool{})
+}
+
+// ListExtendingRegistries lists the OperandRegistries extending the OperandRegistry, directly or through other bases
+func (m *ODLMOperator) ListExtendingRegistries(ctx context.Context, key types.NamespacedName) ([]types.NamespacedName, error) {
+	registryList := &apiv1alpha1.OperandRegistryList{}
+	if err := m.Client.List(ctx, registryList); err != nil {
+		return nil, err
+	}
+	var extending []types.NamespacedName
+	visited := map[types.NamespacedName]bool{key: true}
+	bases := []types.NamespacedName{key}
+	for len(bases) > 0 {
+		base := bases[0]
+		bases = bases[1:]
+		for i := range registryList.Items {
+			baseKey := registryList.Items[i].GetBaseKey()
+			if baseKey == nil || *baseKey != base {
+				continue
+			}
+			regKey := types.NamespacedName{Namespace: registryList.Items[i].Namespace, Name: registryList.Items[i].Name}
+			if visited[regKey] {
+				continue
+			}
+			visited[regKey] = true
+			extending = append(extending, regKey)
+			bases = append(bases, regKey)
+		}
+	}
+	return extending, nil
+}
+
+// RegistryRevision is the data recorded in a revision of an OperandRegistry.
+// The effective spec of the base OperandRegistry is pinned in the revision, so a change of the base is a new revision
+// of the extending OperandRegistry, which is rolled out like a change of its own spec.
+type RegistryRevision struct {
+	apiv1alpha1.OperandRegistrySpec `json:",inline"`
+	// Base holds the fields merged from the base OperandRegistry,
+	// it is nil when the OperandRegistry doesn't extend a base or the base can't be merged
+	Base *apiv1alpha1.OperandRegistrySpec `json:"base,omitempty"`
+}
+
+// GetRegistryRevision returns the revision data of the OperandRegistry with the current effective spec of its base
+func (m *ODLMOperator) GetRegistryRevision(ctx context.Context, reg *apiv1alpha1.OperandRegistry) (*RegistryRevision, error) {
+	revision := &RegistryRevision{OperandRegistrySpec: *reg.RevisionSpec()}
+	baseKey := reg.GetBaseKey()
+	if baseKey == nil {
+		return revision, nil
+	}
+	base, err := m.getBaseRegistry(ctx, *baseKey)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return revision, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get the base OperandRegistry %s", baseKey.String())
+	}
+	if err := m.MergeBaseRegistry(ctx, base); err != nil {
+		// The OperandRegistry reports the failure when it is merged onto the base
+		baseErr := &BaseRegistryError{}
+		if errors.As(err, &baseErr) {
+			return revision, nil
+		}
+		return nil, err
+	}
+	revision.Base = &apiv1alpha1.OperandRegistrySpec{
+		Operators:               base.Spec.Operators,
+		CatalogSourcePolicy:     base.Spec.CatalogSourcePolicy,
+		ProgressDeadlineSeconds: base.Spec.ProgressDeadlineSeconds,
+	}
+	return revision, nil
+}
+
+// mergePinnedBase merges the operators of the OperandRegistry onto the base pinned in one of its revisions
+func mergePinnedBase(ctx context.Context, reg *apiv1alpha1.OperandRegistry, base *apiv1alpha1.OperandRegistrySpec) error {
+	get := func(ctx context.Context, key types.NamespacedName) (*apiv1alpha1.OperandRegistry, error) {
+		return &apiv1alpha1.OperandRegistry{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Spec:       *base.DeepCopy(),
+		}, nil
+	}
+	return mergeBaseRegistry(ctx, reg, get, map[types.NamespacedName]bool{})
+}
+
+func (m *ODLMOperator) getBaseRegistry(ctx context.Context, key types.NamespacedName) (*apiv1alpha1.OperandRegistry, error) {
+	reg := &apiv1alpha1.OperandRegistry{}
+	if err := m.Client.Get(ctx, key, reg); err != nil {
+		return nil, err
+	}
+	return reg, nil
+}
+
+// mergeBaseRegistry merges the base OperandRegistries recursively, visited records the registries of the chain to detect a cycle
+func mergeBaseRegistry(ctx context.Context, reg *apiv1alpha1.OperandRegistry, get registryGetter, visited map[types.NamespacedName]bool) error {
+	baseKey := reg.GetBaseKey()
+	if baseKey == nil {
+		return nil
+	}
+	visited[types.NamespacedName{Namespace: reg.Namespace, Name: reg.Name}] = true
+	if visited[*baseKey] {
+		return &BaseRegistryError{
+			Reason:  BaseReasonCycle,
+			Message: fmt.Sprintf("OperandRegistry %s/%s extends %s, which forms a cycle", reg.Namespace, reg.Name, baseKey.String()),
+		}
+	}
+	base, err := get(ctx, *baseKey)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return &BaseRegistryError{
+				Reason:  BaseReasonNotFound,
+				Message: fmt.Sprintf("base OperandRegistry %s of OperandRegistry %s/%s is not found", baseKey.String(), reg.Namespace, reg.Name),
+				Err:     err,
+			}
+		}
+		return errors.Wrapf(err, "failed to get the base OperandRegistry %s", baseKey.String())
+	}
+	if err := mergeBaseRegistry(ctx, base, get, visited); err != nil {
+		return err
+	}
+
+	operators, err := MergeOperators(base.Spec.Operators, reg.Spec.Operators, reg.Spec.RemoveOperators)
+	if err != nil {
+		return &BaseRegistryError{
+			Reason:  BaseReasonInvalidOverlay,
+			Message: fmt.Sprintf("failed to merge OperandRegistry %s/%s onto %s: %v", reg.Namespace, reg.Name, baseKey.String(), err),
+			Err:     err,
+		}
+	}
+	reg.Spec.Operators = operators
+	if reg.Spec.CatalogSourcePolicy == nil {
+		reg.Spec.CatalogSourcePolicy = base.Spec.CatalogSourcePolicy
+	}
+	if reg.Spec.ProgressDeadlineSeconds == nil {
+		reg.Spec.ProgressDeadlineSeconds = base.Spec.ProgressDeadlineSeconds
+	}
+	return nil
+}
+
+// MergeOperators returns the base operators without the removed ones, overridden by the overlay operators with the same name,
+// followed by the other overlay operators. An overlay operator only overrides the top-level fields it sets:
+// a nested struct such as SubscriptionConfig, Timeouts or CatalogSourcePolicy replaces the base one wholesale,
+// and a field can't be unset, because an omitted field is indistinguishable from an unset one.
+func MergeOperators(base, overlay []apiv1alpha1.Operator, remove []string) ([]apiv1alpha1.Operator, error) {
+	removed := make(map[string]bool)
+	for _, name := range remove {
+		removed[name] = true
+	}
+	overrides := make(map[string]apiv1alpha1.Operator)
+	for _, o := range overlay {
+		overrides[o.Name] = o
+	}
+
+	merged := []apiv1alpha1.Operator{}
+	inherited := make(map[string]bool)
+	for _, o := range base {
+		if removed[o.Name] {
+			klog.V(3).Infof("Operator %s of the base OperandRegistry is removed", o.Name)
+			continue
+		}
+		inherited[o.Name] = true
+		override, ok := overrides[o.Name]
+		if !ok {
+			merged = append(merged, *o.DeepCopy())
+			continue
+		}
+		op, err := overrideOperator(o, override)
+		if err != nil {
+			return nil, err
+		}
+		merged = append(merged, op)
+	}
+	for _, o := range overlay {
+		if !inherited[o.Name] {
+			merged = append(merged, *o.DeepCopy())
+		}
+	}
+	return merged, nil
+}
+
+// overrideOperator sets the top-level fields of the base operator which are set in the overlay operator
+func overrideOperator(base, overlay apiv1alpha1.Operator) (apiv1alpha1.Operator, error) {
+	fields := make(map[string]json.RawMessage)
+	data, err := json.Marshal(base)
+	if err != nil {
+		return apiv1alpha1.Operator{}, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return apiv1alpha1.Operator{}, err
+	}
+	overlayFields := make(map[string]json.RawMessage)
+	if data, err = json.Marshal(overlay); err != nil {
+		return apiv1alpha1.Operator{}, err
+	}
+	if err := json.Unmarshal(data, &overlayFields); err != nil {
+		return apiv1alpha1.Operator{}, err
+	}
+	for field, value := range overlayFields {
+		fields[field] = value
+	}
+
+	op := apiv1alpha1.Operator{}
+	if data, err = json.Marshal(fields); err != nil {
+		return apiv1alpha1.Operator{}, err
+	}
+	if err := json.Unmarshal(data, &op); err != nil {
+		return apiv1alpha1.Operator{}, errors.Wrapf(err, "failed to override operator %s", base.Name)
+	}
+	return op, nil
+}
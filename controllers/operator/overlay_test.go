@@ -0,0 +1,124 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/testutil"
+)
+
+var _ = Describe("Registry overlays", func() {
+	ctx := context.Background()
+	registry := func(name string, extends *operatorv1alpha1.RegistryReference, operators ...operatorv1alpha1.Operator) *operatorv1alpha1.OperandRegistry {
+		return &operatorv1alpha1.OperandRegistry{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec:       operatorv1alpha1.OperandRegistrySpec{Operators: operators, Extends: extends},
+		}
+	}
+	newOperator := func(objs ...client.Object) *ODLMOperator {
+		c := testutil.NewFakeClient(objs...)
+		return &ODLMOperator{Client: c, Reader: c}
+	}
+	base := func() *operatorv1alpha1.OperandRegistry {
+		return registry("common-service", nil,
+			operatorv1alpha1.Operator{Name: "etcd", PackageName: "etcd", Channel: "stable", SourceName: "community-operators", SourceNamespace: "openshift-marketplace"},
+			operatorv1alpha1.Operator{Name: "jenkins", PackageName: "jenkins", Channel: "alpha", Scope: operatorv1alpha1.ScopePublic},
+		)
+	}
+
+	It("Should override the fields set by the overlay, add and remove operators", func() {
+		overlay := registry("dev", &operatorv1alpha1.RegistryReference{Name: "common-service"},
+			operatorv1alpha1.Operator{Name: "etcd", Channel: "beta", InstallPlanApproval: olmv1alpha1.ApprovalManual},
+			operatorv1alpha1.Operator{Name: "mongodb", PackageName: "mongodb", Channel: "v1"},
+		)
+		overlay.Spec.RemoveOperators = []string{"jenkins"}
+		m := newOperator(base(), overlay)
+
+		reg := overlay.DeepCopy()
+		Expect(m.MergeBaseRegistry(ctx, reg)).Should(Succeed())
+		Expect(reg.Spec.Operators).Should(Equal([]operatorv1alpha1.Operator{
+			{Name: "etcd", PackageName: "etcd", Channel: "beta", InstallPlanApproval: olmv1alpha1.ApprovalManual, SourceName: "community-operators", SourceNamespace: "openshift-marketplace"},
+			{Name: "mongodb", PackageName: "mongodb", Channel: "v1"},
+		}))
+	})
+
+	It("Should replace a nested field of the base operator as a whole", func() {
+		minute, hour := &metav1.Duration{Duration: time.Minute}, &metav1.Duration{Duration: time.Hour}
+		common := base()
+		common.Spec.Operators[0].Timeouts = &operatorv1alpha1.Timeouts{CRDeleteTimeout: minute, SyncPeriod: hour}
+		overlay := registry("dev", &operatorv1alpha1.RegistryReference{Name: "common-service"},
+			operatorv1alpha1.Operator{Name: "etcd", Timeouts: &operatorv1alpha1.Timeouts{SyncPeriod: minute}})
+		m := newOperator(common, overlay)
+
+		reg := overlay.DeepCopy()
+		Expect(m.MergeBaseRegistry(ctx, reg)).Should(Succeed())
+		Expect(reg.GetOperator("etcd").Timeouts).Should(Equal(&operatorv1alpha1.Timeouts{SyncPeriod: minute}))
+		Expect(reg.GetOperator("etcd").Channel).Should(Equal("stable"))
+	})
+
+	It("Should follow the chain of bases and detect a cycle", func() {
+		stage := registry("stage", &operatorv1alpha1.RegistryReference{Name: "common-service", Namespace: "ns"},
+			operatorv1alpha1.Operator{Name: "jenkins", Channel: "beta"})
+		prod := registry("prod", &operatorv1alpha1.RegistryReference{Name: "stage"},
+			operatorv1alpha1.Operator{Name: "etcd", SourceName: "certified-operators"})
+		m := newOperator(base(), stage, prod)
+
+		reg := prod.DeepCopy()
+		Expect(m.MergeBaseRegistry(ctx, reg)).Should(Succeed())
+		Expect(reg.GetOperator("etcd").SourceName).Should(Equal("certified-operators"))
+		Expect(reg.GetOperator("jenkins").Channel).Should(Equal("beta"))
+		Expect(reg.GetOperator("jenkins").Scope).Should(Equal(operatorv1alpha1.ScopePublic))
+
+		extending, err := m.ListExtendingRegistries(ctx, types.NamespacedName{Namespace: "ns", Name: "common-service"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(extending).Should(ConsistOf(types.NamespacedName{Namespace: "ns", Name: "stage"}, types.NamespacedName{Namespace: "ns", Name: "prod"}))
+
+		cyclic := base()
+		cyclic.Spec.Extends = &operatorv1alpha1.RegistryReference{Name: "prod"}
+		m = newOperator(cyclic, stage, prod)
+		err = m.MergeBaseRegistry(ctx, prod.DeepCopy())
+		Expect(err).Should(MatchError(ContainSubstring("forms a cycle")))
+		baseErr := &BaseRegistryError{}
+		Expect(errors.As(err, &baseErr)).Should(BeTrue())
+		Expect(baseErr.Reason).Should(Equal(BaseReasonCycle))
+		Expect(apierrors.IsNotFound(err)).Should(BeFalse())
+	})
+
+	It("Should report the missing base as a NotFound error", func() {
+		overlay := registry("dev", &operatorv1alpha1.RegistryReference{Name: "missing"})
+		m := newOperator(overlay)
+
+		_, err := m.GetOperandRegistry(ctx, types.NamespacedName{Namespace: "ns", Name: "dev"})
+		Expect(err).Should(MatchError("base OperandRegistry ns/missing of OperandRegistry ns/dev is not found"))
+		Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+		baseErr := &BaseRegistryError{}
+		Expect(errors.As(err, &baseErr)).Should(BeTrue())
+		Expect(baseErr.Reason).Should(Equal(BaseReasonNotFound))
+	})
+})
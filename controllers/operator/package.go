@@ -0,0 +1,89 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apiv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+)
+
+// ValidatePackageChannel checks that the PackageManifests of the operator namespace provide the package and the channel
+// of the operator, from its CatalogSource when it is set. It returns the type of the failed check, either
+// ConditionPackageNotFound or ConditionChannelNotFound, and a message listing the available channels.
+// The type is empty when the check passes.
+func (m *ODLMOperator) ValidatePackageChannel(ctx context.Context, o *apiv1alpha1.Operator) (apiv1alpha1.ConditionType, string, error) {
+	if o.PackageName == "" {
+		return apiv1alpha1.ConditionPackageNotFound, fmt.Sprintf("Operator %s doesn't set a packageName", o.Name), nil
+	}
+	sources, err := m.listPackageSources(ctx, o.PackageName, o.Namespace, o.Channel)
+	if err != nil {
+		return "", "", err
+	}
+	if o.SourceName != "" && o.SourceNamespace != "" {
+		var specified []packageSource
+		for _, source := range sources {
+			if source.Name == o.SourceName && source.Namespace == o.SourceNamespace {
+				specified = append(specified, source)
+			}
+		}
+		if len(specified) == 0 {
+			return apiv1alpha1.ConditionPackageNotFound, fmt.Sprintf("CatalogSource %s/%s doesn't provide package %s", o.SourceNamespace, o.SourceName, o.PackageName), nil
+		}
+		sources = specified
+	}
+	if len(sources) == 0 {
+		return apiv1alpha1.ConditionPackageNotFound, fmt.Sprintf("No CatalogSource provides package %s in the namespace %s", o.PackageName, o.Namespace), nil
+	}
+	if o.Channel == "" {
+		return "", "", nil
+	}
+
+	channels := make(map[string]bool)
+	for _, source := range sources {
+		if source.HasChannel {
+			return "", "", nil
+		}
+		for _, c := range source.Channels {
+			channels[c] = true
+		}
+	}
+	available := make([]string, 0, len(channels))
+	for c := range channels {
+		available = append(available, c)
+	}
+	sort.Strings(available)
+	return apiv1alpha1.ConditionChannelNotFound, fmt.Sprintf("Package %s has no channel %s, available channels: %s", o.PackageName, o.Channel, strings.Join(available, ", ")), nil
+}
+
+// GetDefaultChannel returns the default channel of the package in the CatalogSource, from the PackageManifests of the namespace.
+// It returns the empty string when the CatalogSource doesn't provide the package.
+func (m *ODLMOperator) GetDefaultChannel(ctx context.Context, packageName, namespace, sourceName, sourceNamespace string) (string, error) {
+	sources, err := m.listPackageSources(ctx, packageName, namespace, "")
+	if err != nil {
+		return "", err
+	}
+	for _, source := range sources {
+		if source.Name == sourceName && source.Namespace == sourceNamespace {
+			return source.DefaultChannel, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,107 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+)
+
+var _ = Describe("Package validation", func() {
+	ctx := context.Background()
+	m := &ODLMOperator{}
+	operator := func(channel, sourceName string) *operatorv1alpha1.Operator {
+		o := &operatorv1alpha1.Operator{Name: "etcd", Namespace: "etcd-ns", PackageName: "etcd", Channel: channel}
+		if sourceName != "" {
+			o.SourceName, o.SourceNamespace = sourceName, "openshift-marketplace"
+		}
+		return o
+	}
+
+	BeforeEach(func() {
+		channels := []string{"clusterwide-alpha", "singlenamespace-alpha"}
+		packageManifests.set(packageKey{packageName: "etcd", channel: "singlenamespace-alpha", namespace: "etcd-ns"},
+			[]packageSource{{Name: "community-operators", Namespace: "openshift-marketplace", HasChannel: true, Channels: channels}})
+		packageManifests.set(packageKey{packageName: "etcd", channel: "single-namespace", namespace: "etcd-ns"},
+			[]packageSource{{Name: "community-operators", Namespace: "openshift-marketplace", Channels: channels}})
+		packageManifests.set(packageKey{packageName: "etcd", channel: "alpha", namespace: "other-ns"}, []packageSource{})
+		packageManifests.set(packageKey{packageName: "etcd", channel: "", namespace: "etcd-ns"},
+			[]packageSource{{Name: "community-operators", Namespace: "openshift-marketplace", HasChannel: true, Channels: channels, DefaultChannel: "singlenamespace-alpha"}})
+	})
+	AfterEach(func() {
+		packageManifests.invalidateAll()
+	})
+
+	It("Should accept the package and channel provided by a CatalogSource", func() {
+		condType, _, err := m.ValidatePackageChannel(ctx, operator("singlenamespace-alpha", ""))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(condType).Should(BeEmpty())
+
+		condType, _, err = m.ValidatePackageChannel(ctx, operator("singlenamespace-alpha", "community-operators"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(condType).Should(BeEmpty())
+	})
+
+	It("Should list the available channels when the channel is not found", func() {
+		condType, reason, err := m.ValidatePackageChannel(ctx, operator("single-namespace", ""))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(condType).Should(Equal(operatorv1alpha1.ConditionChannelNotFound))
+		Expect(reason).Should(Equal("Package etcd has no channel single-namespace, available channels: clusterwide-alpha, singlenamespace-alpha"))
+	})
+
+	It("Should report the package not found in the namespace or in the specified CatalogSource", func() {
+		o := operator("alpha", "")
+		o.Namespace = "other-ns"
+		condType, _, err := m.ValidatePackageChannel(ctx, o)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(condType).Should(Equal(operatorv1alpha1.ConditionPackageNotFound))
+
+		condType, reason, err := m.ValidatePackageChannel(ctx, operator("singlenamespace-alpha", "certified-operators"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(condType).Should(Equal(operatorv1alpha1.ConditionPackageNotFound))
+		Expect(reason).Should(ContainSubstring("openshift-marketplace/certified-operators"))
+	})
+
+	It("Should report the operator without a packageName", func() {
+		o := operator("alpha", "")
+		o.PackageName = ""
+		condType, reason, err := m.ValidatePackageChannel(ctx, o)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(condType).Should(Equal(operatorv1alpha1.ConditionPackageNotFound))
+		Expect(reason).Should(Equal("Operator etcd doesn't set a packageName"))
+	})
+
+	It("Should track the default channel of the package when the channel is not set", func() {
+		registry := &operatorv1alpha1.OperandRegistry{
+			ObjectMeta: metav1.ObjectMeta{Name: "common-service", Namespace: "ibm-common-services"},
+			Spec:       operatorv1alpha1.OperandRegistrySpec{Operators: []operatorv1alpha1.Operator{*operator("", "community-operators")}},
+		}
+		Expect(m.resolveOperandRegistry(ctx, types.NamespacedName{Namespace: "ibm-common-services", Name: "common-service"}, registry)).Should(Succeed())
+		Expect(registry.Spec.Operators[0].Channel).Should(Equal("singlenamespace-alpha"))
+		Expect(registry.Status.DefaultChannels).Should(Equal(map[string]string{"etcd": "singlenamespace-alpha"}))
+
+		channel, err := m.GetDefaultChannel(ctx, "etcd", "etcd-ns", "certified-operators", "openshift-marketplace")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(channel).Should(BeEmpty())
+	})
+})
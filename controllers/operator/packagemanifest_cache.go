@@ -0,0 +1,300 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	constant "github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+)
+
+var (
+	packageManifestCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "odlm_packagemanifest_cache_requests_total",
+		Help: "Number of PackageManifest resolutions served by the cache, partitioned by result (hit or miss).",
+	}, []string{"result"})
+	packageManifestCacheInvalidations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "odlm_packagemanifest_cache_invalidations_total",
+		Help: "Number of PackageManifest cache invalidations, partitioned by the kind of resource triggering them.",
+	}, []string{"kind"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(packageManifestCacheRequests, packageManifestCacheInvalidations)
+}
+
+// packageSource is a CatalogSource providing a package
+type packageSource struct {
+	Name       string
+	Namespace  string
+	HasChannel bool
+	// Channels are the names of the channels of the package in the CatalogSource
+	Channels []string
+	// DefaultChannel is the default channel of the package in the CatalogSource
+	DefaultChannel string
+}
+
+type packageKey struct {
+	packageName string
+	channel     string
+	namespace   string
+}
+
+type packageEntry struct {
+	sources   []packageSource
+	cachedAt  time.Time
+	expiresAt time.Time
+}
+
+// catalogKey is the namespace/name of a CatalogSource
+type catalogKey struct {
+	name      string
+	namespace string
+}
+
+// catalogState is the observed state of a CatalogSource
+type catalogState struct {
+	generation int64
+	// connectionState is the last observed state of the gRPC connection
+	connectionState string
+}
+
+// packageManifestCache caches the CatalogSources providing a package, keyed by package/channel/namespace.
+// The entries are invalidated when a CatalogSource is added or deleted, when its generation changes,
+// or when the state of its gRPC connection changes, e.g. when the catalog pod is rolled to a new image,
+// and when a PackageManifest of the package changes. They expire after a TTL.
+type packageManifestCache struct {
+	sync.RWMutex
+	entries map[packageKey]packageEntry
+	// catalogs are the states of the CatalogSources observed by the event handlers and the health checks
+	catalogs map[catalogKey]catalogState
+	ttl      time.Duration
+	// informed is true when the PackageManifests of all the namespaces are read from the informer of the manager
+	informed bool
+}
+
+// packageManifests is shared by all the ODLM controllers
+var packageManifests = newPackageManifestCache(constant.DefaultPackageManifestCacheTTL)
+
+func newPackageManifestCache(ttl time.Duration) *packageManifestCache {
+	return &packageManifestCache{
+		entries:  make(map[packageKey]packageEntry),
+		catalogs: make(map[catalogKey]catalogState),
+		ttl:      ttl,
+	}
+}
+
+func (c *packageManifestCache) get(key packageKey) ([]packageSource, bool) {
+	c.RLock()
+	entry, ok := c.entries[key]
+	c.RUnlock()
+	if !ok || time.Now().After(entry.expiresAt) {
+		packageManifestCacheRequests.WithLabelValues("miss").Inc()
+		return nil, false
+	}
+	packageManifestCacheRequests.WithLabelValues("hit").Inc()
+	return entry.sources, true
+}
+
+func (c *packageManifestCache) set(key packageKey, sources []packageSource) {
+	c.Lock()
+	defer c.Unlock()
+	now := time.Now()
+	c.entries[key] = packageEntry{sources: sources, cachedAt: now, expiresAt: now.Add(c.ttl)}
+}
+
+// observeCatalog records the state of the CatalogSource and invalidates the entries it affects when it changes.
+// A CatalogSource observed for the first time can provide any package, so it invalidates the entries cached before
+// it was created, while an updated CatalogSource only invalidates the entries of the packages it provides.
+func (c *packageManifestCache) observeCatalog(cs *olmv1alpha1.CatalogSource) {
+	key := catalogKey{name: cs.Name, namespace: cs.Namespace}
+	state := catalogState{generation: cs.Generation, connectionState: getCatalogSourceState(cs)}
+	c.Lock()
+	defer c.Unlock()
+	observed, ok := c.catalogs[key]
+	c.catalogs[key] = state
+	if !ok {
+		// The creation timestamp is truncated to the second
+		invalidated := false
+		for pkg, entry := range c.entries {
+			if entry.cachedAt.Before(cs.CreationTimestamp.Add(time.Second)) {
+				delete(c.entries, pkg)
+				invalidated = true
+			}
+		}
+		if invalidated {
+			klog.V(3).Infof("Invalidate the cached CatalogSources for the new CatalogSource %s/%s", cs.Namespace, cs.Name)
+			packageManifestCacheInvalidations.WithLabelValues("CatalogSource").Inc()
+		}
+		return
+	}
+	if observed.generation != state.generation {
+		klog.V(3).Infof("Invalidate the cached packages of the updated CatalogSource %s/%s", cs.Namespace, cs.Name)
+		c.invalidateCatalogLocked(key)
+	} else if observed.connectionState != state.connectionState {
+		// The packages served by the catalog may change when it reconnects, e.g. after a catalog image update
+		klog.V(3).Infof("Invalidate the cached packages of the CatalogSource %s/%s, its connection state changed from %q to %q", cs.Namespace, cs.Name, observed.connectionState, state.connectionState)
+		c.invalidateCatalogLocked(key)
+	}
+}
+
+// forgetCatalog invalidates the entries of the packages provided by a deleted CatalogSource
+func (c *packageManifestCache) forgetCatalog(name, namespace string) {
+	key := catalogKey{name: name, namespace: namespace}
+	c.Lock()
+	defer c.Unlock()
+	if _, ok := c.catalogs[key]; !ok {
+		return
+	}
+	delete(c.catalogs, key)
+	klog.V(3).Infof("Invalidate the cached packages of the deleted CatalogSource %s/%s", namespace, name)
+	c.invalidateCatalogLocked(key)
+}
+
+// invalidateCatalogLocked removes the entries whose packages are provided by the CatalogSource, the lock must be held
+func (c *packageManifestCache) invalidateCatalogLocked(key catalogKey) {
+	for pkg, entry := range c.entries {
+		for _, source := range entry.sources {
+			if source.Name == key.name && source.Namespace == key.namespace {
+				delete(c.entries, pkg)
+				break
+			}
+		}
+	}
+	packageManifestCacheInvalidations.WithLabelValues("CatalogSource").Inc()
+}
+
+// invalidatePackage removes the entries of the package in all the channels and namespaces
+func (c *packageManifestCache) invalidatePackage(packageName string) {
+	c.Lock()
+	defer c.Unlock()
+	invalidated := false
+	for pkg := range c.entries {
+		if pkg.packageName == packageName {
+			delete(c.entries, pkg)
+			invalidated = true
+		}
+	}
+	if invalidated {
+		klog.V(3).Infof("Invalidate the cached CatalogSources of the updated PackageManifest %s", packageName)
+		packageManifestCacheInvalidations.WithLabelValues("PackageManifest").Inc()
+	}
+}
+
+// isInformed returns true when the PackageManifests are read from the informer of the manager
+func (c *packageManifestCache) isInformed() bool {
+	c.RLock()
+	defer c.RUnlock()
+	return c.informed
+}
+
+// invalidateAll removes all the entries
+func (c *packageManifestCache) invalidateAll() {
+	c.Lock()
+	defer c.Unlock()
+	c.entries = make(map[packageKey]packageEntry)
+	c.catalogs = make(map[catalogKey]catalogState)
+}
+
+// SetupPackageManifestCache registers the event handlers of the CatalogSources and the PackageManifests invalidating
+// the PackageManifest resolution cache, and resolves the packages from the PackageManifest informer, which is shared
+// with the watch of the OperandRegistry controller.
+// It is only used when the CatalogSources and the PackageManifests of all the namespaces are cached. In the isolated mode
+// the PackageManifests are listed with the API reader, and the cache relies on the CatalogSources observed by the health checks,
+// which read them with the API reader, and on the TTL.
+func SetupPackageManifestCache(mgr manager.Manager) error {
+	ctx := context.Background()
+	csInformer, err := mgr.GetCache().GetInformer(ctx, &olmv1alpha1.CatalogSource{})
+	if err != nil {
+		return err
+	}
+	csInformer.AddEventHandler(packageManifests.catalogSourceEventHandler())
+
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &operatorsv1.PackageManifest{}, "metadata.name", func(obj client.Object) []string {
+		return []string{obj.GetName()}
+	}); err != nil {
+		return err
+	}
+	pmInformer, err := mgr.GetCache().GetInformer(ctx, &operatorsv1.PackageManifest{})
+	if err != nil {
+		return err
+	}
+	pmInformer.AddEventHandler(packageManifests.packageManifestEventHandler())
+
+	packageManifests.Lock()
+	packageManifests.informed = true
+	packageManifests.Unlock()
+	return nil
+}
+
+// catalogSourceEventHandler observes the added and updated CatalogSources and forgets the deleted ones
+func (c *packageManifestCache) catalogSourceEventHandler() toolscache.ResourceEventHandlerFuncs {
+	observe := func(obj interface{}) {
+		if cs, ok := obj.(*olmv1alpha1.CatalogSource); ok {
+			c.observeCatalog(cs)
+		}
+	}
+	return toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    observe,
+		UpdateFunc: func(oldObj, newObj interface{}) { observe(newObj) },
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if cs, ok := obj.(*olmv1alpha1.CatalogSource); ok {
+				c.forgetCatalog(cs.Name, cs.Namespace)
+			}
+		},
+	}
+}
+
+// packageManifestEventHandler invalidates the entries of the package when its PackageManifests are added, deleted,
+// or when their status, i.e. the channels served by the CatalogSources, changes. The resyncs don't invalidate anything.
+func (c *packageManifestCache) packageManifestEventHandler() toolscache.ResourceEventHandlerFuncs {
+	invalidate := func(obj interface{}) {
+		if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		if pm, ok := obj.(*operatorsv1.PackageManifest); ok {
+			c.invalidatePackage(pm.Name)
+		}
+	}
+	return toolscache.ResourceEventHandlerFuncs{
+		AddFunc: invalidate,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldObject, okOld := oldObj.(*operatorsv1.PackageManifest)
+			newObject, okNew := newObj.(*operatorsv1.PackageManifest)
+			if okOld && okNew && reflect.DeepEqual(oldObject.Status, newObject.Status) {
+				return
+			}
+			invalidate(newObj)
+		},
+		DeleteFunc: invalidate,
+	}
+}
@@ -0,0 +1,173 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/testutil"
+)
+
+func cachedCatalogSource(name, namespace string, generation int64, state string, created time.Time) *olmv1alpha1.CatalogSource {
+	cs := &olmv1alpha1.CatalogSource{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Generation: generation, CreationTimestamp: metav1.NewTime(created)},
+	}
+	if state != "" {
+		cs.Status.GRPCConnectionState = &olmv1alpha1.GRPCConnectionState{LastObservedState: state}
+	}
+	return cs
+}
+
+var _ = Describe("PackageManifest cache", func() {
+	etcdKey := packageKey{packageName: "etcd", channel: "singlenamespace-alpha", namespace: "etcd-ns"}
+	jenkinsKey := packageKey{packageName: "jenkins-operator", channel: "alpha", namespace: "etcd-ns"}
+	sources := []packageSource{{Name: "community-operators", Namespace: "openshift-marketplace", HasChannel: true}}
+
+	It("Should only invalidate the packages of a CatalogSource whose generation changes", func() {
+		c := newPackageManifestCache(time.Minute)
+		_, ok := c.get(etcdKey)
+		Expect(ok).Should(BeFalse())
+
+		created := time.Now().Add(-time.Hour)
+		c.observeCatalog(cachedCatalogSource("community-operators", "openshift-marketplace", 1, "READY", created))
+		c.observeCatalog(cachedCatalogSource("certified-operators", "openshift-marketplace", 1, "READY", created))
+		c.set(etcdKey, sources)
+		c.set(jenkinsKey, []packageSource{{Name: "certified-operators", Namespace: "openshift-marketplace", HasChannel: true}})
+		cached, ok := c.get(etcdKey)
+		Expect(ok).Should(BeTrue())
+		Expect(cached).Should(Equal(sources))
+
+		// A status update keeping the generation and the connection state keeps the entries
+		c.observeCatalog(cachedCatalogSource("community-operators", "openshift-marketplace", 1, "READY", created))
+		_, ok = c.get(etcdKey)
+		Expect(ok).Should(BeTrue())
+
+		c.observeCatalog(cachedCatalogSource("community-operators", "openshift-marketplace", 2, "READY", created))
+		_, ok = c.get(etcdKey)
+		Expect(ok).Should(BeFalse())
+		_, ok = c.get(jenkinsKey)
+		Expect(ok).Should(BeTrue())
+
+		c.forgetCatalog("certified-operators", "openshift-marketplace")
+		_, ok = c.get(jenkinsKey)
+		Expect(ok).Should(BeFalse())
+	})
+
+	It("Should invalidate the entries cached before a new CatalogSource is created", func() {
+		c := newPackageManifestCache(time.Minute)
+		c.set(etcdKey, sources)
+
+		// A CatalogSource observed for the first time, which existed before the entry was cached
+		c.observeCatalog(cachedCatalogSource("certified-operators", "openshift-marketplace", 1, "READY", time.Now().Add(-time.Hour)))
+		_, ok := c.get(etcdKey)
+		Expect(ok).Should(BeTrue())
+
+		c.observeCatalog(cachedCatalogSource("dev-operators", "etcd-ns", 1, "READY", time.Now()))
+		_, ok = c.get(etcdKey)
+		Expect(ok).Should(BeFalse())
+	})
+
+	It("Should invalidate the packages of a CatalogSource whose connection state changes", func() {
+		c := newPackageManifestCache(time.Minute)
+		handler := c.catalogSourceEventHandler()
+		created := time.Now().Add(-time.Hour)
+		ready := cachedCatalogSource("community-operators", "openshift-marketplace", 1, "READY", created)
+		handler.OnAdd(ready)
+		c.set(etcdKey, sources)
+
+		// A status update keeping the connection state keeps the entries
+		handler.OnUpdate(ready, ready.DeepCopy())
+		_, ok := c.get(etcdKey)
+		Expect(ok).Should(BeTrue())
+
+		// The catalog pod is rolled to a new image, the CatalogSource reconnects with the same generation
+		connecting := cachedCatalogSource("community-operators", "openshift-marketplace", 1, "CONNECTING", created)
+		handler.OnUpdate(ready, connecting)
+		_, ok = c.get(etcdKey)
+		Expect(ok).Should(BeFalse())
+
+		c.set(etcdKey, sources)
+		handler.OnUpdate(connecting, ready)
+		_, ok = c.get(etcdKey)
+		Expect(ok).Should(BeFalse())
+
+		c.set(etcdKey, sources)
+		handler.OnDelete(toolscache.DeletedFinalStateUnknown{Key: "openshift-marketplace/community-operators", Obj: ready})
+		_, ok = c.get(etcdKey)
+		Expect(ok).Should(BeFalse())
+	})
+
+	It("Should resolve the packages from the informer and invalidate them on PackageManifest changes", func() {
+		packageManifest := func(catalogNamespace, defaultChannel string) *operatorsv1.PackageManifest {
+			return &operatorsv1.PackageManifest{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: catalogNamespace},
+				Status: operatorsv1.PackageManifestStatus{
+					CatalogSource:          "community-operators",
+					CatalogSourceNamespace: catalogNamespace,
+					DefaultChannel:         defaultChannel,
+					Channels:               []operatorsv1.PackageChannel{{Name: defaultChannel}},
+				},
+			}
+		}
+		global := packageManifest("openshift-marketplace", "singlenamespace-alpha")
+		c := testutil.NewFakeClient(global, packageManifest("etcd-ns", "alpha"), packageManifest("other-ns", "alpha"))
+		m := &ODLMOperator{Client: c, Reader: c}
+		packageManifests.Lock()
+		packageManifests.informed = true
+		packageManifests.Unlock()
+		defer func() {
+			packageManifests.invalidateAll()
+			packageManifests.Lock()
+			packageManifests.informed = false
+			packageManifests.Unlock()
+		}()
+
+		// The PackageManifests of the global catalog namespace and of the namespace are available in the namespace
+		sources, err := m.listPackageSources(context.Background(), "etcd", "etcd-ns", "")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(sources).Should(ConsistOf(
+			packageSource{Name: "community-operators", Namespace: "openshift-marketplace", HasChannel: true, Channels: []string{"singlenamespace-alpha"}, DefaultChannel: "singlenamespace-alpha"},
+			packageSource{Name: "community-operators", Namespace: "etcd-ns", HasChannel: true, Channels: []string{"alpha"}, DefaultChannel: "alpha"},
+		))
+
+		handler := packageManifests.packageManifestEventHandler()
+		// A resync keeps the entries
+		handler.OnUpdate(global, global.DeepCopy())
+		_, ok := packageManifests.get(packageKey{packageName: "etcd", namespace: "etcd-ns"})
+		Expect(ok).Should(BeTrue())
+
+		// The catalog changes the default channel of the package
+		handler.OnUpdate(global, packageManifest("openshift-marketplace", "clusterwide-alpha"))
+		_, ok = packageManifests.get(packageKey{packageName: "etcd", namespace: "etcd-ns"})
+		Expect(ok).Should(BeFalse())
+	})
+
+	It("Should expire the cached CatalogSources after the TTL", func() {
+		c := newPackageManifestCache(-time.Second)
+		c.set(etcdKey, sources)
+		_, ok := c.get(etcdKey)
+		Expect(ok).Should(BeFalse())
+	})
+})
@@ -0,0 +1,152 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+	"reflect"
+
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	apiv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+)
+
+// GetOperatorPhase derives the phase of the operator from its Subscription, InstallPlan and ClusterServiceVersion,
+// or from the conditions of its ClusterExtension. It returns OperatorNone when the operator isn't installed yet.
+func (m *ODLMOperator) GetOperatorPhase(ctx context.Context, opt *apiv1alpha1.Operator) (apiv1alpha1.OperatorPhase, error) {
+	if opt.IsClusterExtension() {
+		ce, err := m.GetClusterExtension(ctx, opt.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return apiv1alpha1.OperatorNone, nil
+			}
+			return apiv1alpha1.OperatorNone, errors.Wrapf(err, "failed to get ClusterExtension %s", opt.Name)
+		}
+		return GetClusterExtensionPhase(ce), nil
+	}
+
+	sub, err := m.GetSubscription(ctx, opt.Name, m.GetOperatorNamespace(opt.InstallMode, opt.Namespace), opt.PackageName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return apiv1alpha1.OperatorNone, nil
+		}
+		return apiv1alpha1.OperatorNone, err
+	}
+	if sub == nil {
+		return apiv1alpha1.OperatorNone, nil
+	}
+
+	var ip *olmv1alpha1.InstallPlan
+	if sub.Status.InstallPlanRef != nil && sub.Status.InstallPlanRef.Name != "" {
+		ip = &olmv1alpha1.InstallPlan{}
+		if err := m.Client.Get(ctx, types.NamespacedName{Namespace: sub.Namespace, Name: sub.Status.InstallPlanRef.Name}, ip); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return apiv1alpha1.OperatorNone, errors.Wrapf(err, "failed to get InstallPlan %s/%s", sub.Namespace, sub.Status.InstallPlanRef.Name)
+			}
+			ip = nil
+		}
+	}
+
+	var csv *olmv1alpha1.ClusterServiceVersion
+	if sub.Status.InstalledCSV != "" {
+		csv = &olmv1alpha1.ClusterServiceVersion{}
+		if err := m.Client.Get(ctx, types.NamespacedName{Namespace: sub.Namespace, Name: sub.Status.InstalledCSV}, csv); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return apiv1alpha1.OperatorNone, errors.Wrapf(err, "failed to get ClusterServiceVersion %s/%s", sub.Namespace, sub.Status.InstalledCSV)
+			}
+			csv = nil
+		}
+	}
+	return GetSubscriptionPhase(sub, ip, csv), nil
+}
+
+// GetSubscriptionPhase derives the operator phase from the Subscription, its latest InstallPlan and its installed
+// ClusterServiceVersion. The InstallPlan and the ClusterServiceVersion are nil when they don't exist.
+func GetSubscriptionPhase(sub *olmv1alpha1.Subscription, ip *olmv1alpha1.InstallPlan, csv *olmv1alpha1.ClusterServiceVersion) apiv1alpha1.OperatorPhase {
+	if sub.Status.GetCondition(olmv1alpha1.SubscriptionInstallPlanFailed).Status == corev1.ConditionTrue {
+		return apiv1alpha1.OperatorFailed
+	}
+	if ip != nil && ip.Status.Phase == olmv1alpha1.InstallPlanPhaseFailed {
+		return apiv1alpha1.OperatorFailed
+	}
+	if csv == nil {
+		return apiv1alpha1.OperatorInstalling
+	}
+
+	switch csv.Status.Phase {
+	case olmv1alpha1.CSVPhaseFailed:
+		return apiv1alpha1.OperatorFailed
+	case olmv1alpha1.CSVPhaseSucceeded:
+		// A newer ClusterServiceVersion is being installed to replace the running one
+		if sub.Status.State == olmv1alpha1.SubscriptionStateUpgradePending ||
+			(sub.Status.CurrentCSV != "" && sub.Status.CurrentCSV != sub.Status.InstalledCSV) {
+			return apiv1alpha1.OperatorUpdating
+		}
+		return apiv1alpha1.OperatorRunning
+	case olmv1alpha1.CSVPhaseReplacing, olmv1alpha1.CSVPhaseDeleting:
+		return apiv1alpha1.OperatorUpdating
+	default:
+		if csv.Spec.Replaces != "" {
+			return apiv1alpha1.OperatorUpdating
+		}
+		return apiv1alpha1.OperatorInstalling
+	}
+}
+
+// SubscriptionStatePredicate filters the Subscription events to the ones changing its state or its owner annotations
+func SubscriptionStatePredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldObject, okOld := e.ObjectOld.(*olmv1alpha1.Subscription)
+			newObject, okNew := e.ObjectNew.(*olmv1alpha1.Subscription)
+			if !okOld || !okNew {
+				return false
+			}
+			return !reflect.DeepEqual(oldObject.Annotations, newObject.Annotations) ||
+				oldObject.Status.State != newObject.Status.State ||
+				oldObject.Status.CurrentCSV != newObject.Status.CurrentCSV ||
+				oldObject.Status.InstalledCSV != newObject.Status.InstalledCSV ||
+				oldObject.Status.GetCondition(olmv1alpha1.SubscriptionInstallPlanFailed).Status != newObject.Status.GetCondition(olmv1alpha1.SubscriptionInstallPlanFailed).Status
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// ClusterServiceVersionPhasePredicate filters the ClusterServiceVersion events to the ones changing its phase
+func ClusterServiceVersionPhasePredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldObject, okOld := e.ObjectOld.(*olmv1alpha1.ClusterServiceVersion)
+			newObject, okNew := e.ObjectNew.(*olmv1alpha1.ClusterServiceVersion)
+			if !okOld || !okNew {
+				return false
+			}
+			return oldObject.Status.Phase != newObject.Status.Phase
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
@@ -0,0 +1,74 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+)
+
+var _ = Describe("Operator phase", func() {
+	sub := func(currentCSV, installedCSV string) *olmv1alpha1.Subscription {
+		return &olmv1alpha1.Subscription{Status: olmv1alpha1.SubscriptionStatus{CurrentCSV: currentCSV, InstalledCSV: installedCSV}}
+	}
+	csv := func(phase olmv1alpha1.ClusterServiceVersionPhase, replaces string) *olmv1alpha1.ClusterServiceVersion {
+		return &olmv1alpha1.ClusterServiceVersion{
+			Spec:   olmv1alpha1.ClusterServiceVersionSpec{Replaces: replaces},
+			Status: olmv1alpha1.ClusterServiceVersionStatus{Phase: phase},
+		}
+	}
+
+	It("Should derive the operator phase from the Subscription, InstallPlan and ClusterServiceVersion", func() {
+		Expect(GetSubscriptionPhase(sub("", ""), nil, nil)).Should(Equal(operatorv1alpha1.OperatorInstalling))
+		Expect(GetSubscriptionPhase(sub("etcd.v0.9.2", "etcd.v0.9.2"), nil, csv(olmv1alpha1.CSVPhaseInstalling, ""))).Should(Equal(operatorv1alpha1.OperatorInstalling))
+		Expect(GetSubscriptionPhase(sub("etcd.v0.9.2", "etcd.v0.9.2"), nil, csv(olmv1alpha1.CSVPhaseSucceeded, ""))).Should(Equal(operatorv1alpha1.OperatorRunning))
+		Expect(GetSubscriptionPhase(sub("etcd.v0.9.4", "etcd.v0.9.2"), nil, csv(olmv1alpha1.CSVPhaseSucceeded, ""))).Should(Equal(operatorv1alpha1.OperatorUpdating))
+		Expect(GetSubscriptionPhase(sub("etcd.v0.9.4", "etcd.v0.9.4"), nil, csv(olmv1alpha1.CSVPhasePending, "etcd.v0.9.2"))).Should(Equal(operatorv1alpha1.OperatorUpdating))
+		Expect(GetSubscriptionPhase(sub("etcd.v0.9.2", "etcd.v0.9.2"), nil, csv(olmv1alpha1.CSVPhaseFailed, ""))).Should(Equal(operatorv1alpha1.OperatorFailed))
+
+		ip := &olmv1alpha1.InstallPlan{Status: olmv1alpha1.InstallPlanStatus{Phase: olmv1alpha1.InstallPlanPhaseFailed}}
+		Expect(GetSubscriptionPhase(sub("etcd.v0.9.2", ""), ip, nil)).Should(Equal(operatorv1alpha1.OperatorFailed))
+
+		failed := sub("etcd.v0.9.2", "")
+		failed.Status.Conditions = []olmv1alpha1.SubscriptionCondition{{Type: olmv1alpha1.SubscriptionInstallPlanFailed, Status: corev1.ConditionTrue}}
+		Expect(GetSubscriptionPhase(failed, nil, nil)).Should(Equal(operatorv1alpha1.OperatorFailed))
+	})
+
+	It("Should roll the operator phases up into the registry phase", func() {
+		registry := &operatorv1alpha1.OperandRegistry{}
+		Expect(registry.SummarizePhase()).Should(Equal(operatorv1alpha1.RegistryReady))
+
+		registry.Status.OperatorsStatus = map[string]operatorv1alpha1.OperatorStatus{
+			"etcd":    {Phase: operatorv1alpha1.OperatorRunning},
+			"jenkins": {Phase: operatorv1alpha1.OperatorRunning},
+		}
+		Expect(registry.SummarizePhase()).Should(Equal(operatorv1alpha1.RegistryRunning))
+
+		registry.Status.OperatorsStatus["jenkins"] = operatorv1alpha1.OperatorStatus{Phase: operatorv1alpha1.OperatorInstalling}
+		Expect(registry.SummarizePhase()).Should(Equal(operatorv1alpha1.RegistryPending))
+
+		registry.Status.OperatorsStatus["etcd"] = operatorv1alpha1.OperatorStatus{Phase: operatorv1alpha1.OperatorUpdating}
+		Expect(registry.SummarizePhase()).Should(Equal(operatorv1alpha1.RegistryUpdating))
+
+		registry.Status.OperatorsStatus["jenkins"] = operatorv1alpha1.OperatorStatus{Phase: operatorv1alpha1.OperatorFailed}
+		Expect(registry.SummarizePhase()).Should(Equal(operatorv1alpha1.RegistryFailed))
+	})
+})
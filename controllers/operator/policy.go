@@ -0,0 +1,152 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+)
+
+// RequestPolicy is the OperandPolicies applying to the namespace of an OperandRequest
+type RequestPolicy struct {
+	namespace string
+	policies  []apiv1alpha1.OperandPolicy
+	// hasRules is true when a policy restricts the operators with rules
+	hasRules bool
+	// limitPolicy is the OperandPolicy with the lowest operand limit, and allowedOperands
+	// are the operands of the namespace within the limit
+	limitPolicy     *apiv1alpha1.OperandPolicy
+	allowedOperands map[string]bool
+}
+
+// GetRequestPolicy gets the OperandPolicies applying to the namespace of the OperandRequest.
+func (m *ODLMOperator) GetRequestPolicy(ctx context.Context, requestInstance *apiv1alpha1.OperandRequest) (*RequestPolicy, error) {
+	p := &RequestPolicy{namespace: requestInstance.Namespace}
+	policyList := &apiv1alpha1.OperandPolicyList{}
+	if err := m.Reader.List(ctx, policyList); err != nil {
+		return nil, errors.Wrap(err, "failed to list OperandPolicies")
+	}
+	if len(policyList.Items) == 0 {
+		return p, nil
+	}
+	ns := &corev1.Namespace{}
+	if err := m.Reader.Get(ctx, types.NamespacedName{Name: requestInstance.Namespace}, ns); err != nil {
+		return nil, errors.Wrapf(err, "failed to get namespace %s", requestInstance.Namespace)
+	}
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+		applies, err := policy.AppliesTo(ns.Name, ns.Labels)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid namespaceSelector of OperandPolicy %s", policy.Name)
+		}
+		if !applies {
+			continue
+		}
+		p.policies = append(p.policies, *policy)
+		p.hasRules = p.hasRules || len(policy.Spec.Rules) > 0
+		if policy.Spec.MaxOperands != nil && (p.limitPolicy == nil || *policy.Spec.MaxOperands < *p.limitPolicy.Spec.MaxOperands) {
+			p.limitPolicy = policy
+		}
+	}
+	if p.limitPolicy != nil {
+		allowed, err := m.limitOperands(ctx, requestInstance.Namespace, int(*p.limitPolicy.Spec.MaxOperands))
+		if err != nil {
+			return nil, err
+		}
+		p.allowedOperands = allowed
+	}
+	return p, nil
+}
+
+// limitOperands returns the first operands of the OperandRequests in the namespace within the limit,
+// in the order of the creation of the OperandRequests, then of their names, and of the operands in their spec.
+// The operands of an OperandRequest created later don't take the place of the ones already allowed.
+func (m *ODLMOperator) limitOperands(ctx context.Context, namespace string, limit int) (map[string]bool, error) {
+	requestList := &apiv1alpha1.OperandRequestList{}
+	if err := m.Client.List(ctx, requestList, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrapf(err, "failed to list the OperandRequests in namespace %s", namespace)
+	}
+	sort.Slice(requestList.Items, func(i, j int) bool {
+		ti, tj := requestList.Items[i].CreationTimestamp, requestList.Items[j].CreationTimestamp
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return requestList.Items[i].Name < requestList.Items[j].Name
+	})
+	allowed := make(map[string]bool)
+	for _, request := range requestList.Items {
+		if !request.DeletionTimestamp.IsZero() {
+			continue
+		}
+		for _, req := range request.Spec.Requests {
+			for _, operand := range req.Operands {
+				if !allowed[operand.Name] && len(allowed) < limit {
+					allowed[operand.Name] = true
+				}
+			}
+		}
+	}
+	return allowed, nil
+}
+
+// CheckOperator returns the reason why the OperandPolicies don't allow requesting the operator from the OperandRegistry,
+// it is empty when it is allowed
+func (p *RequestPolicy) CheckOperator(registryKey types.NamespacedName, operatorName string) string {
+	if len(p.policies) == 0 {
+		return ""
+	}
+	if p.allowedOperands != nil && !p.allowedOperands[operatorName] {
+		return fmt.Sprintf("Namespace %s exceeds the limit of %d operands of OperandPolicy %s", p.namespace, *p.limitPolicy.Spec.MaxOperands, p.limitPolicy.Name)
+	}
+	if !p.hasRules {
+		return ""
+	}
+	for _, policy := range p.policies {
+		for _, rule := range policy.Spec.Rules {
+			if rule.Allows(registryKey.String(), operatorName) {
+				return ""
+			}
+		}
+	}
+	return fmt.Sprintf("No OperandPolicy allows namespace %s to request operator %s from OperandRegistry %s", p.namespace, operatorName, registryKey.String())
+}
+
+// CheckKind returns the reason why the OperandPolicies don't allow the operand of the operator from the OperandRegistry
+// to create the custom resource kind, it is empty when it is allowed
+func (p *RequestPolicy) CheckKind(registryKey types.NamespacedName, operatorName, apiVersion, kind string) string {
+	if !p.hasRules {
+		return ""
+	}
+	groupKind := schema.FromAPIVersionAndKind(apiVersion, kind).GroupKind().String()
+	for _, policy := range p.policies {
+		for _, rule := range policy.Spec.Rules {
+			if rule.Allows(registryKey.String(), operatorName) && rule.AllowsKind(groupKind) {
+				return ""
+			}
+		}
+	}
+	return fmt.Sprintf("No OperandPolicy allows namespace %s to create %s through operator %s", p.namespace, groupKind, operatorName)
+}
@@ -0,0 +1,124 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/testutil"
+)
+
+var _ = Describe("OperandPolicy", func() {
+	ctx := context.Background()
+	common := types.NamespacedName{Namespace: "ibm-common-services", Name: "common-service"}
+	newOperator := func(objs ...client.Object) *ODLMOperator {
+		c := testutil.NewFakeClient(objs...)
+		return &ODLMOperator{Client: c, Reader: c}
+	}
+	request := func(name string, operands ...string) *operatorv1alpha1.OperandRequest {
+		req := operatorv1alpha1.Request{Registry: common.Name, RegistryNamespace: common.Namespace}
+		for _, operand := range operands {
+			req.Operands = append(req.Operands, operatorv1alpha1.Operand{Name: operand})
+		}
+		return &operatorv1alpha1.OperandRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tenant"},
+			Spec:       operatorv1alpha1.OperandRequestSpec{Requests: []operatorv1alpha1.Request{req}},
+		}
+	}
+	tenant := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant", Labels: map[string]string{"tier": "tenant"}}}
+
+	It("Should not restrict a namespace no OperandPolicy applies to", func() {
+		policy := &operatorv1alpha1.OperandPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "other"},
+			Spec:       operatorv1alpha1.OperandPolicySpec{Namespaces: []string{"other"}, Rules: []operatorv1alpha1.PolicyRule{{Operators: []string{"jenkins"}}}},
+		}
+		m := newOperator(tenant, policy)
+		p, err := m.GetRequestPolicy(ctx, request("example", "etcd"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(p.CheckOperator(common, "etcd")).Should(BeEmpty())
+		Expect(p.CheckKind(common, "etcd", "etcd.database.coreos.com/v1beta2", "EtcdCluster")).Should(BeEmpty())
+	})
+
+	It("Should only allow the operators and the kinds of the rules", func() {
+		policy := &operatorv1alpha1.OperandPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenants"},
+			Spec: operatorv1alpha1.OperandPolicySpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "tenant"}},
+				Rules: []operatorv1alpha1.PolicyRule{
+					{Registries: []string{common.String()}, Operators: []string{"etcd"}, Kinds: []string{"EtcdCluster.etcd.database.coreos.com"}},
+					{Operators: []string{"jenkins"}},
+				},
+			},
+		}
+		m := newOperator(tenant, policy)
+		p, err := m.GetRequestPolicy(ctx, request("example", "etcd"))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(p.CheckOperator(common, "etcd")).Should(BeEmpty())
+		Expect(p.CheckOperator(common, "jenkins")).Should(BeEmpty())
+		Expect(p.CheckOperator(types.NamespacedName{Namespace: "tenant", Name: "team"}, "etcd")).Should(Equal("No OperandPolicy allows namespace tenant to request operator etcd from OperandRegistry tenant/team"))
+		Expect(p.CheckOperator(common, "mongodb")).ShouldNot(BeEmpty())
+
+		Expect(p.CheckKind(common, "etcd", "etcd.database.coreos.com/v1beta2", "EtcdCluster")).Should(BeEmpty())
+		Expect(p.CheckKind(common, "etcd", "v1", "ConfigMap")).Should(Equal("No OperandPolicy allows namespace tenant to create ConfigMap through operator etcd"))
+		Expect(p.CheckKind(common, "jenkins", "jenkins.io/v1alpha2", "Jenkins")).ShouldNot(BeEmpty())
+	})
+
+	It("Should allow the first operands of the namespace within the lowest limit", func() {
+		limit := func(name string, max int32) *operatorv1alpha1.OperandPolicy {
+			return &operatorv1alpha1.OperandPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       operatorv1alpha1.OperandPolicySpec{Namespaces: []string{"tenant"}, MaxOperands: &max},
+			}
+		}
+		m := newOperator(tenant, limit("loose", 5), limit("strict", 2), request("a", "etcd", "jenkins"), request("b", "jenkins", "mongodb"))
+		p, err := m.GetRequestPolicy(ctx, request("b", "jenkins", "mongodb"))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(p.CheckOperator(common, "etcd")).Should(BeEmpty())
+		Expect(p.CheckOperator(common, "jenkins")).Should(BeEmpty())
+		Expect(p.CheckOperator(common, "mongodb")).Should(Equal("Namespace tenant exceeds the limit of 2 operands of OperandPolicy strict"))
+		// A policy without rules doesn't restrict the kinds
+		Expect(p.CheckKind(common, "etcd", "etcd.database.coreos.com/v1beta2", "EtcdCluster")).Should(BeEmpty())
+	})
+
+	It("Should allow the operands of the OperandRequests created first", func() {
+		max := int32(1)
+		policy := &operatorv1alpha1.OperandPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "strict"},
+			Spec:       operatorv1alpha1.OperandPolicySpec{Namespaces: []string{"tenant"}, MaxOperands: &max},
+		}
+		older, newer := request("z", "etcd"), request("a", "jenkins")
+		older.CreationTimestamp = metav1.NewTime(metav1.Now().Add(-time.Hour))
+		newer.CreationTimestamp = metav1.Now()
+		m := newOperator(tenant, policy, older, newer)
+		p, err := m.GetRequestPolicy(ctx, newer)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(p.CheckOperator(common, "etcd")).Should(BeEmpty())
+		Expect(p.CheckOperator(common, "jenkins")).Should(Equal("Namespace tenant exceeds the limit of 1 operands of OperandPolicy strict"))
+	})
+})
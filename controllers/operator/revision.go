@@ -0,0 +1,152 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	constant "github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+)
+
+// RevisionName returns the name of the ControllerRevision recording the spec, it is suffixed with the hash of the spec
+func RevisionName(ownerName string, data []byte) string {
+	hasher := fnv.New32a()
+	hasher.Write(data)
+	return ownerName + "-" + rand.SafeEncodeString(strconv.FormatUint(uint64(hasher.Sum32()), 10))
+}
+
+// ListRevisions lists the ControllerRevisions of the object, sorted by the revision number
+func (m *ODLMOperator) ListRevisions(ctx context.Context, kind string, owner client.Object) ([]appsv1.ControllerRevision, error) {
+	revisionList := &appsv1.ControllerRevisionList{}
+	opts := []client.ListOption{
+		client.InNamespace(owner.GetNamespace()),
+		client.MatchingLabels(map[string]string{
+			constant.RevisionOwnerKindLabel: kind,
+			constant.RevisionOwnerNameLabel: owner.GetName(),
+		}),
+	}
+	if err := m.Reader.List(ctx, revisionList, opts...); err != nil {
+		return nil, errors.Wrapf(err, "failed to list the ControllerRevisions of %s %s/%s", kind, owner.GetNamespace(), owner.GetName())
+	}
+	revisions := revisionList.Items
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	return revisions, nil
+}
+
+// RecordRevision records the applied spec of the object as a ControllerRevision and deletes the oldest revisions exceeding the history limit.
+// When the spec is the same as an earlier revision, that revision becomes the latest one.
+// It returns the name of the ControllerRevision recording the spec.
+func (m *ODLMOperator) RecordRevision(ctx context.Context, kind string, owner client.Object, spec interface{}, limit int32) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to marshal the spec of %s %s/%s", kind, owner.GetNamespace(), owner.GetName())
+	}
+	name := RevisionName(owner.GetName(), data)
+
+	revisions, err := m.ListRevisions(ctx, kind, owner)
+	if err != nil {
+		return "", err
+	}
+
+	var current *appsv1.ControllerRevision
+	var history []appsv1.ControllerRevision
+	var latest int64
+	for i := range revisions {
+		if revisions[i].Name == name {
+			current = &revisions[i]
+		} else {
+			history = append(history, revisions[i])
+		}
+		if revisions[i].Revision > latest {
+			latest = revisions[i].Revision
+		}
+	}
+
+	if current == nil {
+		revision := &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: owner.GetNamespace(),
+				Labels: map[string]string{
+					constant.RevisionOwnerKindLabel: kind,
+					constant.RevisionOwnerNameLabel: owner.GetName(),
+				},
+			},
+			Data:     runtime.RawExtension{Raw: data},
+			Revision: latest + 1,
+		}
+		if err := controllerutil.SetControllerReference(owner, revision, m.Scheme); err != nil {
+			return "", errors.Wrapf(err, "failed to set the owner of ControllerRevision %s/%s", revision.Namespace, revision.Name)
+		}
+		klog.V(2).Infof("Recording revision %d of %s %s/%s", revision.Revision, kind, owner.GetNamespace(), owner.GetName())
+		if err := m.Create(ctx, revision); err != nil {
+			return "", errors.Wrapf(err, "failed to create ControllerRevision %s/%s", revision.Namespace, revision.Name)
+		}
+	} else if current.Revision != latest {
+		klog.V(2).Infof("Restoring revision %d of %s %s/%s as revision %d", current.Revision, kind, owner.GetNamespace(), owner.GetName(), latest+1)
+		original := current.DeepCopy()
+		current.Revision = latest + 1
+		if err := m.Patch(ctx, current, client.MergeFrom(original)); err != nil {
+			return "", errors.Wrapf(err, "failed to update ControllerRevision %s/%s", current.Namespace, current.Name)
+		}
+	}
+
+	// Delete the oldest revisions exceeding the history limit
+	for i := 0; i < len(history)-int(limit); i++ {
+		klog.V(2).Infof("Deleting revision %d of %s %s/%s", history[i].Revision, kind, owner.GetNamespace(), owner.GetName())
+		if err := m.Delete(ctx, &history[i]); client.IgnoreNotFound(err) != nil {
+			return "", errors.Wrapf(err, "failed to delete ControllerRevision %s/%s", history[i].Namespace, history[i].Name)
+		}
+	}
+	return name, nil
+}
+
+// RestoreRevision restores the spec of the object from the revision, which is either the revision number or the name of the ControllerRevision.
+// It returns the restored revision, or nil when the revision is not found.
+func (m *ODLMOperator) RestoreRevision(ctx context.Context, kind string, owner client.Object, revision string, spec interface{}) (*appsv1.ControllerRevision, error) {
+	revisions, err := m.ListRevisions(ctx, kind, owner)
+	if err != nil {
+		return nil, err
+	}
+	number, numberErr := strconv.ParseInt(revision, 10, 64)
+	for i := range revisions {
+		if revisions[i].Name != revision && (numberErr != nil || revisions[i].Revision != number) {
+			continue
+		}
+		if err := json.Unmarshal(revisions[i].Data.Raw, spec); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal the spec of ControllerRevision %s/%s", revisions[i].Namespace, revisions[i].Name)
+		}
+		return &revisions[i], nil
+	}
+	klog.Warningf("Revision %s of %s %s/%s is not found", revision, kind, owner.GetNamespace(), owner.GetName())
+	return nil, nil
+}
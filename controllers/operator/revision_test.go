@@ -0,0 +1,170 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/testutil"
+)
+
+var _ = Describe("Revision history", func() {
+	ctx := context.Background()
+
+	newOperator := func() *ODLMOperator {
+		c := testutil.NewFakeClient()
+		return &ODLMOperator{Client: c, Reader: c, Scheme: c.Scheme()}
+	}
+	registry := &operatorv1alpha1.OperandRegistry{
+		ObjectMeta: metav1.ObjectMeta{Name: "common-service", Namespace: "ibm-common-services", UID: "registry-uid"},
+	}
+	spec := func(channel string) operatorv1alpha1.OperandRegistrySpec {
+		return operatorv1alpha1.OperandRegistrySpec{
+			Operators: []operatorv1alpha1.Operator{{Name: "etcd", PackageName: "etcd", Channel: channel, SourceName: "community-operators", SourceNamespace: "openshift-marketplace"}},
+		}
+	}
+
+	It("Should record the applied specs and prune the revisions exceeding the history limit", func() {
+		m := newOperator()
+		alpha, err := m.RecordRevision(ctx, "OperandRegistry", registry, spec("alpha"), 1)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = m.RecordRevision(ctx, "OperandRegistry", registry, spec("beta"), 1)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		// an unchanged spec doesn't create a new revision
+		again, err := m.RecordRevision(ctx, "OperandRegistry", registry, spec("alpha"), 1)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(again).Should(Equal(alpha))
+
+		revisions, err := m.ListRevisions(ctx, "OperandRegistry", registry)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(revisions).Should(HaveLen(2))
+		Expect(revisions[1].Name).Should(Equal(alpha))
+		Expect(revisions[1].Revision).Should(Equal(int64(3)))
+		Expect(revisions[1].OwnerReferences).Should(HaveLen(1))
+
+		_, err = m.RecordRevision(ctx, "OperandRegistry", registry, spec("stable"), 1)
+		Expect(err).ShouldNot(HaveOccurred())
+		revisions, err = m.ListRevisions(ctx, "OperandRegistry", registry)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(revisions).Should(HaveLen(2))
+		Expect(revisions[0].Name).Should(Equal(alpha))
+	})
+
+	It("Should restore the spec by the revision number or the revision name", func() {
+		m := newOperator()
+		alpha, err := m.RecordRevision(ctx, "OperandRegistry", registry, spec("alpha"), 10)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = m.RecordRevision(ctx, "OperandRegistry", registry, spec("beta"), 10)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		restored := operatorv1alpha1.OperandRegistrySpec{}
+		revision, err := m.RestoreRevision(ctx, "OperandRegistry", registry, "1", &restored)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(revision.Name).Should(Equal(alpha))
+		Expect(restored.Operators[0].Channel).Should(Equal("alpha"))
+
+		restored = operatorv1alpha1.OperandRegistrySpec{}
+		_, err = m.RestoreRevision(ctx, "OperandRegistry", registry, alpha, &restored)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(restored.Operators[0].Channel).Should(Equal("alpha"))
+
+		revision, err = m.RestoreRevision(ctx, "OperandRegistry", registry, "5", &restored)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(revision).Should(BeNil())
+	})
+
+	It("Should get the OperandRegistry with the revision rolled out to the OperandRequest", func() {
+		m := newOperator()
+		previous, err := m.RecordRevision(ctx, "OperandRegistry", registry, spec("alpha"), 10)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		current := registry.DeepCopy()
+		current.Spec = spec("beta")
+		current.Spec.RolloutStrategy = &operatorv1alpha1.RolloutStrategy{}
+		current.Status.Rollout = &operatorv1alpha1.RolloutStatus{
+			Revision:         "common-service-next",
+			PreviousRevision: previous,
+			Phase:            operatorv1alpha1.RolloutProgressing,
+			Promoted:         []string{"canary/req"},
+		}
+		Expect(m.Client.Create(ctx, current)).Should(Succeed())
+		registryKey := types.NamespacedName{Namespace: registry.Namespace, Name: registry.Name}
+
+		reg, revision, err := m.GetOperandRegistryForRequest(ctx, registryKey, types.NamespacedName{Namespace: "ns-a", Name: "req"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(revision).Should(Equal(previous))
+		Expect(reg.Spec.Operators[0].Channel).Should(Equal("alpha"))
+
+		reg, revision, err = m.GetOperandRegistryForRequest(ctx, registryKey, types.NamespacedName{Namespace: "canary", Name: "req"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(revision).ShouldNot(Equal(previous))
+		Expect(reg.Spec.Operators[0].Channel).Should(Equal("beta"))
+	})
+
+	It("Should pin the base OperandRegistry in the revisions, so a change of the base is rolled out", func() {
+		m := newOperator()
+		base := &operatorv1alpha1.OperandRegistry{
+			ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "ibm-common-services"},
+			Spec:       spec("alpha"),
+		}
+		Expect(m.Client.Create(ctx, base)).Should(Succeed())
+		current := registry.DeepCopy()
+		current.Spec.Extends = &operatorv1alpha1.RegistryReference{Name: "base"}
+		current.Spec.RolloutStrategy = &operatorv1alpha1.RolloutStrategy{}
+		Expect(m.Client.Create(ctx, current)).Should(Succeed())
+
+		data, err := m.GetRegistryRevision(ctx, current)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(data.Base.Operators[0].Channel).Should(Equal("alpha"))
+		previous, err := m.RecordRevision(ctx, "OperandRegistry", current, data, 10)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		base.Spec = spec("beta")
+		Expect(m.Client.Update(ctx, base)).Should(Succeed())
+		data, err = m.GetRegistryRevision(ctx, current)
+		Expect(err).ShouldNot(HaveOccurred())
+		next, err := m.RecordRevision(ctx, "OperandRegistry", current, data, 10)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(next).ShouldNot(Equal(previous))
+
+		current.Status.Rollout = &operatorv1alpha1.RolloutStatus{
+			Revision:         next,
+			PreviousRevision: previous,
+			Phase:            operatorv1alpha1.RolloutProgressing,
+			Promoted:         []string{"canary/req"},
+		}
+		Expect(m.Client.Status().Update(ctx, current)).Should(Succeed())
+		registryKey := types.NamespacedName{Namespace: registry.Namespace, Name: registry.Name}
+
+		reg, revision, err := m.GetOperandRegistryForRequest(ctx, registryKey, types.NamespacedName{Namespace: "ns-a", Name: "req"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(revision).Should(Equal(previous))
+		Expect(reg.Spec.Operators[0].Channel).Should(Equal("alpha"))
+
+		reg, revision, err = m.GetOperandRegistryForRequest(ctx, registryKey, types.NamespacedName{Namespace: "canary", Name: "req"})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(revision).Should(Equal(next))
+		Expect(reg.Spec.Operators[0].Channel).Should(Equal("beta"))
+	})
+})
@@ -0,0 +1,128 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	constant "github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+)
+
+// The keys of the timeouts in the ODLM ConfigMap, they are also the names of the manager flags.
+const (
+	CRDeleteTimeoutKey  = "cr-delete-timeout"
+	SubDeleteTimeoutKey = "sub-delete-timeout"
+	RequeueDurationKey  = "requeue-duration"
+	SyncPeriodKey       = "sync-period"
+)
+
+// DefaultTimeouts returns the default timeouts and requeue intervals.
+func DefaultTimeouts() operatorv1alpha1.Timeouts {
+	return operatorv1alpha1.Timeouts{
+		CRDeleteTimeout:  &metav1.Duration{Duration: constant.DefaultCRDeleteTimeout},
+		SubDeleteTimeout: &metav1.Duration{Duration: constant.DefaultSubDeleteTimeout},
+		RequeueDuration:  &metav1.Duration{Duration: constant.DefaultRequeueDuration},
+		SyncPeriod:       &metav1.Duration{Duration: constant.DefaultSyncPeriod},
+	}
+}
+
+// GlobalTimeouts returns the timeouts and requeue intervals used when they are not overridden
+// by the OperandRegistry or the OperandConfig, the default ones overridden by the Timeouts of the ODLMOperator.
+func (m *ODLMOperator) GlobalTimeouts() operatorv1alpha1.Timeouts {
+	return DefaultTimeouts().Merge(m.Timeouts)
+}
+
+// EffectiveTimeouts returns the timeouts of an operator, the global timeouts overridden
+// by the OperandRegistry operator and then by the OperandConfig service. Both of them can be nil.
+func (m *ODLMOperator) EffectiveTimeouts(opt *operatorv1alpha1.Operator, service *operatorv1alpha1.ConfigService) operatorv1alpha1.Timeouts {
+	timeouts := m.GlobalTimeouts()
+	if opt != nil {
+		timeouts = timeouts.Merge(opt.Timeouts)
+	}
+	if service != nil {
+		timeouts = timeouts.Merge(service.Timeouts)
+	}
+	return timeouts
+}
+
+// ParseTimeouts converts the durations keyed by the flag names into timeouts, the missing keys are left unset.
+func ParseTimeouts(data map[string]string) (*operatorv1alpha1.Timeouts, error) {
+	timeouts := &operatorv1alpha1.Timeouts{}
+	fields := map[string]**metav1.Duration{
+		CRDeleteTimeoutKey:  &timeouts.CRDeleteTimeout,
+		SubDeleteTimeoutKey: &timeouts.SubDeleteTimeout,
+		RequeueDurationKey:  &timeouts.RequeueDuration,
+		SyncPeriodKey:       &timeouts.SyncPeriod,
+	}
+	for key, field := range fields {
+		value, ok := data[key]
+		if !ok {
+			continue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", key)
+		}
+		*field = &metav1.Duration{Duration: d}
+	}
+	if err := ValidateTimeouts(timeouts); err != nil {
+		return nil, err
+	}
+	return timeouts, nil
+}
+
+// ValidateTimeouts checks the timeouts set are positive.
+func ValidateTimeouts(timeouts *operatorv1alpha1.Timeouts) error {
+	fields := map[string]*metav1.Duration{
+		CRDeleteTimeoutKey:  timeouts.CRDeleteTimeout,
+		SubDeleteTimeoutKey: timeouts.SubDeleteTimeout,
+		RequeueDurationKey:  timeouts.RequeueDuration,
+		SyncPeriodKey:       timeouts.SyncPeriod,
+	}
+	for key, d := range fields {
+		if d != nil && d.Duration <= 0 {
+			return errors.Errorf("%s must be positive, got %s", key, d.Duration)
+		}
+	}
+	return nil
+}
+
+// LoadTimeouts reads the timeouts from the ODLM ConfigMap in the namespace, it returns nil when the ConfigMap doesn't exist.
+// The ConfigMap is only read at startup, a change of the timeouts takes effect when ODLM restarts.
+func LoadTimeouts(ctx context.Context, reader client.Reader, namespace string) (*operatorv1alpha1.Timeouts, error) {
+	cm := &corev1.ConfigMap{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: constant.ODLMConfigMapName, Namespace: namespace}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get ConfigMap %s/%s", namespace, constant.ODLMConfigMapName)
+	}
+	timeouts, err := ParseTimeouts(cm.Data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid ConfigMap %s/%s", namespace, constant.ODLMConfigMapName)
+	}
+	return timeouts, nil
+}
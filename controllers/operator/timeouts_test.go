@@ -0,0 +1,87 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package operator
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	constant "github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/testutil"
+)
+
+var _ = Describe("Timeouts", func() {
+	It("Should override the global timeouts by the OperandRegistry and then by the OperandConfig", func() {
+		m := &ODLMOperator{Timeouts: &operatorv1alpha1.Timeouts{SyncPeriod: &metav1.Duration{Duration: time.Hour}}}
+		opt := &operatorv1alpha1.Operator{Name: "etcd", Timeouts: &operatorv1alpha1.Timeouts{
+			CRDeleteTimeout:  &metav1.Duration{Duration: 30 * time.Minute},
+			SubDeleteTimeout: &metav1.Duration{Duration: 20 * time.Minute},
+		}}
+		service := &operatorv1alpha1.ConfigService{Name: "etcd", Timeouts: &operatorv1alpha1.Timeouts{
+			CRDeleteTimeout: &metav1.Duration{Duration: time.Minute},
+		}}
+
+		timeouts := m.EffectiveTimeouts(opt, service)
+		Expect(timeouts.CRDeleteTimeout.Duration).Should(Equal(time.Minute))
+		Expect(timeouts.SubDeleteTimeout.Duration).Should(Equal(20 * time.Minute))
+		Expect(timeouts.RequeueDuration.Duration).Should(Equal(constant.DefaultRequeueDuration))
+		Expect(timeouts.SyncPeriod.Duration).Should(Equal(time.Hour))
+
+		// The overrides are copied and the global timeouts stay unchanged
+		timeouts.CRDeleteTimeout.Duration = time.Second
+		Expect(service.Timeouts.CRDeleteTimeout.Duration).Should(Equal(time.Minute))
+		Expect(m.EffectiveTimeouts(nil, nil).CRDeleteTimeout.Duration).Should(Equal(constant.DefaultCRDeleteTimeout))
+		Expect(m.Timeouts.CRDeleteTimeout).Should(BeNil())
+
+		// An ODLMOperator without timeouts uses the default ones
+		Expect((&ODLMOperator{}).GlobalTimeouts()).Should(Equal(DefaultTimeouts()))
+	})
+
+	It("Should load the timeouts from the ODLM ConfigMap", func() {
+		ctx := context.Background()
+		reader := testutil.NewFakeClient()
+		timeouts, err := LoadTimeouts(ctx, reader, "ibm-common-services")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(timeouts).Should(BeNil())
+
+		reader = testutil.NewFakeClient(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: constant.ODLMConfigMapName, Namespace: "ibm-common-services"},
+			Data:       map[string]string{CRDeleteTimeoutKey: "15m", RequeueDurationKey: "5s"},
+		})
+		timeouts, err = LoadTimeouts(ctx, reader, "ibm-common-services")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(timeouts.CRDeleteTimeout.Duration).Should(Equal(15 * time.Minute))
+		Expect(timeouts.RequeueDuration.Duration).Should(Equal(5 * time.Second))
+		Expect(timeouts.SubDeleteTimeout).Should(BeNil())
+		Expect(timeouts.SyncPeriod).Should(BeNil())
+	})
+
+	It("Should reject invalid durations", func() {
+		_, err := ParseTimeouts(map[string]string{SyncPeriodKey: "often"})
+		Expect(err).Should(HaveOccurred())
+		_, err = ParseTimeouts(map[string]string{SubDeleteTimeoutKey: "0s"})
+		Expect(err).Should(HaveOccurred())
+		Expect(ValidateTimeouts(&operatorv1alpha1.Timeouts{RequeueDuration: &metav1.Duration{Duration: -time.Second}})).ShouldNot(Succeed())
+		Expect(ValidateTimeouts(&operatorv1alpha1.Timeouts{RequeueDuration: &metav1.Duration{Duration: time.Second}})).Should(Succeed())
+	})
+})
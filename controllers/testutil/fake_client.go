@@ -0,0 +1,47 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package testutil
+
+import (
+	olmv1 "github.com/operator-framework/api/pkg/operators/v1"
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+)
+
+// NewScheme returns a scheme with the Kubernetes, OLM, package server and ODLM types
+func NewScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(olmv1alpha1.AddToScheme(s))
+	utilruntime.Must(olmv1.AddToScheme(s))
+	utilruntime.Must(operatorsv1.AddToScheme(s))
+	utilruntime.Must(apiv1alpha1.AddToScheme(s))
+	return s
+}
+
+// NewFakeClient returns a fake client holding the objects, for the unit tests which don't need an API server.
+// It is used as both the client and the API reader of the ODLMOperator under test.
+func NewFakeClient(objs ...client.Object) client.Client {
+	return fake.NewClientBuilder().WithScheme(NewScheme()).WithObjects(objs...).Build()
+}
@@ -23,6 +23,8 @@ import (
 	"time"
 
 	"k8s.io/client-go/discovery"
+
+	constant "github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
 )
 
 // GetOperatorNamespace returns the Namespace of the operator
@@ -52,6 +54,15 @@ func GetInstallScope() string {
 	return ns
 }
 
+// GetGlobalCatalogNamespace returns the namespace of the CatalogSources OLM makes available to all the namespaces
+func GetGlobalCatalogNamespace() string {
+	ns, found := os.LookupEnv("GLOBAL_CATALOG_NAMESPACE")
+	if !found || ns == "" {
+		return constant.DefaultGlobalCatalogNamespace
+	}
+	return ns
+}
+
 func GetIsolatedMode() bool {
 	isEnable, found := os.LookupEnv("ISOLATED_MODE")
 	if !found || isEnable != "true" {
@@ -5,6 +5,7 @@ go 1.17
 require (
 	github.com/IBM/controller-filtered-cache v0.3.2
 	github.com/IBM/ibm-namespace-scope-operator v1.0.0-alpha
+	github.com/blang/semver/v4 v4.0.0
 	github.com/coreos/etcd-operator v0.9.4
 	github.com/deckarep/golang-set v1.7.1
 	github.com/mohae/deepcopy v0.0.0-20170929034955-c48cc78d4826
@@ -13,19 +14,20 @@ require (
 	github.com/operator-framework/api v0.6.2
 	github.com/operator-framework/operator-lifecycle-manager v0.17.0
 	github.com/pkg/errors v0.9.1
+	github.com/prometheus/client_golang v1.11.0
 	k8s.io/api v0.21.3
 	k8s.io/apimachinery v0.21.3
 	k8s.io/client-go v0.21.3
 	k8s.io/klog v1.0.0
 	sigs.k8s.io/controller-runtime v0.9.6
 	sigs.k8s.io/kubebuilder v1.0.9-0.20200805184228-f7a3b65dd250
+	sigs.k8s.io/yaml v1.2.0
 )
 
 require (
 	cloud.google.com/go v0.54.0 // indirect
 	github.com/beorn7/perks v1.0.1 // indirect
 	github.com/blang/semver v3.5.1+incompatible // indirect
-	github.com/blang/semver/v4 v4.0.0 // indirect
 	github.com/cespare/xxhash/v2 v2.1.1 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/evanphx/json-patch v4.11.0+incompatible // indirect
@@ -48,7 +50,6 @@ require (
 	github.com/modern-go/reflect2 v1.0.1 // indirect
 	github.com/nxadm/tail v1.4.8 // indirect
 	github.com/operator-framework/operator-registry v1.13.6 // indirect
-	github.com/prometheus/client_golang v1.11.0 // indirect
 	github.com/prometheus/client_model v0.2.0 // indirect
 	github.com/prometheus/common v0.26.0 // indirect
 	github.com/prometheus/procfs v0.6.0 // indirect
@@ -78,7 +79,6 @@ require (
 	k8s.io/kube-openapi v0.0.0-20210305001622-591a79e4bda7 // indirect
 	k8s.io/utils v0.0.0-20210722164352-7f3ee0f31471 // indirect
 	sigs.k8s.io/structured-merge-diff/v4 v4.1.2 // indirect
-	sigs.k8s.io/yaml v1.2.0 // indirect
 )
 
 // fix vulnerability: CVE-2021-3121 in github.com/gogo/protobuf v1.2.1
@@ -17,6 +17,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
 	"strings"
@@ -25,6 +26,7 @@ import (
 	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	operatorsv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -38,13 +40,19 @@ import (
 	nssv1 "github.com/IBM/ibm-namespace-scope-operator/api/v1"
 
 	operatorv1alpha1 "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/backup"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/catalog"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/clusteroperandrequest"
 	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/constant"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/garbagecollector"
 	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/k8sutil"
 	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/namespacescope"
 	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/operandbindinfo"
 	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/operandconfig"
 	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/operandregistry"
 	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/operandrequest"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/operandrequesttemplate"
+	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/operandstatus"
 	deploy "github.com/IBM/operand-deployment-lifecycle-manager/controllers/operator"
 	"github.com/IBM/operand-deployment-lifecycle-manager/controllers/util"
 	// +kubebuilder:scaffold:imports
@@ -68,6 +76,27 @@ func init() {
 func main() {
 	klog.InitFlags(nil)
 	defer klog.Flush()
+
+	// Back up or restore the state managed by ODLM instead of running the manager
+	if len(os.Args) > 1 && (os.Args[1] == backup.CommandBackup || os.Args[1] == backup.CommandRestore) {
+		if err := backup.RunCommand(os.Args[1], os.Args[2:], scheme); err != nil {
+			klog.Errorf("%s failed: %v", os.Args[1], err)
+			klog.Flush()
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Generate an OperandRegistry and an OperandConfig from a catalog instead of running the manager
+	if len(os.Args) > 1 && os.Args[1] == catalog.CommandGenerate {
+		if err := catalog.RunCommand(os.Args[2:]); err != nil {
+			klog.Errorf("%s failed: %v", os.Args[1], err)
+			klog.Flush()
+			os.Exit(1)
+		}
+		return
+	}
+
 	var metricsAddr string
 	var probeAddr string
 	var enableLeaderElection bool
@@ -78,8 +107,44 @@ func main() {
 			"Enabling this will ensure there is only one active controller manager.")
 	var stepSize = flag.Int("batch-chunk-size", 1, "batch-chunk-size is used to control at most how many subscriptions will be created concurrently")
 
+	var gcInterval = flag.Duration("gc-interval", constant.DefaultGCInterval, "gc-interval is the period of sweeping the orphaned resources labeled by ODLM, 0 disables the garbage collector")
+	var gcGracePeriod = flag.Duration("gc-grace-period", constant.DefaultGCGracePeriod, "gc-grace-period is how long a resource must stay orphaned before the garbage collector deletes it")
+	var gcDryRun = flag.Bool("gc-dry-run", true, "gc-dry-run only reports the orphaned resources with events without deleting them")
+
+	var crDeleteTimeout = flag.Duration(deploy.CRDeleteTimeoutKey, constant.DefaultCRDeleteTimeout, "cr-delete-timeout is how long to wait for a custom resource or a k8s resource to be deleted")
+	var subDeleteTimeout = flag.Duration(deploy.SubDeleteTimeoutKey, constant.DefaultSubDeleteTimeout, "sub-delete-timeout is how long to wait for a Subscription, a ClusterServiceVersion or a ClusterExtension to be deleted")
+	var requeueDuration = flag.Duration(deploy.RequeueDurationKey, constant.DefaultRequeueDuration, "requeue-duration is how long to wait before reconciling a resource that is not ready yet")
+	var syncPeriod = flag.Duration(deploy.SyncPeriodKey, constant.DefaultSyncPeriod, "sync-period is how often a ready OperandRequest is reconciled")
+	var conflictPolicy = flag.String(deploy.ConflictPolicyKey, string(deploy.ConflictPolicyOldest), "registry-conflict-policy chooses the OperandRegistry managing an operator requested from several OperandRegistries, one of Oldest, Priority or Refuse")
 	flag.Parse()
 
+	// The timeouts set on the command line take precedence over the ones in the ODLM ConfigMap
+	flagTimeouts := &operatorv1alpha1.Timeouts{}
+	var conflictPolicyFlag string
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case deploy.CRDeleteTimeoutKey:
+			flagTimeouts.CRDeleteTimeout = &metav1.Duration{Duration: *crDeleteTimeout}
+		case deploy.SubDeleteTimeoutKey:
+			flagTimeouts.SubDeleteTimeout = &metav1.Duration{Duration: *subDeleteTimeout}
+		case deploy.RequeueDurationKey:
+			flagTimeouts.RequeueDuration = &metav1.Duration{Duration: *requeueDuration}
+		case deploy.SyncPeriodKey:
+			flagTimeouts.SyncPeriod = &metav1.Duration{Duration: *syncPeriod}
+		case deploy.ConflictPolicyKey:
+			conflictPolicyFlag = *conflictPolicy
+		}
+	})
+	if err := deploy.ValidateTimeouts(flagTimeouts); err != nil {
+		klog.Errorf("invalid timeout flag: %v", err)
+		os.Exit(1)
+	}
+	flagConflictPolicy, err := deploy.ParseConflictPolicy(conflictPolicyFlag)
+	if err != nil {
+		klog.Errorf("invalid conflict policy flag: %v", err)
+		os.Exit(1)
+	}
+
 	gvkLabelMap := map[schema.GroupVersionKind]cache.Selector{
 		corev1.SchemeGroupVersion.WithKind("Secret"): {
 			LabelSelector: constant.OpbiTypeLabel,
@@ -107,35 +172,94 @@ func main() {
 		klog.Errorf("unable to start manager: %v", err)
 		os.Exit(1)
 	}
+	configTimeouts, err := deploy.LoadTimeouts(context.TODO(), mgr.GetAPIReader(), util.GetOperatorNamespace())
+	if err != nil {
+		klog.Errorf("unable to load the timeouts: %v", err)
+		os.Exit(1)
+	}
+	// The timeouts are read once, a change of the ODLM ConfigMap takes effect when ODLM restarts
+	timeouts := operatorv1alpha1.Timeouts{}.Merge(configTimeouts).Merge(flagTimeouts)
+	configConflictPolicy, err := deploy.LoadConflictPolicy(context.TODO(), mgr.GetAPIReader(), util.GetOperatorNamespace())
+	if err != nil {
+		klog.Errorf("unable to load the conflict policy: %v", err)
+		os.Exit(1)
+	}
+	registryConflictPolicy := configConflictPolicy
+	if flagConflictPolicy != "" {
+		registryConflictPolicy = flagConflictPolicy
+	}
+
+	newODLMOperator := func(name string) *deploy.ODLMOperator {
+		m := deploy.NewODLMOperator(mgr, name)
+		m.Timeouts = &timeouts
+		m.ConflictPolicy = registryConflictPolicy
+		return m
+	}
+
+	// The CatalogSources of all the namespaces are only cached out of the isolated mode
+	if !isolatedModeEnable {
+		if err = deploy.SetupPackageManifestCache(mgr); err != nil {
+			klog.Errorf("unable to set up PackageManifest cache: %v", err)
+			os.Exit(1)
+		}
+	}
 	if err = (&operandrequest.Reconciler{
-		ODLMOperator: deploy.NewODLMOperator(mgr, "OperandRequest"),
+		ODLMOperator: newODLMOperator("OperandRequest"),
 		StepSize:     *stepSize,
 	}).SetupWithManager(mgr); err != nil {
 		klog.Errorf("unable to create controller OperandRequest: %v", err)
 		os.Exit(1)
 	}
 	if err = (&operandconfig.Reconciler{
-		ODLMOperator: deploy.NewODLMOperator(mgr, "OperandConfig"),
+		ODLMOperator: newODLMOperator("OperandConfig"),
 	}).SetupWithManager(mgr); err != nil {
 		klog.Errorf("unable to create controller OperandConfig: %v", err)
 		os.Exit(1)
 	}
 	if err = (&operandbindinfo.Reconciler{
-		ODLMOperator: deploy.NewODLMOperator(mgr, "OperandBindInfo"),
+		ODLMOperator: newODLMOperator("OperandBindInfo"),
 	}).SetupWithManager(mgr); err != nil {
 		klog.Errorf("unable to create controller OperandBindInfo: %v", err)
 		os.Exit(1)
 	}
 	if err = (&operandregistry.Reconciler{
-		ODLMOperator: deploy.NewODLMOperator(mgr, "OperandRegistry"),
+		ODLMOperator: newODLMOperator("OperandRegistry"),
 	}).SetupWithManager(mgr); err != nil {
 		klog.Errorf("unable to create controller OperandRegistry: %v", err)
 		os.Exit(1)
 	}
+	if err = (&clusteroperandrequest.Reconciler{
+		ODLMOperator: newODLMOperator("ClusterOperandRequest"),
+	}).SetupWithManager(mgr); err != nil {
+		klog.Errorf("unable to create controller ClusterOperandRequest: %v", err)
+		os.Exit(1)
+	}
+	if err = (&operandrequesttemplate.Reconciler{
+		ODLMOperator: newODLMOperator("OperandRequestTemplate"),
+	}).SetupWithManager(mgr); err != nil {
+		klog.Errorf("unable to create controller OperandRequestTemplate: %v", err)
+		os.Exit(1)
+	}
+	if err = (&operandstatus.Reconciler{
+		ODLMOperator: newODLMOperator("OperandStatus"),
+	}).SetupWithManager(mgr); err != nil {
+		klog.Errorf("unable to create controller OperandStatus: %v", err)
+		os.Exit(1)
+	}
+	if err = (&garbagecollector.Collector{
+		ODLMOperator: newODLMOperator("GarbageCollector"),
+		Interval:     *gcInterval,
+		GracePeriod:  *gcGracePeriod,
+		DryRun:       *gcDryRun,
+		Namespaces:   strings.Split(watchNamespace, ","),
+	}).SetupWithManager(mgr); err != nil {
+		klog.Errorf("unable to create garbage collector: %v", err)
+		os.Exit(1)
+	}
 	// Single instance case, disable it on SaaS or on-prem multi instances case
 	if !isolatedModeEnable {
 		if err = (&namespacescope.Reconciler{
-			ODLMOperator: deploy.NewODLMOperator(mgr, "NamespaceScope"),
+			ODLMOperator: newODLMOperator("NamespaceScope"),
 		}).SetupWithManager(mgr); err != nil {
 			klog.Errorf("unable to create controller NamespaceScope: %v", err)
 			os.Exit(1)